@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"agent/bridge"
+	"agent/contracts"
+	"agent/multichain"
+	"agent/state"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// accountsBucket holds the smart accounts registered by deploy-account, keyed by chain ID
+const accountsBucket = "smart_accounts"
+
+// AccountDeployment is a smart account registered for one chain
+type AccountDeployment struct {
+	Address  common.Address `json:"address"`
+	Contract string         `json:"contract"` // SmartAccount or SmartAccountV2
+	Owner    common.Address `json:"owner"`
+	Salt     common.Hash    `json:"salt"`
+	TxHash   common.Hash    `json:"txHash"` // zero if the account was already deployed
+}
+
+// loadRegisteredAccounts adds the accounts deploy-account registered for chains the environment does not configure
+func (s *SentinelAgent) loadRegisteredAccounts() error {
+	keys, err := s.store.Keys(accountsBucket)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		chainID, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			continue
+		}
+		if common.IsHexAddress(s.config.SmartAccounts[chainID]) {
+			continue
+		}
+		var deployment AccountDeployment
+		if _, err := s.store.Get(accountsBucket, key, &deployment); err != nil {
+			return err
+		}
+		s.config.SmartAccounts[chainID] = deployment.Address.Hex()
+	}
+	return nil
+}
+
+// expectedOwner is OWNER if set, otherwise the address of the owner key
+func (s *SentinelAgent) expectedOwner() (common.Address, error) {
+	if common.IsHexAddress(s.config.Owner) {
+		return common.HexToAddress(s.config.Owner), nil
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(s.config.OwnerKey, "0x"))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("set OWNER or OWNER_PRIVATE_KEY: %v", err)
+	}
+	return crypto.PubkeyToAddress(key.PublicKey), nil
+}
+
+// verifyAccounts refuses to start unless every bound smart account is owned
+// by the expected owner and the agent's key may execute through it
+func (s *SentinelAgent) verifyAccounts(ctx context.Context) error {
+	if len(s.accounts) == 0 {
+		return nil
+	}
+	expected, err := s.expectedOwner()
+	if err != nil {
+		return err
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(s.config.PrivateKey, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid private key: %v", err)
+	}
+	agent := crypto.PubkeyToAddress(key.PublicKey)
+
+	for chainID, account := range s.accounts {
+		owner, err := account.Owner(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read owner of %s on chain %d: %v", account.Address.Hex(), chainID, err)
+		}
+		if owner != expected {
+			return fmt.Errorf("smart account %s on chain %d is owned by %s, expected %s", account.Address.Hex(), chainID, owner.Hex(), expected.Hex())
+		}
+
+		// Session keys are authorized and rotated by the session manager
+		if s.sessions != nil || agent == owner {
+			continue
+		}
+		expiry, err := account.SessionKeyExpiry(ctx, agent)
+		if err != nil {
+			return fmt.Errorf("failed to read session key of %s on chain %d: %v", agent.Hex(), chainID, err)
+		}
+		if !expiry.After(time.Now()) {
+			return fmt.Errorf("%s is neither the owner of %s on chain %d nor an authorized session key", agent.Hex(), account.Address.Hex(), chainID)
+		}
+	}
+	log.Printf("🔐 Verified ownership of %d smart accounts by %s", len(s.accounts), expected.Hex())
+	return nil
+}
+
+// deployAccounts is the CLI step that deploys the smart account through the
+// CREATE2 deployer on every connected chain, registers it per chain and,
+// for SmartAccountV2, links the deployments with addSupportedChain
+func deployAccounts() error {
+	ctx := context.Background()
+	s := NewSentinelAgent()
+	s.config = s.loadConfiguration()
+
+	var err error
+	s.store, err = state.Open(s.config.StateDir)
+	if err != nil {
+		return fmt.Errorf("failed to open state store: %v", err)
+	}
+	s.multiChainManager = multichain.NewMultiChainManager()
+	if err := s.multiChainManager.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize multi-chain manager: %v", err)
+	}
+
+	contract := getEnvOrDefault("ACCOUNT_CONTRACT", "SmartAccountV2")
+	if contract != "SmartAccount" && contract != "SmartAccountV2" {
+		return fmt.Errorf("ACCOUNT_CONTRACT must be SmartAccount or SmartAccountV2, got %q", contract)
+	}
+	artifact := getEnvOrDefault("ACCOUNT_ARTIFACT", filepath.Join("..", "blockchain", "out", contract+".sol", contract+".json"))
+	bytecode, err := contracts.LoadArtifact(artifact)
+	if err != nil {
+		return fmt.Errorf("%v; run forge build in blockchain/ or set ACCOUNT_ARTIFACT", err)
+	}
+
+	owner, err := s.expectedOwner()
+	if err != nil {
+		return err
+	}
+	initCode, err := contracts.InitCode(bytecode, owner)
+	if err != nil {
+		return err
+	}
+	salt := contracts.DeploymentSalt(getEnvOrDefault("ACCOUNT_SALT", "sentinel-agent"))
+	address := contracts.DeterministicAddress(salt, initCode)
+
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(s.config.PrivateKey, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid private key: %v", err)
+	}
+	deployer := bridge.NewKeyedSender(key, s.multiChainManager.GetClient)
+
+	chainIDs := parseChainIDs(os.Getenv("ACCOUNT_CHAINS"))
+	if len(chainIDs) == 0 {
+		for _, chain := range s.multiChainManager.GetSupportedChains() {
+			if _, err := s.multiChainManager.GetClient(chain.ChainID); err == nil {
+				chainIDs = append(chainIDs, chain.ChainID)
+			}
+		}
+	}
+
+	log.Printf("🚀 Deploying %s for %s at %s on chains %v", contract, owner.Hex(), address.Hex(), chainIDs)
+	deployed := make([]uint64, 0, len(chainIDs))
+	for _, chainID := range chainIDs {
+		deployment, err := s.deployAccount(ctx, deployer, chainID, salt, initCode)
+		if err != nil {
+			log.Printf("⚠️  Failed to deploy on chain %d: %v", chainID, err)
+			continue
+		}
+		deployment.Contract = contract
+		deployment.Owner = owner
+		if err := s.store.Put(accountsBucket, strconv.FormatUint(chainID, 10), deployment); err != nil {
+			return fmt.Errorf("failed to register account on chain %d: %v", chainID, err)
+		}
+		deployed = append(deployed, chainID)
+	}
+	if len(deployed) == 0 {
+		return fmt.Errorf("no chain could be deployed to")
+	}
+
+	if contract == "SmartAccountV2" {
+		if err := s.linkAccounts(ctx, address, deployed); err != nil {
+			return err
+		}
+	}
+	if err := s.store.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Smart account %s registered on chains %v\n", address.Hex(), deployed)
+	fmt.Printf("   SMART_ACCOUNT=%s\n", address.Hex())
+	return nil
+}
+
+// deployAccount deploys initCode through the CREATE2 deployer unless the chain already has the account
+func (s *SentinelAgent) deployAccount(ctx context.Context, deployer bridge.Sender, chainID uint64, salt common.Hash, initCode []byte) (*AccountDeployment, error) {
+	client, err := s.multiChainManager.GetClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+	deployment := &AccountDeployment{Address: contracts.DeterministicAddress(salt, initCode), Salt: salt}
+
+	code, err := client.CodeAt(ctx, deployment.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read code at %s: %v", deployment.Address.Hex(), err)
+	}
+	if len(code) > 0 {
+		log.Printf("🔐 Smart account already deployed on chain %d", chainID)
+		return deployment, nil
+	}
+
+	factory, err := client.CodeAt(ctx, contracts.DeterministicDeployer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read code at %s: %v", contracts.DeterministicDeployer.Hex(), err)
+	}
+	if len(factory) == 0 {
+		return nil, fmt.Errorf("no CREATE2 deployer at %s", contracts.DeterministicDeployer.Hex())
+	}
+
+	receipt, err := deployer.Send(ctx, chainID, contracts.DeterministicDeployer, contracts.DeploymentData(salt, initCode), nil)
+	if err != nil {
+		return nil, err
+	}
+	code, err = client.CodeAt(ctx, deployment.Address, nil)
+	if err != nil || len(code) == 0 {
+		return nil, fmt.Errorf("deployment %s left no code at %s", receipt.TxHash.Hex(), deployment.Address.Hex())
+	}
+	deployment.TxHash = receipt.TxHash
+	log.Printf("✅ Deployed smart account on chain %d in %s", chainID, receipt.TxHash.Hex())
+	return deployment, nil
+}
+
+// linkAccounts registers every deployment as the cross-chain contract of the
+// others; this needs the owner key, so it is skipped when the owner is offline
+func (s *SentinelAgent) linkAccounts(ctx context.Context, address common.Address, chainIDs []uint64) error {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(s.config.OwnerKey, "0x"))
+	if err != nil {
+		log.Printf("⚠️  No owner key; run addSupportedChain from the owner to link chains %v", chainIDs)
+		return nil
+	}
+	owner := bridge.NewKeyedSender(key, s.multiChainManager.GetClient)
+
+	for _, chainID := range chainIDs {
+		client, err := s.multiChainManager.GetClient(chainID)
+		if err != nil {
+			return err
+		}
+		account, err := contracts.NewSmartAccountClient(ctx, address, client)
+		if err != nil {
+			return err
+		}
+		current, err := account.Owner(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read owner on chain %d: %v", chainID, err)
+		}
+		if current != owner.Address() {
+			log.Printf("⚠️  OWNER_PRIVATE_KEY does not own the account on chain %d; skipping addSupportedChain", chainID)
+			continue
+		}
+
+		for _, remote := range chainIDs {
+			if remote == chainID {
+				continue
+			}
+			registered, err := account.CrossChainContract(ctx, remote)
+			if err != nil {
+				return fmt.Errorf("failed to read chain %d on chain %d: %v", remote, chainID, err)
+			}
+			if registered == address {
+				continue
+			}
+			data, err := contracts.PackAddSupportedChain(remote, address)
+			if err != nil {
+				return err
+			}
+			if _, err := owner.Send(ctx, chainID, address, data, nil); err != nil {
+				return fmt.Errorf("addSupportedChain(%d) on chain %d failed: %v", remote, chainID, err)
+			}
+			log.Printf("🔗 Linked chain %d to the account on chain %d", remote, chainID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"agent/multichain"
+)
+
+// serveAPI serves portfolio history and performance to the dashboard on
+// APIListen until ctx is cancelled. Ranges are given as RFC 3339 from and to
+// query parameters and default to all history up to now.
+func (s *SentinelAgent) serveAPI(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/history", s.handleHistory)
+	mux.HandleFunc("GET /api/performance", s.handlePerformance)
+
+	server := &http.Server{Addr: s.config.APIListen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("🌐 Agent API listening on %s", s.config.APIListen)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("⚠️  Agent API stopped: %v", err)
+	}
+}
+
+func (s *SentinelAgent) handleHistory(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	snapshots, err := s.history.GetSnapshots(from, to)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, snapshots)
+}
+
+func (s *SentinelAgent) handlePerformance(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	report, err := s.history.GetPerformance(r.Context(), from, to)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, struct {
+		TotalChange string `json:"totalChange"`
+		*multichain.PerformanceReport
+	}{report.TotalChange(), report})
+}
+
+func parseRange(r *http.Request) (from, to time.Time, err error) {
+	to = time.Now()
+	if value := r.URL.Query().Get("from"); value != "" {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			return from, to, err
+		}
+	}
+	if value := r.URL.Query().Get("to"); value != "" {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			return from, to, err
+		}
+	}
+	return from, to, nil
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("⚠️  Failed to write API response: %v", err)
+	}
+}
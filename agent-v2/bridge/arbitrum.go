@@ -0,0 +1,326 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ArbRetryableTx is the ArbOS precompile managing retryable tickets
+var ArbRetryableTx = common.HexToAddress("0x000000000000000000000000000000000000006E")
+
+const (
+	// arbDepositGasLimit is the L2 gas granted to auto-redeem a deposit ticket
+	arbDepositGasLimit = 300000
+
+	// arbGatewayDataSize bounds the calldata the token gateway forwards to L2,
+	// which sets the submission fee of ERC-20 deposits
+	arbGatewayDataSize = 1500
+
+	// l1MessageTypeSubmitRetryable is the inbox message kind of a retryable ticket
+	l1MessageTypeSubmitRetryable = 9
+
+	// arbSubmitRetryableTxType is the transaction type ArbOS uses for ticket creation
+	arbSubmitRetryableTxType = 0x69
+)
+
+const arbitrumABI = `[
+	{"inputs":[{"internalType":"uint256","name":"dataLength","type":"uint256"},{"internalType":"uint256","name":"baseFee","type":"uint256"}],"name":"calculateRetryableSubmissionFee","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"l2CallValue","type":"uint256"},{"internalType":"uint256","name":"maxSubmissionCost","type":"uint256"},{"internalType":"address","name":"excessFeeRefundAddress","type":"address"},{"internalType":"address","name":"callValueRefundAddress","type":"address"},{"internalType":"uint256","name":"gasLimit","type":"uint256"},{"internalType":"uint256","name":"maxFeePerGas","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"createRetryableTicket","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"address","name":"_token","type":"address"}],"name":"getGateway","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"address","name":"_token","type":"address"},{"internalType":"address","name":"_to","type":"address"},{"internalType":"uint256","name":"_amount","type":"uint256"},{"internalType":"uint256","name":"_maxGas","type":"uint256"},{"internalType":"uint256","name":"_gasPriceBid","type":"uint256"},{"internalType":"bytes","name":"_data","type":"bytes"}],"name":"outboundTransfer","outputs":[{"internalType":"bytes","name":"","type":"bytes"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"bytes32","name":"ticketId","type":"bytes32"}],"name":"getTimeout","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"bytes32","name":"ticketId","type":"bytes32"}],"name":"redeem","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"nonpayable","type":"function"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"uint256","name":"messageNum","type":"uint256"},{"indexed":false,"internalType":"bytes","name":"data","type":"bytes"}],"name":"InboxMessageDelivered","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"uint256","name":"messageIndex","type":"uint256"},{"indexed":true,"internalType":"bytes32","name":"beforeInboxAcc","type":"bytes32"},{"indexed":false,"internalType":"address","name":"inbox","type":"address"},{"indexed":false,"internalType":"uint8","name":"kind","type":"uint8"},{"indexed":false,"internalType":"address","name":"sender","type":"address"},{"indexed":false,"internalType":"bytes32","name":"messageDataHash","type":"bytes32"},{"indexed":false,"internalType":"uint256","name":"baseFeeL1","type":"uint256"},{"indexed":false,"internalType":"uint64","name":"timestamp","type":"uint64"}],"name":"MessageDelivered","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"ticketId","type":"bytes32"},{"indexed":true,"internalType":"bytes32","name":"retryTxHash","type":"bytes32"},{"indexed":true,"internalType":"uint64","name":"sequenceNum","type":"uint64"},{"indexed":false,"internalType":"uint64","name":"donatedGas","type":"uint64"},{"indexed":false,"internalType":"address","name":"gasDonor","type":"address"},{"indexed":false,"internalType":"uint256","name":"maxRefund","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"submissionFeeRefund","type":"uint256"}],"name":"RedeemScheduled","type":"event"}
+]`
+
+var arbABI = mustParseABI(arbitrumABI)
+
+// ArbitrumChain holds the L1 contracts of an Arbitrum chain
+type ArbitrumChain struct {
+	Inbox         common.Address
+	Bridge        common.Address
+	GatewayRouter common.Address
+}
+
+// ArbitrumBridge deposits through the canonical Arbitrum bridge using retryable
+// tickets. Like OPStackBridge it only supports L1 -> L2.
+type ArbitrumBridge struct {
+	l1ChainID uint64
+	chains    map[uint64]*ArbitrumChain
+	clients   ClientSource
+}
+
+// NewArbitrumBridge returns a bridge for Arbitrum One deposits from Ethereum
+func NewArbitrumBridge(clients ClientSource) *ArbitrumBridge {
+	return &ArbitrumBridge{
+		l1ChainID: 1,
+		chains: map[uint64]*ArbitrumChain{
+			42161: {
+				Inbox:         common.HexToAddress("0x4Dbd4fc535Ac27206064B68FfCf827b0A60BAB3f"),
+				Bridge:        common.HexToAddress("0x8315177aB297bA92A06054cE80a67Ed4DBd7ed3a"),
+				GatewayRouter: common.HexToAddress("0x72Ce9c846789fdB6fC1f34aC4AD25Dd9ef7031ef"),
+			},
+		},
+		clients: clients,
+	}
+}
+
+func (b *ArbitrumBridge) Name() string {
+	return "arbitrum"
+}
+
+func (b *ArbitrumBridge) Supports(fromChainID, toChainID uint64) bool {
+	_, exists := b.chains[toChainID]
+	return fromChainID == b.l1ChainID && exists
+}
+
+// Quote prices the retryable ticket: a submission fee for the L1 data plus
+// prepaid L2 gas for the auto-redeem, both with headroom for fee movements
+func (b *ArbitrumBridge) Quote(ctx context.Context, req *TransferRequest) (*Quote, error) {
+	chain, exists := b.chains[req.ToChainID]
+	if !exists || req.FromChainID != b.l1ChainID {
+		return nil, fmt.Errorf("route %d -> %d not supported", req.FromChainID, req.ToChainID)
+	}
+	l1, err := b.clients(req.FromChainID)
+	if err != nil {
+		return nil, err
+	}
+	l2, err := b.clients(req.ToChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := l1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 header: %v", err)
+	}
+	l2GasPrice, err := l2.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 gas price: %v", err)
+	}
+	maxFeePerGas := new(big.Int).Mul(l2GasPrice, big.NewInt(2))
+
+	dataSize := int64(0)
+	if req.Token != NativeToken {
+		dataSize = arbGatewayDataSize
+	}
+	out, err := callView(ctx, l1, arbABI, chain.Inbox, "calculateRetryableSubmissionFee", big.NewInt(dataSize), header.BaseFee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission fee: %v", err)
+	}
+	submissionCost := new(big.Int).Mul(out[0].(*big.Int), big.NewInt(2))
+
+	gasLimit := big.NewInt(arbDepositGasLimit)
+	fee := new(big.Int).Mul(gasLimit, maxFeePerGas)
+	fee.Add(fee, submissionCost)
+
+	quote := &Quote{
+		Bridge:            b.Name(),
+		Request:           req,
+		AmountOut:         req.Amount,
+		NativeFee:         fee,
+		EstimatedDuration: 15 * time.Minute,
+	}
+
+	if req.Token == NativeToken {
+		data, err := arbABI.Pack("createRetryableTicket", req.Recipient, req.Amount, submissionCost,
+			req.Sender, req.Sender, gasLimit, maxFeePerGas, []byte{})
+		if err != nil {
+			return nil, err
+		}
+		quote.Tx = &Call{To: chain.Inbox, Data: data, Value: new(big.Int).Add(req.Amount, fee)}
+		return quote, nil
+	}
+
+	out, err = callView(ctx, l1, arbABI, chain.GatewayRouter, "getGateway", req.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token gateway: %v", err)
+	}
+	gatewayData, err := gatewayDataArgs.Pack(submissionCost, []byte{})
+	if err != nil {
+		return nil, err
+	}
+	data, err := arbABI.Pack("outboundTransfer", req.Token, req.Recipient, req.Amount, gasLimit, maxFeePerGas, gatewayData)
+	if err != nil {
+		return nil, err
+	}
+	quote.Tx = &Call{To: chain.GatewayRouter, Data: data, Value: fee}
+	quote.Approval = out[0].(common.Address)
+	return quote, nil
+}
+
+// gatewayDataArgs is the (maxSubmissionCost, callHookData) tuple the token gateway expects
+var gatewayDataArgs = func() abi.Arguments {
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	return abi.Arguments{{Type: uint256Type}, {Type: bytesType}}
+}()
+
+// Initiate sends the deposit and derives the L2 ticket ID from the inbox message
+func (b *ArbitrumBridge) Initiate(ctx context.Context, quote *Quote, sender Sender) (*Transfer, error) {
+	transfer, receipt, err := initiate(ctx, b.clients, quote, sender)
+	if err != nil {
+		return nil, err
+	}
+	chain := b.chains[transfer.ToChainID]
+
+	type delivered struct {
+		Inbox           common.Address
+		Kind            uint8
+		Sender          common.Address
+		MessageDataHash [32]byte
+		BaseFeeL1       *big.Int
+		Timestamp       uint64
+	}
+	messages := make(map[common.Hash]*delivered)
+	inboxData := make(map[common.Hash][]byte)
+
+	for _, entry := range receipt.Logs {
+		if len(entry.Topics) < 2 {
+			continue
+		}
+		switch {
+		case entry.Address == chain.Bridge && entry.Topics[0] == arbABI.Events["MessageDelivered"].ID:
+			var message delivered
+			if err := arbABI.UnpackIntoInterface(&message, "MessageDelivered", entry.Data); err != nil {
+				return nil, fmt.Errorf("failed to decode MessageDelivered: %v", err)
+			}
+			messages[entry.Topics[1]] = &message
+		case entry.Address == chain.Inbox && entry.Topics[0] == arbABI.Events["InboxMessageDelivered"].ID:
+			out, err := arbABI.Unpack("InboxMessageDelivered", entry.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode InboxMessageDelivered: %v", err)
+			}
+			inboxData[entry.Topics[1]] = out[0].([]byte)
+		}
+	}
+
+	for messageNum, message := range messages {
+		data, exists := inboxData[messageNum]
+		if !exists || message.Kind != l1MessageTypeSubmitRetryable {
+			continue
+		}
+		ticketID, err := retryableTicketID(new(big.Int).SetUint64(transfer.ToChainID), messageNum, message.Sender, message.BaseFeeL1, data)
+		if err != nil {
+			return nil, err
+		}
+		transfer.Data["ticketId"] = ticketID.Hex()
+		return transfer, nil
+	}
+	return nil, fmt.Errorf("deposit %s created no retryable ticket", receipt.TxHash.Hex())
+}
+
+// retryableTicketID reproduces the hash of the ArbitrumSubmitRetryableTx that
+// ArbOS creates for an inbox message, which is the ticket ID on L2
+func retryableTicketID(chainID *big.Int, messageNum common.Hash, sender common.Address, baseFee *big.Int, data []byte) (common.Hash, error) {
+	const words = 9
+	if len(data) < words*32 {
+		return common.Hash{}, fmt.Errorf("retryable message too short: %d bytes", len(data))
+	}
+	word := func(i int) *big.Int { return new(big.Int).SetBytes(data[i*32 : (i+1)*32]) }
+	address := func(i int) common.Address { return common.BytesToAddress(data[i*32 : (i+1)*32]) }
+
+	dataLength := word(8).Uint64()
+	if uint64(len(data)) < words*32+dataLength {
+		return common.Hash{}, fmt.Errorf("retryable message truncated")
+	}
+
+	var destination interface{} = address(0)
+	if address(0) == (common.Address{}) {
+		destination = []byte{}
+	}
+
+	encoded, err := rlp.EncodeToBytes([]interface{}{
+		chainID,
+		messageNum,
+		sender,
+		baseFee,
+		word(2), // deposit value
+		word(7), // max fee per gas
+		word(6), // gas limit
+		destination,
+		word(1), // L2 call value
+		address(5),
+		word(3), // max submission fee
+		address(4),
+		data[words*32 : words*32+dataLength],
+	})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(append([]byte{arbSubmitRetryableTxType}, encoded...)), nil
+}
+
+// Status follows the ticket on L2: created, then redeemed automatically or,
+// if the auto-redeem ran out of gas, waiting for a manual redeem
+func (b *ArbitrumBridge) Status(ctx context.Context, transfer *Transfer) (Status, error) {
+	l2, err := b.clients(transfer.ToChainID)
+	if err != nil {
+		return transfer.Status, err
+	}
+	ticketID := common.HexToHash(transfer.Data["ticketId"])
+
+	creation, err := l2.TransactionReceipt(ctx, ticketID)
+	if errors.Is(err, ethereum.NotFound) {
+		return StatusPending, nil
+	}
+	if err != nil {
+		return transfer.Status, fmt.Errorf("failed to get ticket receipt: %v", err)
+	}
+	if creation.Status != 1 {
+		transfer.Error = "retryable ticket creation failed"
+		return StatusFailed, nil
+	}
+
+	redeems := []common.Hash{}
+	if transfer.DestinationTxHash != (common.Hash{}) {
+		redeems = append(redeems, transfer.DestinationTxHash)
+	}
+	for _, entry := range creation.Logs {
+		if entry.Address == ArbRetryableTx && len(entry.Topics) >= 3 && entry.Topics[0] == arbABI.Events["RedeemScheduled"].ID {
+			redeems = append(redeems, entry.Topics[2])
+		}
+	}
+	for _, hash := range redeems {
+		receipt, err := l2.TransactionReceipt(ctx, hash)
+		if err == nil && receipt.Status == 1 {
+			transfer.DestinationTxHash = hash
+			return StatusCompleted, nil
+		}
+	}
+
+	if _, err := callView(ctx, l2, arbABI, ArbRetryableTx, "getTimeout", ticketID); err == nil {
+		return StatusClaimable, nil
+	}
+	transfer.Error = "retryable ticket no longer exists and no successful redeem was found"
+	return StatusFailed, nil
+}
+
+// Claim manually redeems a ticket whose auto-redeem failed
+func (b *ArbitrumBridge) Claim(ctx context.Context, transfer *Transfer, sender Sender) error {
+	data, err := arbABI.Pack("redeem", common.HexToHash(transfer.Data["ticketId"]))
+	if err != nil {
+		return err
+	}
+	receipt, err := sender.Send(ctx, transfer.ToChainID, ArbRetryableTx, data, nil)
+	if err != nil {
+		return err
+	}
+	for _, entry := range receipt.Logs {
+		if entry.Address == ArbRetryableTx && len(entry.Topics) >= 3 && entry.Topics[0] == arbABI.Events["RedeemScheduled"].ID {
+			transfer.DestinationTxHash = entry.Topics[2]
+		}
+	}
+	return nil
+}
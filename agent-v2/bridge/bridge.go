@@ -0,0 +1,311 @@
+package bridge
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"agent/contracts"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// NativeToken is the placeholder address for a chain's native currency
+var NativeToken = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+// Status is the lifecycle stage of a cross-chain transfer
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // initiated, waiting for the destination chain
+	StatusClaimable Status = "claimable" // arrived but needs a claim transaction to release funds
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Terminal reports whether a transfer in this status needs no further tracking
+func (s Status) Terminal() bool {
+	return s == StatusCompleted || s == StatusFailed
+}
+
+// ErrNothingToClaim is returned by Claim for bridges that release funds automatically
+var ErrNothingToClaim = errors.New("transfer does not need a claim")
+
+// TransferRequest describes moving an amount of a token between two chains
+type TransferRequest struct {
+	FromChainID uint64
+	ToChainID   uint64
+	Token       common.Address // token on the source chain
+	TokenOut    common.Address // the same asset on the destination chain
+	Amount      *big.Int
+	Sender      common.Address
+	Recipient   common.Address
+}
+
+// Call is a transaction a bridge needs sent on the source chain
+type Call struct {
+	To    common.Address
+	Data  []byte
+	Value *big.Int
+}
+
+// Quote is the expected outcome of a transfer through one bridge
+type Quote struct {
+	Bridge            string
+	Request           *TransferRequest
+	AmountOut         *big.Int       // tokens received on the destination chain
+	NativeFee         *big.Int       // source-chain native currency paid on top of Amount
+	EstimatedDuration time.Duration  // typical time until funds are usable on the destination
+	Approval          common.Address // spender that needs an allowance of Amount, zero if none
+	Tx                *Call
+}
+
+// Transfer is an initiated cross-chain transfer, persisted until it settles
+type Transfer struct {
+	ID                string            `json:"id"`
+	Bridge            string            `json:"bridge"`
+	FromChainID       uint64            `json:"fromChainId"`
+	ToChainID         uint64            `json:"toChainId"`
+	Token             common.Address    `json:"token"`
+	TokenOut          common.Address    `json:"tokenOut"`
+	Amount            *big.Int          `json:"amount"`
+	ExpectedOut       *big.Int          `json:"expectedOut"`
+	Recipient         common.Address    `json:"recipient"`
+	SourceTxHash      common.Hash       `json:"sourceTxHash"`
+	DestinationTxHash common.Hash       `json:"destinationTxHash"`
+	Status            Status            `json:"status"`
+	TimedOut          bool              `json:"timedOut"`
+	Error             string            `json:"error,omitempty"`
+	InitiatedAt       time.Time         `json:"initiatedAt"`
+	Deadline          time.Time         `json:"deadline"`
+	UpdatedAt         time.Time         `json:"updatedAt"`
+	Data              map[string]string `json:"data,omitempty"` // bridge-specific tracking data
+}
+
+// Bridge moves tokens between chains. Status may refresh bridge-specific
+// fields of the transfer, such as the destination transaction hash.
+type Bridge interface {
+	Name() string
+	Supports(fromChainID, toChainID uint64) bool
+	Quote(ctx context.Context, req *TransferRequest) (*Quote, error)
+	Initiate(ctx context.Context, quote *Quote, sender Sender) (*Transfer, error)
+	Status(ctx context.Context, transfer *Transfer) (Status, error)
+	Claim(ctx context.Context, transfer *Transfer, sender Sender) error
+}
+
+// ClientSource returns the RPC client of a chain
+type ClientSource func(chainID uint64) (*ethclient.Client, error)
+
+// Sender submits transactions on any chain and waits for them to be mined
+type Sender interface {
+	Address() common.Address
+	Send(ctx context.Context, chainID uint64, to common.Address, data []byte, value *big.Int) (*types.Receipt, error)
+}
+
+// KeyedSender sends transactions directly from an EOA
+type KeyedSender struct {
+	Journal *TxJournal // optional; records transactions until they are mined
+	key     *ecdsa.PrivateKey
+	clients ClientSource
+}
+
+func NewKeyedSender(key *ecdsa.PrivateKey, clients ClientSource) *KeyedSender {
+	return &KeyedSender{key: key, clients: clients}
+}
+
+func (s *KeyedSender) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+func (s *KeyedSender) Send(ctx context.Context, chainID uint64, to common.Address, data []byte, value *big.Int) (*types.Receipt, error) {
+	client, err := s.clients(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	signed, err := s.submit(ctx, client, chainID, to, data, value)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := bind.WaitMined(ctx, client, signed)
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for %s: %v", signed.Hash().Hex(), err)
+	}
+	if s.Journal != nil {
+		s.Journal.Done(signed.Hash())
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction %s reverted", signed.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+// submit signs and sends a transaction. The sender's nonce on the chain is
+// locked until the node has accepted the transaction, so the next send sees it
+// as pending instead of reusing its nonce.
+func (s *KeyedSender) submit(ctx context.Context, client *ethclient.Client, chainID uint64, to common.Address, data []byte, value *big.Int) (*types.Transaction, error) {
+	from := s.Address()
+	lock := nonceLockFor(chainID, from)
+	lock.Lock()
+	defer lock.Unlock()
+
+	nonce, err := client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %v", err)
+	}
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest gas price: %v", err)
+	}
+	gas, err := client.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &to, Data: data, Value: value})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %v", err)
+	}
+	gas = gas * 12 / 10
+
+	tx := types.NewTransaction(nonce, to, value, gas, gasPrice, data)
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(new(big.Int).SetUint64(chainID)), s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %v", err)
+	}
+	if err := client.SendTransaction(ctx, signed); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %v", err)
+	}
+	if s.Journal != nil {
+		s.Journal.Sent(chainID, from, signed)
+	}
+	return signed, nil
+}
+
+type nonceKey struct {
+	chainID uint64
+	from    common.Address
+}
+
+// nonceLocks is shared by every KeyedSender, since several senders may sign
+// with the same key, e.g. session.Manager creates one per send
+var nonceLocks sync.Map // nonceKey -> *sync.Mutex
+
+func nonceLockFor(chainID uint64, from common.Address) *sync.Mutex {
+	lock, _ := nonceLocks.LoadOrStore(nonceKey{chainID: chainID, from: from}, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// SmartAccountSender routes every call through SmartAccount.execute, signed by
+// the account's owner or one of its session keys
+type SmartAccountSender struct {
+	signer  Sender
+	account common.Address
+}
+
+func NewSmartAccountSender(signer Sender, account common.Address) *SmartAccountSender {
+	return &SmartAccountSender{signer: signer, account: account}
+}
+
+// Address is the smart account, which holds the funds and makes the calls
+func (s *SmartAccountSender) Address() common.Address {
+	return s.account
+}
+
+func (s *SmartAccountSender) Send(ctx context.Context, chainID uint64, to common.Address, data []byte, value *big.Int) (*types.Receipt, error) {
+	if value != nil && value.Sign() != 0 {
+		return nil, fmt.Errorf("smart account execute cannot forward value")
+	}
+	calldata, err := contracts.PackExecute(to, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack execute: %v", err)
+	}
+	return s.signer.Send(ctx, chainID, s.account, calldata, nil)
+}
+
+const erc20ABI = `[
+	{"inputs":[{"internalType":"address","name":"owner","type":"address"},{"internalType":"address","name":"spender","type":"address"}],"name":"allowance","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"address","name":"spender","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"}],"name":"approve","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}
+]`
+
+var tokenABI = mustParseABI(erc20ABI)
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("invalid ABI: %v", err))
+	}
+	return parsed
+}
+
+// callView performs a read-only call and unpacks the result
+func callView(ctx context.Context, client *ethclient.Client, contract abi.ABI, to common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := contract.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return contract.Unpack(method, output)
+}
+
+// initiate approves the bridge if needed and sends the quoted transaction
+func initiate(ctx context.Context, clients ClientSource, quote *Quote, sender Sender) (*Transfer, *types.Receipt, error) {
+	req := quote.Request
+	if quote.Tx == nil {
+		return nil, nil, fmt.Errorf("quote from %s has no transaction", quote.Bridge)
+	}
+
+	if quote.Approval != (common.Address{}) && req.Token != NativeToken {
+		client, err := clients(req.FromChainID)
+		if err != nil {
+			return nil, nil, err
+		}
+		out, err := callView(ctx, client, tokenABI, req.Token, "allowance", sender.Address(), quote.Approval)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read allowance: %v", err)
+		}
+		if out[0].(*big.Int).Cmp(req.Amount) < 0 {
+			data, _ := tokenABI.Pack("approve", quote.Approval, req.Amount)
+			if _, err := sender.Send(ctx, req.FromChainID, req.Token, data, nil); err != nil {
+				return nil, nil, fmt.Errorf("failed to approve %s: %v", quote.Bridge, err)
+			}
+		}
+	}
+
+	receipt, err := sender.Send(ctx, req.FromChainID, quote.Tx.To, quote.Tx.Data, quote.Tx.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	transfer := &Transfer{
+		ID:           fmt.Sprintf("%d-%s", req.FromChainID, receipt.TxHash.Hex()),
+		Bridge:       quote.Bridge,
+		FromChainID:  req.FromChainID,
+		ToChainID:    req.ToChainID,
+		Token:        req.Token,
+		TokenOut:     req.TokenOut,
+		Amount:       req.Amount,
+		ExpectedOut:  quote.AmountOut,
+		Recipient:    req.Recipient,
+		SourceTxHash: receipt.TxHash,
+		Status:       StatusPending,
+		InitiatedAt:  now,
+		UpdatedAt:    now,
+		Data:         make(map[string]string),
+	}
+	return transfer, receipt, nil
+}
@@ -0,0 +1,116 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"agent/state"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const pendingBucket = "pending_txs"
+
+// PendingTx is a transaction that was broadcast but not yet seen mined
+type PendingTx struct {
+	ChainID uint64         `json:"chainId"`
+	Hash    common.Hash    `json:"hash"`
+	From    common.Address `json:"from"`
+	Nonce   uint64         `json:"nonce"`
+	SentAt  time.Time      `json:"sentAt"`
+}
+
+// TxJournal records transactions from broadcast until their receipt arrives.
+// Entries left behind by a shutdown are the transactions whose outcome is
+// unknown; Reconcile resolves them on the next start.
+type TxJournal struct {
+	store    *state.Store
+	inFlight map[common.Hash]bool
+	mu       sync.Mutex
+}
+
+func NewTxJournal(store *state.Store) *TxJournal {
+	return &TxJournal{store: store, inFlight: make(map[common.Hash]bool)}
+}
+
+// Sent records a broadcast transaction
+func (j *TxJournal) Sent(chainID uint64, from common.Address, tx *types.Transaction) {
+	pending := &PendingTx{ChainID: chainID, Hash: tx.Hash(), From: from, Nonce: tx.Nonce(), SentAt: time.Now()}
+	if err := j.store.Put(pendingBucket, tx.Hash().Hex(), pending); err != nil {
+		log.Printf("⚠️  Failed to record pending transaction %s: %v", tx.Hash().Hex(), err)
+	}
+	j.mu.Lock()
+	j.inFlight[tx.Hash()] = true
+	j.mu.Unlock()
+}
+
+// Done removes a transaction once its receipt is known
+func (j *TxJournal) Done(hash common.Hash) {
+	if err := j.store.Delete(pendingBucket, hash.Hex()); err != nil {
+		log.Printf("⚠️  Failed to clear pending transaction %s: %v", hash.Hex(), err)
+	}
+	j.mu.Lock()
+	delete(j.inFlight, hash)
+	j.mu.Unlock()
+}
+
+// InFlight lists the transactions this process sent that have no receipt yet
+func (j *TxJournal) InFlight() []common.Hash {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	hashes := make([]common.Hash, 0, len(j.inFlight))
+	for hash := range j.inFlight {
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+// Reconcile checks the transactions a previous run left pending and clears
+// those that were mined or whose nonce has since been used by another one
+func (j *TxJournal) Reconcile(ctx context.Context, clients ClientSource) error {
+	keys, err := j.store.Keys(pendingBucket)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		var pending PendingTx
+		if found, err := j.store.Get(pendingBucket, key, &pending); err != nil || !found {
+			continue
+		}
+		client, err := clients(pending.ChainID)
+		if err != nil {
+			log.Printf("⚠️  Cannot check pending transaction %s: %v", pending.Hash.Hex(), err)
+			continue
+		}
+
+		receipt, err := client.TransactionReceipt(ctx, pending.Hash)
+		switch {
+		case err == nil:
+			if receipt.Status == types.ReceiptStatusSuccessful {
+				log.Printf("✅ Transaction %s left pending at shutdown was mined in block %d", pending.Hash.Hex(), receipt.BlockNumber)
+			} else {
+				log.Printf("❌ Transaction %s left pending at shutdown reverted", pending.Hash.Hex())
+			}
+		case errors.Is(err, ethereum.NotFound):
+			nonce, nonceErr := client.NonceAt(ctx, pending.From, nil)
+			if nonceErr != nil || nonce <= pending.Nonce {
+				log.Printf("⏳ Transaction %s on chain %d is still pending", pending.Hash.Hex(), pending.ChainID)
+				continue
+			}
+			log.Printf("⚠️  Transaction %s on chain %d was dropped or replaced", pending.Hash.Hex(), pending.ChainID)
+		default:
+			log.Printf("⚠️  Failed to check pending transaction %s: %v", pending.Hash.Hex(), err)
+			continue
+		}
+
+		if err := j.store.Delete(pendingBucket, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
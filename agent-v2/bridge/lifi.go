@@ -0,0 +1,205 @@
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DefaultLiFiURL is the public LI.FI API, which routes across third-party bridges
+const DefaultLiFiURL = "https://li.quest/v1"
+
+// LiFiBridge transfers through whichever bridge the LI.FI aggregator picks
+type LiFiBridge struct {
+	baseURL string
+	apiKey  string
+	clients ClientSource
+	client  *http.Client
+}
+
+func NewLiFiBridge(baseURL, apiKey string, clients ClientSource) *LiFiBridge {
+	return &LiFiBridge{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		clients: clients,
+		client:  &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (b *LiFiBridge) Name() string {
+	return "lifi"
+}
+
+func (b *LiFiBridge) Supports(fromChainID, toChainID uint64) bool {
+	return fromChainID != toChainID
+}
+
+type lifiQuote struct {
+	Tool     string `json:"tool"`
+	Estimate struct {
+		ToAmount          string  `json:"toAmount"`
+		ApprovalAddress   string  `json:"approvalAddress"`
+		ExecutionDuration float64 `json:"executionDuration"`
+	} `json:"estimate"`
+	TransactionRequest struct {
+		To    string `json:"to"`
+		Data  string `json:"data"`
+		Value string `json:"value"`
+	} `json:"transactionRequest"`
+}
+
+func (b *LiFiBridge) Quote(ctx context.Context, req *TransferRequest) (*Quote, error) {
+	params := url.Values{}
+	params.Set("fromChain", strconv.FormatUint(req.FromChainID, 10))
+	params.Set("toChain", strconv.FormatUint(req.ToChainID, 10))
+	params.Set("fromToken", lifiToken(req.Token))
+	params.Set("toToken", lifiToken(req.TokenOut))
+	params.Set("fromAmount", req.Amount.String())
+	params.Set("fromAddress", req.Sender.Hex())
+	params.Set("toAddress", req.Recipient.Hex())
+
+	var resp lifiQuote
+	if err := b.get(ctx, "/quote", params, &resp); err != nil {
+		return nil, err
+	}
+
+	amountOut, ok := new(big.Int).SetString(resp.Estimate.ToAmount, 10)
+	if !ok {
+		return nil, fmt.Errorf("LI.FI quote: invalid amount %q", resp.Estimate.ToAmount)
+	}
+	data, err := hexutil.Decode(resp.TransactionRequest.Data)
+	if err != nil {
+		return nil, fmt.Errorf("LI.FI quote: invalid calldata: %v", err)
+	}
+	value, err := hexutil.DecodeBig(resp.TransactionRequest.Value)
+	if err != nil {
+		return nil, fmt.Errorf("LI.FI quote: invalid value: %v", err)
+	}
+
+	nativeFee := new(big.Int).Set(value)
+	if req.Token == NativeToken {
+		nativeFee.Sub(nativeFee, req.Amount)
+	}
+
+	quote := &Quote{
+		Bridge:            b.Name(),
+		Request:           req,
+		AmountOut:         amountOut,
+		NativeFee:         nativeFee,
+		EstimatedDuration: time.Duration(resp.Estimate.ExecutionDuration * float64(time.Second)),
+		Tx: &Call{
+			To:    common.HexToAddress(resp.TransactionRequest.To),
+			Data:  data,
+			Value: value,
+		},
+	}
+	if common.IsHexAddress(resp.Estimate.ApprovalAddress) {
+		quote.Approval = common.HexToAddress(resp.Estimate.ApprovalAddress)
+	}
+	return quote, nil
+}
+
+func (b *LiFiBridge) Initiate(ctx context.Context, quote *Quote, sender Sender) (*Transfer, error) {
+	transfer, _, err := initiate(ctx, b.clients, quote, sender)
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+type lifiStatus struct {
+	Status    string `json:"status"`
+	Substatus string `json:"substatus"`
+	Receiving struct {
+		TxHash string `json:"txHash"`
+	} `json:"receiving"`
+}
+
+func (b *LiFiBridge) Status(ctx context.Context, transfer *Transfer) (Status, error) {
+	params := url.Values{}
+	params.Set("txHash", transfer.SourceTxHash.Hex())
+	params.Set("fromChain", strconv.FormatUint(transfer.FromChainID, 10))
+	params.Set("toChain", strconv.FormatUint(transfer.ToChainID, 10))
+
+	var resp lifiStatus
+	if err := b.get(ctx, "/status", params, &resp); err != nil {
+		return transfer.Status, err
+	}
+
+	if len(resp.Receiving.TxHash) == 66 {
+		transfer.DestinationTxHash = common.HexToHash(resp.Receiving.TxHash)
+	}
+
+	switch resp.Status {
+	case "DONE":
+		if resp.Substatus == "COMPLETED" {
+			return StatusCompleted, nil
+		}
+		// PARTIAL delivers a different token and REFUNDED returns funds on the source chain
+		transfer.Error = "transfer ended as " + resp.Substatus
+		return StatusFailed, nil
+	case "FAILED", "INVALID":
+		transfer.Error = "transfer " + strings.ToLower(resp.Status)
+		return StatusFailed, nil
+	default:
+		return StatusPending, nil
+	}
+}
+
+// Claim is never needed: the underlying bridges deliver funds automatically
+func (b *LiFiBridge) Claim(ctx context.Context, transfer *Transfer, sender Sender) error {
+	return ErrNothingToClaim
+}
+
+func (b *LiFiBridge) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if b.apiKey != "" {
+		req.Header.Set("x-lifi-api-key", b.apiKey)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("LI.FI %s failed: %v", path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// Status returns 404 until the source transaction has been indexed
+	if path == "/status" && resp.StatusCode == http.StatusNotFound {
+		return json.Unmarshal([]byte(`{"status":"NOT_FOUND"}`), out)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if len(body) > 200 {
+			body = body[:200]
+		}
+		return fmt.Errorf("LI.FI %s failed: HTTP %d: %s", path, resp.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("LI.FI %s: failed to decode response: %v", path, err)
+	}
+	return nil
+}
+
+// lifiToken maps the native placeholder to the zero address LI.FI uses
+func lifiToken(token common.Address) string {
+	if token == NativeToken {
+		return common.Address{}.Hex()
+	}
+	return token.Hex()
+}
@@ -0,0 +1,219 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const mockBridgeABI = `[
+	{"inputs":[{"internalType":"address","name":"token","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"uint256","name":"destinationChainId","type":"uint256"},{"internalType":"address","name":"recipient","type":"address"}],"name":"deposit","outputs":[{"internalType":"uint256","name":"depositId","type":"uint256"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"uint256","name":"sourceChainId","type":"uint256"},{"internalType":"uint256","name":"depositId","type":"uint256"},{"internalType":"address","name":"token","type":"address"},{"internalType":"address","name":"recipient","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"}],"name":"release","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"uint256","name":"sourceChainId","type":"uint256"},{"internalType":"uint256","name":"depositId","type":"uint256"}],"name":"transferId","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"pure","type":"function"},
+	{"inputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"name":"released","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"uint256","name":"depositId","type":"uint256"},{"indexed":true,"internalType":"address","name":"token","type":"address"},{"indexed":true,"internalType":"address","name":"recipient","type":"address"},{"indexed":false,"internalType":"uint256","name":"amount","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"destinationChainId","type":"uint256"},{"indexed":false,"internalType":"address","name":"sender","type":"address"}],"name":"Deposited","type":"event"}
+]`
+
+var mockABI = mustParseABI(mockBridgeABI)
+
+// LocalBridge transfers through MockBridge deployments (blockchain/contracts/MockBridge.sol)
+// at the same address on local chains, such as the two anvil chains started by
+// blockchain/scripts/local-bridge.sh. A MockRelayer completes the transfers.
+type LocalBridge struct {
+	address common.Address
+	chains  map[uint64]bool
+	clients ClientSource
+}
+
+func NewLocalBridge(address common.Address, chainIDs []uint64, clients ClientSource) *LocalBridge {
+	chains := make(map[uint64]bool)
+	for _, chainID := range chainIDs {
+		chains[chainID] = true
+	}
+	return &LocalBridge{address: address, chains: chains, clients: clients}
+}
+
+func (b *LocalBridge) Name() string {
+	return "local"
+}
+
+func (b *LocalBridge) Supports(fromChainID, toChainID uint64) bool {
+	return fromChainID != toChainID && b.chains[fromChainID] && b.chains[toChainID]
+}
+
+func (b *LocalBridge) Quote(ctx context.Context, req *TransferRequest) (*Quote, error) {
+	data, err := mockABI.Pack("deposit", req.Token, req.Amount, new(big.Int).SetUint64(req.ToChainID), req.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &Quote{
+		Bridge:            b.Name(),
+		Request:           req,
+		AmountOut:         req.Amount,
+		NativeFee:         big.NewInt(0),
+		EstimatedDuration: 30 * time.Second,
+		Tx:                &Call{To: b.address, Data: data, Value: big.NewInt(0)},
+	}
+	if req.Token == NativeToken {
+		quote.Tx.Value = req.Amount
+	} else {
+		quote.Approval = b.address
+	}
+	return quote, nil
+}
+
+func (b *LocalBridge) Initiate(ctx context.Context, quote *Quote, sender Sender) (*Transfer, error) {
+	transfer, receipt, err := initiate(ctx, b.clients, quote, sender)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range receipt.Logs {
+		if entry.Address == b.address && len(entry.Topics) == 4 && entry.Topics[0] == mockABI.Events["Deposited"].ID {
+			transfer.Data["depositId"] = entry.Topics[1].Big().String()
+			return transfer, nil
+		}
+	}
+	return nil, fmt.Errorf("deposit %s emitted no Deposited event", receipt.TxHash.Hex())
+}
+
+func (b *LocalBridge) Status(ctx context.Context, transfer *Transfer) (Status, error) {
+	depositID, ok := new(big.Int).SetString(transfer.Data["depositId"], 10)
+	if !ok {
+		return transfer.Status, fmt.Errorf("transfer %s has no deposit ID", transfer.ID)
+	}
+	client, err := b.clients(transfer.ToChainID)
+	if err != nil {
+		return transfer.Status, err
+	}
+
+	out, err := callView(ctx, client, mockABI, b.address, "transferId", new(big.Int).SetUint64(transfer.FromChainID), depositID)
+	if err != nil {
+		return transfer.Status, err
+	}
+	out, err = callView(ctx, client, mockABI, b.address, "released", common.Hash(out[0].([32]byte)))
+	if err != nil {
+		return transfer.Status, err
+	}
+	if out[0].(bool) {
+		return StatusCompleted, nil
+	}
+	return StatusPending, nil
+}
+
+func (b *LocalBridge) Claim(ctx context.Context, transfer *Transfer, sender Sender) error {
+	return ErrNothingToClaim
+}
+
+// MockRelayer releases MockBridge deposits on their destination chain. Tokens
+// are released at the same address they were deposited from, which holds on
+// local chains deployed from identical nonces.
+type MockRelayer struct {
+	bridge    *LocalBridge
+	sender    Sender
+	nextBlock map[uint64]uint64
+}
+
+// NewMockRelayer relays with sender, which must be the relayer set on the MockBridge contracts
+func NewMockRelayer(bridge *LocalBridge, sender Sender) *MockRelayer {
+	return &MockRelayer{
+		bridge:    bridge,
+		sender:    sender,
+		nextBlock: make(map[uint64]uint64),
+	}
+}
+
+// Run relays deposits every interval until the context is cancelled
+func (r *MockRelayer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.Relay(ctx); err != nil {
+				log.Printf("⚠️  Mock relayer: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Relay releases every deposit made since the previous call
+func (r *MockRelayer) Relay(ctx context.Context) error {
+	for sourceChainID := range r.bridge.chains {
+		client, err := r.bridge.clients(sourceChainID)
+		if err != nil {
+			return err
+		}
+		latest, err := client.BlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get block number on chain %d: %v", sourceChainID, err)
+		}
+		from := r.nextBlock[sourceChainID]
+		if from > latest {
+			continue
+		}
+
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(latest),
+			Addresses: []common.Address{r.bridge.address},
+			Topics:    [][]common.Hash{{mockABI.Events["Deposited"].ID}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read deposits on chain %d: %v", sourceChainID, err)
+		}
+
+		for _, entry := range logs {
+			if err := r.release(ctx, sourceChainID, entry.Topics, entry.Data); err != nil {
+				return err
+			}
+		}
+		r.nextBlock[sourceChainID] = latest + 1
+	}
+	return nil
+}
+
+func (r *MockRelayer) release(ctx context.Context, sourceChainID uint64, topics []common.Hash, data []byte) error {
+	if len(topics) != 4 {
+		return nil
+	}
+	out, err := mockABI.Unpack("Deposited", data)
+	if err != nil {
+		return fmt.Errorf("failed to decode deposit: %v", err)
+	}
+	amount := out[0].(*big.Int)
+	destination := out[1].(*big.Int).Uint64()
+	if !r.bridge.chains[destination] {
+		return nil
+	}
+
+	transfer := &Transfer{
+		FromChainID: sourceChainID,
+		ToChainID:   destination,
+		Data:        map[string]string{"depositId": topics[1].Big().String()},
+	}
+	status, err := r.bridge.Status(ctx, transfer)
+	if err != nil || status == StatusCompleted {
+		return err
+	}
+
+	call, err := mockABI.Pack("release", new(big.Int).SetUint64(sourceChainID), topics[1].Big(),
+		common.BytesToAddress(topics[2].Bytes()), common.BytesToAddress(topics[3].Bytes()), amount)
+	if err != nil {
+		return err
+	}
+	if _, err := r.sender.Send(ctx, destination, r.bridge.address, call, nil); err != nil {
+		return fmt.Errorf("failed to release deposit %s from chain %d: %v", topics[1].Big(), sourceChainID, err)
+	}
+	log.Printf("🔁 Mock relayer released deposit %s from chain %d on chain %d", topics[1].Big(), sourceChainID, destination)
+	return nil
+}
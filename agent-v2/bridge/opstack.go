@@ -0,0 +1,196 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// L2CrossDomainMessenger is the predeploy relaying L1 messages on every OP Stack chain
+var L2CrossDomainMessenger = common.HexToAddress("0x4200000000000000000000000000000000000007")
+
+// opDepositGasLimit is the L2 gas granted to relay a deposit
+const opDepositGasLimit = 200000
+
+const opStackABI = `[
+	{"inputs":[{"internalType":"address","name":"_to","type":"address"},{"internalType":"uint32","name":"_minGasLimit","type":"uint32"},{"internalType":"bytes","name":"_extraData","type":"bytes"}],"name":"depositETHTo","outputs":[],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"address","name":"_l1Token","type":"address"},{"internalType":"address","name":"_l2Token","type":"address"},{"internalType":"address","name":"_to","type":"address"},{"internalType":"uint256","name":"_amount","type":"uint256"},{"internalType":"uint32","name":"_minGasLimit","type":"uint32"},{"internalType":"bytes","name":"_extraData","type":"bytes"}],"name":"depositERC20To","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"name":"successfulMessages","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"name":"failedMessages","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"uint256","name":"_nonce","type":"uint256"},{"internalType":"address","name":"_sender","type":"address"},{"internalType":"address","name":"_target","type":"address"},{"internalType":"uint256","name":"_value","type":"uint256"},{"internalType":"uint256","name":"_minGasLimit","type":"uint256"},{"internalType":"bytes","name":"_message","type":"bytes"}],"name":"relayMessage","outputs":[],"stateMutability":"payable","type":"function"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"target","type":"address"},{"indexed":false,"internalType":"address","name":"sender","type":"address"},{"indexed":false,"internalType":"bytes","name":"message","type":"bytes"},{"indexed":false,"internalType":"uint256","name":"messageNonce","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"gasLimit","type":"uint256"}],"name":"SentMessage","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"sender","type":"address"},{"indexed":false,"internalType":"uint256","name":"value","type":"uint256"}],"name":"SentMessageExtension1","type":"event"}
+]`
+
+var opABI = mustParseABI(opStackABI)
+
+// OPStackChain holds the L1 contracts of one OP Stack L2
+type OPStackChain struct {
+	L1StandardBridge       common.Address
+	L1CrossDomainMessenger common.Address
+}
+
+// OPStackBridge deposits through the canonical OP Stack bridge. Only L1 -> L2
+// is supported: withdrawals wait out a seven day challenge period, far beyond
+// the horizon of any trade.
+type OPStackBridge struct {
+	l1ChainID uint64
+	chains    map[uint64]*OPStackChain
+	clients   ClientSource
+}
+
+// NewOPStackBridge returns a bridge for OP Mainnet and Base deposits from Ethereum
+func NewOPStackBridge(clients ClientSource) *OPStackBridge {
+	return &OPStackBridge{
+		l1ChainID: 1,
+		chains: map[uint64]*OPStackChain{
+			10: {
+				L1StandardBridge:       common.HexToAddress("0x99C9fc46f92E8a1c0deC1b1747d010903E884bE1"),
+				L1CrossDomainMessenger: common.HexToAddress("0x25ace71c97B33Cc4729CF772ae268934F7ab5fA1"),
+			},
+			8453: {
+				L1StandardBridge:       common.HexToAddress("0x3154Cf16ccdb4C6d922629664174b904d80F2C35"),
+				L1CrossDomainMessenger: common.HexToAddress("0x866E82a600A1414e583f7F13623F1aC5d58b0Afa"),
+			},
+		},
+		clients: clients,
+	}
+}
+
+func (b *OPStackBridge) Name() string {
+	return "op-stack"
+}
+
+func (b *OPStackBridge) Supports(fromChainID, toChainID uint64) bool {
+	_, exists := b.chains[toChainID]
+	return fromChainID == b.l1ChainID && exists
+}
+
+func (b *OPStackBridge) Quote(ctx context.Context, req *TransferRequest) (*Quote, error) {
+	chain, exists := b.chains[req.ToChainID]
+	if !exists || req.FromChainID != b.l1ChainID {
+		return nil, fmt.Errorf("route %d -> %d not supported", req.FromChainID, req.ToChainID)
+	}
+
+	quote := &Quote{
+		Bridge:            b.Name(),
+		Request:           req,
+		AmountOut:         req.Amount,
+		NativeFee:         big.NewInt(0), // deposit gas on L2 is paid for by L1 gas
+		EstimatedDuration: 3 * time.Minute,
+	}
+
+	if req.Token == NativeToken {
+		data, err := opABI.Pack("depositETHTo", req.Recipient, uint32(opDepositGasLimit), []byte{})
+		if err != nil {
+			return nil, err
+		}
+		quote.Tx = &Call{To: chain.L1StandardBridge, Data: data, Value: req.Amount}
+	} else {
+		data, err := opABI.Pack("depositERC20To", req.Token, req.TokenOut, req.Recipient, req.Amount, uint32(opDepositGasLimit), []byte{})
+		if err != nil {
+			return nil, err
+		}
+		quote.Tx = &Call{To: chain.L1StandardBridge, Data: data, Value: big.NewInt(0)}
+		quote.Approval = chain.L1StandardBridge
+	}
+
+	return quote, nil
+}
+
+// Initiate sends the deposit and records the cross-domain message it emits,
+// whose hash identifies the relay on L2
+func (b *OPStackBridge) Initiate(ctx context.Context, quote *Quote, sender Sender) (*Transfer, error) {
+	transfer, receipt, err := initiate(ctx, b.clients, quote, sender)
+	if err != nil {
+		return nil, err
+	}
+	chain := b.chains[transfer.ToChainID]
+
+	var message struct {
+		Sender       common.Address
+		Message      []byte
+		MessageNonce *big.Int
+		GasLimit     *big.Int
+	}
+	var target common.Address
+	value := big.NewInt(0)
+	found := false
+
+	for _, entry := range receipt.Logs {
+		if entry.Address != chain.L1CrossDomainMessenger || len(entry.Topics) < 2 {
+			continue
+		}
+		switch entry.Topics[0] {
+		case opABI.Events["SentMessage"].ID:
+			if err := opABI.UnpackIntoInterface(&message, "SentMessage", entry.Data); err != nil {
+				return nil, fmt.Errorf("failed to decode SentMessage: %v", err)
+			}
+			target = common.BytesToAddress(entry.Topics[1].Bytes())
+			found = true
+		case opABI.Events["SentMessageExtension1"].ID:
+			out, err := opABI.Unpack("SentMessageExtension1", entry.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode SentMessageExtension1: %v", err)
+			}
+			value = out[0].(*big.Int)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("deposit %s emitted no cross-domain message", receipt.TxHash.Hex())
+	}
+
+	relay, err := opABI.Pack("relayMessage", message.MessageNonce, message.Sender, target, value, message.GasLimit, message.Message)
+	if err != nil {
+		return nil, err
+	}
+	transfer.Data["messageHash"] = crypto.Keccak256Hash(relay).Hex()
+	transfer.Data["relayCall"] = hexutil.Encode(relay)
+	return transfer, nil
+}
+
+// Status checks the L2 messenger; a failed relay can be replayed by Claim
+func (b *OPStackBridge) Status(ctx context.Context, transfer *Transfer) (Status, error) {
+	client, err := b.clients(transfer.ToChainID)
+	if err != nil {
+		return transfer.Status, err
+	}
+	hash := common.HexToHash(transfer.Data["messageHash"])
+
+	out, err := callView(ctx, client, opABI, L2CrossDomainMessenger, "successfulMessages", hash)
+	if err != nil {
+		return transfer.Status, fmt.Errorf("failed to read relay status: %v", err)
+	}
+	if out[0].(bool) {
+		return StatusCompleted, nil
+	}
+
+	out, err = callView(ctx, client, opABI, L2CrossDomainMessenger, "failedMessages", hash)
+	if err != nil {
+		return transfer.Status, fmt.Errorf("failed to read relay status: %v", err)
+	}
+	if out[0].(bool) {
+		return StatusClaimable, nil
+	}
+	return StatusPending, nil
+}
+
+// Claim replays a deposit whose relay failed on L2, e.g. for lack of gas
+func (b *OPStackBridge) Claim(ctx context.Context, transfer *Transfer, sender Sender) error {
+	relay, err := hexutil.Decode(transfer.Data["relayCall"])
+	if err != nil {
+		return fmt.Errorf("transfer %s has no relay call: %v", transfer.ID, err)
+	}
+
+	receipt, err := sender.Send(ctx, transfer.ToChainID, L2CrossDomainMessenger, relay, nil)
+	if err != nil {
+		return err
+	}
+	transfer.DestinationTxHash = receipt.TxHash
+	return nil
+}
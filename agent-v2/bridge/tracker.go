@@ -0,0 +1,222 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"agent/state"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const transferBucket = "bridge_transfers"
+
+const (
+	// DefaultTransferTimeout applies when a bridge gives no duration estimate
+	DefaultTransferTimeout = 2 * time.Hour
+
+	// timeoutFactor is how many estimated durations a transfer may take before it is flagged
+	timeoutFactor = 3
+
+	minTransferTimeout = 15 * time.Minute
+)
+
+// Tracker initiates transfers through the best bridge and follows them until
+// they settle. In-flight transfers are persisted so tracking survives restarts.
+type Tracker struct {
+	store     *state.Store
+	sender    Sender
+	bridges   []Bridge
+	transfers map[string]*Transfer
+	mu        sync.Mutex
+}
+
+func NewTracker(store *state.Store, sender Sender, bridges ...Bridge) (*Tracker, error) {
+	t := &Tracker{
+		store:     store,
+		sender:    sender,
+		bridges:   bridges,
+		transfers: make(map[string]*Transfer),
+	}
+
+	keys, err := store.Keys(transferBucket)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		var transfer Transfer
+		if _, err := store.Get(transferBucket, key, &transfer); err != nil {
+			return nil, err
+		}
+		if !transfer.Status.Terminal() {
+			t.transfers[transfer.ID] = &transfer
+		}
+	}
+	if len(t.transfers) > 0 {
+		log.Printf("🌉 Resumed tracking %d in-flight bridge transfers", len(t.transfers))
+	}
+
+	return t, nil
+}
+
+func (t *Tracker) bridge(name string) (Bridge, error) {
+	for _, bridge := range t.bridges {
+		if bridge.Name() == name {
+			return bridge, nil
+		}
+	}
+	return nil, fmt.Errorf("bridge %s not configured", name)
+}
+
+// BestQuote asks every bridge supporting the route and returns the quote that
+// delivers the most tokens. Sender and recipient default to the tracker's sender.
+func (t *Tracker) BestQuote(ctx context.Context, req *TransferRequest) (*Quote, error) {
+	if req.Sender == (common.Address{}) {
+		req.Sender = t.sender.Address()
+	}
+	if req.Recipient == (common.Address{}) {
+		req.Recipient = req.Sender
+	}
+
+	var best *Quote
+	for _, bridge := range t.bridges {
+		if !bridge.Supports(req.FromChainID, req.ToChainID) {
+			continue
+		}
+		quote, err := bridge.Quote(ctx, req)
+		if err != nil {
+			log.Printf("⚠️  %s quote failed for %d -> %d: %v", bridge.Name(), req.FromChainID, req.ToChainID, err)
+			continue
+		}
+		if best == nil || quote.AmountOut.Cmp(best.AmountOut) > 0 ||
+			(quote.AmountOut.Cmp(best.AmountOut) == 0 && quote.NativeFee.Cmp(best.NativeFee) < 0) {
+			best = quote
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("no bridge quote for %d -> %d", req.FromChainID, req.ToChainID)
+	}
+	return best, nil
+}
+
+// Initiate sends a quoted transfer and starts tracking it
+func (t *Tracker) Initiate(ctx context.Context, quote *Quote) (*Transfer, error) {
+	bridge, err := t.bridge(quote.Bridge)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := bridge.Initiate(ctx, quote, t.sender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate %s transfer: %v", quote.Bridge, err)
+	}
+
+	timeout := DefaultTransferTimeout
+	if quote.EstimatedDuration > 0 {
+		timeout = timeoutFactor * quote.EstimatedDuration
+		if timeout < minTransferTimeout {
+			timeout = minTransferTimeout
+		}
+	}
+	transfer.Deadline = transfer.InitiatedAt.Add(timeout)
+
+	t.mu.Lock()
+	t.transfers[transfer.ID] = transfer
+	t.mu.Unlock()
+
+	if err := t.store.Put(transferBucket, transfer.ID, transfer); err != nil {
+		log.Printf("⚠️  Failed to persist bridge transfer %s: %v", transfer.ID, err)
+	}
+
+	log.Printf("🌉 Initiated %s transfer %s (%d -> %d)", transfer.Bridge, transfer.ID, transfer.FromChainID, transfer.ToChainID)
+	return transfer, nil
+}
+
+// Poll refreshes every in-flight transfer, claims those waiting for a claim
+// and flags those past their deadline
+func (t *Tracker) Poll(ctx context.Context) {
+	for _, transfer := range t.InFlight() {
+		if err := t.refresh(ctx, transfer); err != nil {
+			log.Printf("⚠️  Failed to check bridge transfer %s: %v", transfer.ID, err)
+		}
+	}
+}
+
+func (t *Tracker) refresh(ctx context.Context, transfer *Transfer) error {
+	bridge, err := t.bridge(transfer.Bridge)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status, err := bridge.Status(ctx, transfer)
+	if err != nil {
+		return err
+	}
+
+	if status == StatusClaimable {
+		err := bridge.Claim(ctx, transfer, t.sender)
+		if err != nil && err != ErrNothingToClaim {
+			transfer.Error = err.Error()
+			log.Printf("⚠️  Failed to claim bridge transfer %s: %v", transfer.ID, err)
+		} else if err == nil {
+			log.Printf("🪝 Claimed bridge transfer %s", transfer.ID)
+		}
+	}
+
+	if status != transfer.Status {
+		log.Printf("🌉 Bridge transfer %s: %s -> %s", transfer.ID, transfer.Status, status)
+	}
+	transfer.Status = status
+	transfer.UpdatedAt = time.Now()
+
+	if !status.Terminal() && !transfer.TimedOut && transfer.UpdatedAt.After(transfer.Deadline) {
+		transfer.TimedOut = true
+		log.Printf("🚨 Bridge transfer %s has not settled after %s, check it manually",
+			transfer.ID, transfer.UpdatedAt.Sub(transfer.InitiatedAt).Round(time.Minute))
+	}
+
+	if status.Terminal() {
+		delete(t.transfers, transfer.ID)
+	}
+	return t.store.Put(transferBucket, transfer.ID, transfer)
+}
+
+// InFlight returns the transfers that have not settled yet, oldest first
+func (t *Tracker) InFlight() []*Transfer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	transfers := make([]*Transfer, 0, len(t.transfers))
+	for _, transfer := range t.transfers {
+		transfers = append(transfers, transfer)
+	}
+	sort.Slice(transfers, func(i, j int) bool {
+		return transfers[i].InitiatedAt.Before(transfers[j].InitiatedAt)
+	})
+	return transfers
+}
+
+// Get returns a transfer by ID, including settled transfers
+func (t *Tracker) Get(id string) (*Transfer, bool, error) {
+	t.mu.Lock()
+	transfer, exists := t.transfers[id]
+	t.mu.Unlock()
+	if exists {
+		return transfer, true, nil
+	}
+
+	var stored Transfer
+	found, err := t.store.Get(transferBucket, id, &stored)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return &stored, true, nil
+}
@@ -0,0 +1,167 @@
+// Command localbundler is a minimal ERC-4337 bundler for local chains. It
+// accepts UserOperations over the standard bundler RPC methods and submits
+// each one immediately in its own handleOps transaction, so the agent's
+// UserOperation path can be exercised against anvil without a real bundler.
+//
+//	go run ./cmd/localbundler -rpc http://127.0.0.1:8545 -key <funded key> [-listen 127.0.0.1:4337]
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+
+	"agent/bridge"
+	"agent/userop"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Fixed validation limits; generous enough for SmartAccountV2 and simple paymasters
+const (
+	verificationGas     = 150000
+	preVerificationGas  = 50000
+	paymasterGas        = 100000
+	paymasterPostOpGas  = 50000
+	factoryVerification = 250000
+)
+
+// bundlerService implements the eth_ bundler methods; go-ethereum's rpc
+// package maps SendUserOperation to eth_sendUserOperation and so on
+type bundlerService struct {
+	client   *ethclient.Client
+	chainID  uint64
+	sender   *bridge.KeyedSender
+	receipts map[common.Hash]*userop.OperationReceipt
+	mu       sync.Mutex
+}
+
+func (b *bundlerService) SupportedEntryPoints() []common.Address {
+	return []common.Address{userop.EntryPointV07}
+}
+
+func (b *bundlerService) EstimateUserOperationGas(ctx context.Context, op *userop.UserOperation, entryPoint common.Address) (*userop.GasEstimate, error) {
+	if entryPoint != userop.EntryPointV07 {
+		return nil, fmt.Errorf("unsupported EntryPoint %s", entryPoint.Hex())
+	}
+	callGas, err := b.client.EstimateGas(ctx, ethereum.CallMsg{From: entryPoint, To: &op.Sender, Data: op.CallData})
+	if err != nil {
+		return nil, fmt.Errorf("call simulation failed: %v", err)
+	}
+
+	verification := uint64(verificationGas)
+	if op.Factory != nil {
+		verification += factoryVerification
+	}
+	estimate := &userop.GasEstimate{
+		PreVerificationGas:   (*hexutil.Big)(big.NewInt(preVerificationGas)),
+		VerificationGasLimit: (*hexutil.Big)(new(big.Int).SetUint64(verification)),
+		CallGasLimit:         (*hexutil.Big)(new(big.Int).SetUint64(callGas * 12 / 10)),
+	}
+	if op.Paymaster != nil {
+		estimate.PaymasterVerificationGasLimit = (*hexutil.Big)(big.NewInt(paymasterGas))
+		estimate.PaymasterPostOpGasLimit = (*hexutil.Big)(big.NewInt(paymasterPostOpGas))
+	}
+	return estimate, nil
+}
+
+func (b *bundlerService) SendUserOperation(ctx context.Context, op *userop.UserOperation, entryPoint common.Address) (common.Hash, error) {
+	if entryPoint != userop.EntryPointV07 {
+		return common.Hash{}, fmt.Errorf("unsupported EntryPoint %s", entryPoint.Hex())
+	}
+	hash := op.Hash(entryPoint, b.chainID)
+
+	data, err := userop.EntryPointABI.Pack("handleOps", []userop.PackedUserOperation{op.Pack()}, b.sender.Address())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to pack handleOps: %v", err)
+	}
+	receipt, err := b.sender.Send(ctx, b.chainID, entryPoint, data, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("handleOps failed: %v", err)
+	}
+
+	for _, entry := range receipt.Logs {
+		if len(entry.Topics) < 2 || entry.Topics[0] != userop.UserOperationEventTopic || entry.Topics[1] != hash {
+			continue
+		}
+		values, err := userop.EntryPointABI.Unpack("UserOperationEvent", entry.Data)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to decode UserOperationEvent: %v", err)
+		}
+
+		opReceipt := &userop.OperationReceipt{
+			UserOpHash:    hash,
+			Success:       values[1].(bool),
+			ActualGasCost: (*hexutil.Big)(values[2].(*big.Int)),
+		}
+		opReceipt.Receipt.TransactionHash = receipt.TxHash
+
+		b.mu.Lock()
+		b.receipts[hash] = opReceipt
+		b.mu.Unlock()
+		log.Printf("📦 Bundled UserOperation %s from %s in %s (success=%t)", hash.Hex(), op.Sender.Hex(), receipt.TxHash.Hex(), opReceipt.Success)
+		return hash, nil
+	}
+	return common.Hash{}, fmt.Errorf("handleOps emitted no UserOperationEvent for %s", hash.Hex())
+}
+
+func (b *bundlerService) GetUserOperationReceipt(hash common.Hash) *userop.OperationReceipt {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.receipts[hash]
+}
+
+func main() {
+	rpcURL := flag.String("rpc", "http://127.0.0.1:8545", "RPC endpoint of the local chain")
+	keyHex := flag.String("key", "", "private key that submits bundles and receives their fees")
+	listen := flag.String("listen", "127.0.0.1:4337", "address to serve the bundler RPC on")
+	flag.Parse()
+
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(*keyHex, "0x"))
+	if err != nil {
+		log.Fatalf("Invalid -key: %v", err)
+	}
+
+	client, err := ethclient.Dial(*rpcURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to %s: %v", *rpcURL, err)
+	}
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to get chain ID: %v", err)
+	}
+	code, err := client.CodeAt(context.Background(), userop.EntryPointV07, nil)
+	if err != nil || len(code) == 0 {
+		log.Fatalf("No EntryPoint v0.7 at %s; start anvil as a fork of a chain that has one", userop.EntryPointV07.Hex())
+	}
+
+	service := newBundlerService(client, chainID.Uint64(), key)
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		log.Fatalf("Failed to register bundler service: %v", err)
+	}
+
+	log.Printf("🧪 Local bundler for chain %d listening on http://%s", chainID, *listen)
+	log.Fatal(http.ListenAndServe(*listen, server))
+}
+
+func newBundlerService(client *ethclient.Client, chainID uint64, key *ecdsa.PrivateKey) *bundlerService {
+	clients := func(uint64) (*ethclient.Client, error) { return client, nil }
+	return &bundlerService{
+		client:   client,
+		chainID:  chainID,
+		sender:   bridge.NewKeyedSender(key, clients),
+		receipts: make(map[common.Hash]*userop.OperationReceipt),
+	}
+}
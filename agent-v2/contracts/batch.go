@@ -0,0 +1,158 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Batch is a sequence of calls the account executes atomically through
+// SmartAccountV2.executeCalls, e.g. an approval followed by the swap that spends it
+type Batch struct {
+	Calls        []SmartAccountV2Call
+	AllowFailure bool // keep going after a failed call instead of reverting the batch
+}
+
+func NewBatch() *Batch {
+	return &Batch{}
+}
+
+// Add appends a call; value is taken from the account's balance and may be nil
+func (b *Batch) Add(target common.Address, value *big.Int, data []byte) *Batch {
+	if value == nil {
+		value = new(big.Int)
+	}
+	b.Calls = append(b.Calls, SmartAccountV2Call{Target: target, Value: value, Data: data})
+	return b
+}
+
+func (b *Batch) Len() int {
+	return len(b.Calls)
+}
+
+// Pack encodes the batch as account calldata
+func (b *Batch) Pack() ([]byte, error) {
+	if len(b.Calls) == 0 {
+		return nil, fmt.Errorf("empty batch")
+	}
+	return v2ABI.Pack("executeCalls", b.Calls, b.AllowFailure)
+}
+
+// CallResult is the outcome of one call of an executed batch
+type CallResult struct {
+	Index      int
+	Target     common.Address
+	Success    bool
+	ReturnData []byte
+}
+
+// BatchCallError reports the call that reverted a batch
+type BatchCallError struct {
+	Index      int
+	Target     common.Address
+	ReturnData []byte
+}
+
+func (e *BatchCallError) Error() string {
+	if reason, err := abi.UnpackRevert(e.ReturnData); err == nil {
+		return fmt.Sprintf("batched call %d to %s reverted: %s", e.Index, e.Target.Hex(), reason)
+	}
+	return fmt.Sprintf("batched call %d to %s reverted with %s", e.Index, e.Target.Hex(), hexutil.Encode(e.ReturnData))
+}
+
+// Simulate runs the batch with eth_call from the given authorized sender and
+// returns what each call would produce. A call that would revert the batch is
+// reported as a *BatchCallError.
+func (b *Batch) Simulate(ctx context.Context, backend bind.ContractCaller, from, account common.Address) ([]CallResult, error) {
+	data, err := b.Pack()
+	if err != nil {
+		return nil, err
+	}
+	output, err := backend.CallContract(ctx, ethereum.CallMsg{From: from, To: &account, Data: data}, nil)
+	if err != nil {
+		if callErr := b.decodeCallFailed(err); callErr != nil {
+			return nil, callErr
+		}
+		return nil, fmt.Errorf("batch simulation failed: %v", err)
+	}
+
+	out, err := v2ABI.Unpack("executeCalls", output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode executeCalls result: %v", err)
+	}
+	successes, returns := out[0].([]bool), out[1].([][]byte)
+	if len(successes) != len(b.Calls) || len(returns) != len(b.Calls) {
+		return nil, fmt.Errorf("executeCalls returned %d results for %d calls", len(successes), len(b.Calls))
+	}
+
+	results := make([]CallResult, len(b.Calls))
+	for i, call := range b.Calls {
+		results[i] = CallResult{Index: i, Target: call.Target, Success: successes[i], ReturnData: returns[i]}
+	}
+	return results, nil
+}
+
+// decodeCallFailed extracts the CallFailed revert from an eth_call error, if that is what it is
+func (b *Batch) decodeCallFailed(err error) *BatchCallError {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil
+	}
+	encoded, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil
+	}
+	revert, decodeErr := hexutil.Decode(encoded)
+	if decodeErr != nil {
+		return nil
+	}
+
+	callFailed := v2ABI.Errors["CallFailed"]
+	if len(revert) < 4 || string(revert[:4]) != string(callFailed.ID[:4]) {
+		return nil
+	}
+	args, unpackErr := callFailed.Inputs.Unpack(revert[4:])
+	if unpackErr != nil {
+		return nil
+	}
+	index := int(args[0].(*big.Int).Int64())
+	callErr := &BatchCallError{Index: index, ReturnData: args[1].([]byte)}
+	if index < len(b.Calls) {
+		callErr.Target = b.Calls[index].Target
+	}
+	return callErr
+}
+
+// Results reads the per-call outcomes from the CallExecuted logs the account emitted in receipt
+func (b *Batch) Results(receipt *types.Receipt, account common.Address) ([]CallResult, error) {
+	event := v2ABI.Events["CallExecuted"]
+	var results []CallResult
+	for _, entry := range receipt.Logs {
+		if entry.Address != account || len(entry.Topics) != 3 || entry.Topics[0] != event.ID {
+			continue
+		}
+		values, err := event.Inputs.NonIndexed().Unpack(entry.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode CallExecuted: %v", err)
+		}
+		results = append(results, CallResult{
+			Index:      int(entry.Topics[1].Big().Int64()),
+			Target:     common.BytesToAddress(entry.Topics[2].Bytes()),
+			Success:    values[0].(bool),
+			ReturnData: values[1].([]byte),
+		})
+	}
+	if len(results) != len(b.Calls) {
+		return nil, fmt.Errorf("receipt has results for %d of %d batched calls", len(results), len(b.Calls))
+	}
+	return results, nil
+}
@@ -0,0 +1,259 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Version identifies which Smart Account contract is deployed at an address
+type Version int
+
+const (
+	V1 Version = 1 // SmartAccount: execute and session keys only
+	V2 Version = 2 // SmartAccountV2: adds batching and on-chain strategies
+)
+
+func (v Version) String() string {
+	return fmt.Sprintf("V%d", int(v))
+}
+
+// ErrNotV2 is returned by operations that only SmartAccountV2 supports
+var ErrNotV2 = errors.New("smart account is V1; the operation requires SmartAccountV2")
+
+var (
+	v1ABI = mustParseABI(SmartAccountMetaData)
+	v2ABI = mustParseABI(SmartAccountV2MetaData)
+)
+
+func mustParseABI(metadata *bind.MetaData) *abi.ABI {
+	parsed, err := metadata.GetAbi()
+	if err != nil {
+		panic(fmt.Sprintf("invalid ABI: %v", err))
+	}
+	return parsed
+}
+
+// PackExecute encodes SmartAccount.execute, which both versions implement
+func PackExecute(target common.Address, data []byte) ([]byte, error) {
+	return v1ABI.Pack("execute", target, data)
+}
+
+// PackExecuteDCA encodes SmartAccountV2.executeDCA, which the account may call
+// on itself from an executeCalls batch
+func PackExecuteDCA(strategyID uint64) ([]byte, error) {
+	return v2ABI.Pack("executeDCA", new(big.Int).SetUint64(strategyID))
+}
+
+// PackAddSupportedChain encodes SmartAccountV2.addSupportedChain
+func PackAddSupportedChain(chainID uint64, contract common.Address) ([]byte, error) {
+	return v2ABI.Pack("addSupportedChain", new(big.Int).SetUint64(chainID), contract)
+}
+
+// DetectVersion probes nextStrategyId, which only SmartAccountV2 implements
+func DetectVersion(ctx context.Context, address common.Address, backend bind.ContractCaller) (Version, error) {
+	code, err := backend.CodeAt(ctx, address, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read code at %s: %v", address.Hex(), err)
+	}
+	if len(code) == 0 {
+		return 0, fmt.Errorf("no contract deployed at %s", address.Hex())
+	}
+
+	data, _ := v2ABI.Pack("nextStrategyId")
+	output, err := backend.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		// V1 has no fallback, so the unknown selector reverts
+		if strings.Contains(err.Error(), "revert") {
+			return V1, nil
+		}
+		return 0, fmt.Errorf("failed to probe %s: %v", address.Hex(), err)
+	}
+	if len(output) != 32 {
+		return V1, nil
+	}
+	return V2, nil
+}
+
+// SmartAccountClient wraps the bindings of a deployed Smart Account of either version
+type SmartAccountClient struct {
+	Address common.Address
+	Version Version
+	v1      *SmartAccount
+	v2      *SmartAccountV2 // nil on V1
+}
+
+// NewSmartAccountClient detects the contract version at address and binds it
+func NewSmartAccountClient(ctx context.Context, address common.Address, backend bind.ContractBackend) (*SmartAccountClient, error) {
+	version, err := DetectVersion(ctx, address, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &SmartAccountClient{Address: address, Version: version}
+	client.v1, err = NewSmartAccount(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	if version == V2 {
+		client.v2, err = NewSmartAccountV2(address, backend)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+// V2 returns the SmartAccountV2 bindings, e.g. for event filters
+func (c *SmartAccountClient) V2() (*SmartAccountV2, error) {
+	if c.v2 == nil {
+		return nil, ErrNotV2
+	}
+	return c.v2, nil
+}
+
+func (c *SmartAccountClient) Owner(ctx context.Context) (common.Address, error) {
+	return c.v1.Owner(&bind.CallOpts{Context: ctx})
+}
+
+// SessionKeyExpiry returns when a session key stops being authorized; the zero Unix time if it never was
+func (c *SmartAccountClient) SessionKeyExpiry(ctx context.Context, key common.Address) (time.Time, error) {
+	expiresAt, err := c.v1.SessionKeys(&bind.CallOpts{Context: ctx}, key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(expiresAt.Int64(), 0), nil
+}
+
+func (c *SmartAccountClient) SetSessionKey(opts *bind.TransactOpts, key common.Address, expiresAt time.Time) (*types.Transaction, error) {
+	return c.v1.SetSessionKey(opts, key, big.NewInt(expiresAt.Unix()))
+}
+
+func (c *SmartAccountClient) Execute(opts *bind.TransactOpts, target common.Address, data []byte) (*types.Transaction, error) {
+	return c.v1.Execute(opts, target, data)
+}
+
+func (c *SmartAccountClient) ExecuteCalls(opts *bind.TransactOpts, batch *Batch) (*types.Transaction, error) {
+	if c.v2 == nil {
+		return nil, ErrNotV2
+	}
+	return c.v2.ExecuteCalls(opts, batch.Calls, batch.AllowFailure)
+}
+
+// === SmartAccountV2 strategies ===
+
+func (c *SmartAccountClient) NextStrategyID(ctx context.Context) (uint64, error) {
+	if c.v2 == nil {
+		return 0, ErrNotV2
+	}
+	next, err := c.v2.NextStrategyId(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, err
+	}
+	return next.Uint64(), nil
+}
+
+func (c *SmartAccountClient) CreateDCAStrategy(opts *bind.TransactOpts, tokenIn, tokenOut common.Address, amountPerExecution *big.Int, interval time.Duration, maxExecutions uint64) (*types.Transaction, error) {
+	if c.v2 == nil {
+		return nil, ErrNotV2
+	}
+	return c.v2.CreateDCAStrategy(opts, tokenIn, tokenOut, amountPerExecution,
+		big.NewInt(int64(interval/time.Second)), new(big.Int).SetUint64(maxExecutions))
+}
+
+func (c *SmartAccountClient) DCAStrategy(ctx context.Context, strategyID uint64) (*SmartAccountV2DCAStrategy, error) {
+	if c.v2 == nil {
+		return nil, ErrNotV2
+	}
+	strategy, err := c.v2.GetDCAStrategy(&bind.CallOpts{Context: ctx}, new(big.Int).SetUint64(strategyID))
+	if err != nil {
+		return nil, err
+	}
+	return &strategy, nil
+}
+
+func (c *SmartAccountClient) ExecuteDCA(opts *bind.TransactOpts, strategyID uint64) (*types.Transaction, error) {
+	if c.v2 == nil {
+		return nil, ErrNotV2
+	}
+	return c.v2.ExecuteDCA(opts, new(big.Int).SetUint64(strategyID))
+}
+
+// GridStrategyState is a grid strategy as stored in SmartAccountV2
+type GridStrategyState struct {
+	Active    bool
+	TokenA    common.Address
+	TokenB    common.Address
+	GridSize  *big.Int
+	PriceStep *big.Int
+	BasePrice *big.Int
+}
+
+func (c *SmartAccountClient) GridStrategy(ctx context.Context, strategyID uint64) (*GridStrategyState, error) {
+	if c.v2 == nil {
+		return nil, ErrNotV2
+	}
+	strategy, err := c.v2.GridStrategies(&bind.CallOpts{Context: ctx}, new(big.Int).SetUint64(strategyID))
+	if err != nil {
+		return nil, err
+	}
+	state := GridStrategyState(strategy)
+	return &state, nil
+}
+
+func (c *SmartAccountClient) CreateGridStrategy(opts *bind.TransactOpts, tokenA, tokenB common.Address, gridSize uint64, priceStep, basePrice *big.Int) (*types.Transaction, error) {
+	if c.v2 == nil {
+		return nil, ErrNotV2
+	}
+	return c.v2.CreateGridStrategy(opts, tokenA, tokenB, new(big.Int).SetUint64(gridSize), priceStep, basePrice)
+}
+
+// CreateRebalanceStrategy registers target weights in basis points, which must sum to 10000
+func (c *SmartAccountClient) CreateRebalanceStrategy(opts *bind.TransactOpts, tokens []common.Address, targetBps []uint64, thresholdBps uint64, minInterval time.Duration) (*types.Transaction, error) {
+	if c.v2 == nil {
+		return nil, ErrNotV2
+	}
+	targets := make([]*big.Int, len(targetBps))
+	for i, bps := range targetBps {
+		targets[i] = new(big.Int).SetUint64(bps)
+	}
+	return c.v2.CreateRebalanceStrategy(opts, tokens, targets,
+		new(big.Int).SetUint64(thresholdBps), big.NewInt(int64(minInterval/time.Second)))
+}
+
+// PauseStrategy deactivates a strategy; strategyType is "DCA", "Grid" or "Rebalance"
+func (c *SmartAccountClient) PauseStrategy(opts *bind.TransactOpts, strategyID uint64, strategyType string) (*types.Transaction, error) {
+	if c.v2 == nil {
+		return nil, ErrNotV2
+	}
+	return c.v2.PauseStrategy(opts, new(big.Int).SetUint64(strategyID), strategyType)
+}
+
+func (c *SmartAccountClient) AddSupportedChain(opts *bind.TransactOpts, chainID uint64, contract common.Address) (*types.Transaction, error) {
+	if c.v2 == nil {
+		return nil, ErrNotV2
+	}
+	return c.v2.AddSupportedChain(opts, new(big.Int).SetUint64(chainID), contract)
+}
+
+// CrossChainContract returns the account registered for chainID, or the zero address if the chain is not supported
+func (c *SmartAccountClient) CrossChainContract(ctx context.Context, chainID uint64) (common.Address, error) {
+	if c.v2 == nil {
+		return common.Address{}, ErrNotV2
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	supported, err := c.v2.IsChainSupported(opts, new(big.Int).SetUint64(chainID))
+	if err != nil || !supported {
+		return common.Address{}, err
+	}
+	return c.v2.GetCrossChainContract(opts, new(big.Int).SetUint64(chainID))
+}
@@ -0,0 +1,67 @@
+package contracts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DeterministicDeployer is the keyless CREATE2 factory (Arachnid's) present on
+// most chains. Called with salt ++ initCode it deploys to an address that only
+// depends on the salt and the init code, so an account deployed with the same
+// owner and salt has the same address on every chain.
+var DeterministicDeployer = common.HexToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C")
+
+// forgeArtifact is the part of a `forge build` output file holding the creation code
+type forgeArtifact struct {
+	Bytecode struct {
+		Object string `json:"object"`
+	} `json:"bytecode"`
+}
+
+// LoadArtifact reads the creation bytecode from a forge artifact, e.g.
+// blockchain/out/SmartAccountV2.sol/SmartAccountV2.json. The bindings carry no
+// bytecode, so deployments use what `forge build` compiled.
+func LoadArtifact(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact: %v", err)
+	}
+	var artifact forgeArtifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("failed to decode artifact %s: %v", path, err)
+	}
+	bytecode, err := hexutil.Decode(artifact.Bytecode.Object)
+	if err != nil || len(bytecode) == 0 {
+		return nil, fmt.Errorf("artifact %s has no creation bytecode", path)
+	}
+	return bytecode, nil
+}
+
+// InitCode appends the constructor arguments, which both account versions share, to bytecode
+func InitCode(bytecode []byte, owner common.Address) ([]byte, error) {
+	args, err := v1ABI.Constructor.Inputs.Pack(owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack constructor: %v", err)
+	}
+	return append(append([]byte{}, bytecode...), args...), nil
+}
+
+// DeploymentSalt derives a CREATE2 salt from a label such as "sentinel-account-v1"
+func DeploymentSalt(label string) common.Hash {
+	return crypto.Keccak256Hash([]byte(label))
+}
+
+// DeterministicAddress is where the DeterministicDeployer puts initCode with salt
+func DeterministicAddress(salt common.Hash, initCode []byte) common.Address {
+	return crypto.CreateAddress2(DeterministicDeployer, salt, crypto.Keccak256(initCode))
+}
+
+// DeploymentData is the calldata to send to the DeterministicDeployer
+func DeploymentData(salt common.Hash, initCode []byte) []byte {
+	return append(salt.Bytes(), initCode...)
+}
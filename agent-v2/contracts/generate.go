@@ -0,0 +1,9 @@
+// Package contracts holds Go bindings for the Solidity contracts in
+// blockchain/contracts and a client that drives either Smart Account version.
+//
+// The ABIs are those produced by `forge inspect <Contract> abi`; regenerate the
+// bindings after changing a contract with `go generate ./contracts`.
+package contracts
+
+//go:generate abigen --abi SmartAccount.abi --pkg contracts --type SmartAccount --out smartaccount.go
+//go:generate abigen --abi SmartAccountV2.abi --pkg contracts --type SmartAccountV2 --out smartaccountv2.go
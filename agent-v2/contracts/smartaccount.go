@@ -0,0 +1,285 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// SmartAccountMetaData contains all meta data concerning the SmartAccount contract.
+var SmartAccountMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"constructor\",\"inputs\":[{\"name\":\"_owner\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"execute\",\"inputs\":[{\"name\":\"target\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"data\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"sessionKeys\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"setSessionKey\",\"inputs\":[{\"name\":\"key\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"expiresAt\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"}]",
+}
+
+// SmartAccountABI is the input ABI used to generate the binding from.
+// Deprecated: Use SmartAccountMetaData.ABI instead.
+var SmartAccountABI = SmartAccountMetaData.ABI
+
+// SmartAccount is an auto generated Go binding around an Ethereum contract.
+type SmartAccount struct {
+	SmartAccountCaller     // Read-only binding to the contract
+	SmartAccountTransactor // Write-only binding to the contract
+	SmartAccountFilterer   // Log filterer for contract events
+}
+
+// SmartAccountCaller is an auto generated read-only Go binding around an Ethereum contract.
+type SmartAccountCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SmartAccountTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type SmartAccountTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SmartAccountFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type SmartAccountFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SmartAccountSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type SmartAccountSession struct {
+	Contract     *SmartAccount     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// SmartAccountCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type SmartAccountCallerSession struct {
+	Contract *SmartAccountCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// SmartAccountTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type SmartAccountTransactorSession struct {
+	Contract     *SmartAccountTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// SmartAccountRaw is an auto generated low-level Go binding around an Ethereum contract.
+type SmartAccountRaw struct {
+	Contract *SmartAccount // Generic contract binding to access the raw methods on
+}
+
+// SmartAccountCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type SmartAccountCallerRaw struct {
+	Contract *SmartAccountCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// SmartAccountTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type SmartAccountTransactorRaw struct {
+	Contract *SmartAccountTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewSmartAccount creates a new instance of SmartAccount, bound to a specific deployed contract.
+func NewSmartAccount(address common.Address, backend bind.ContractBackend) (*SmartAccount, error) {
+	contract, err := bindSmartAccount(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccount{SmartAccountCaller: SmartAccountCaller{contract: contract}, SmartAccountTransactor: SmartAccountTransactor{contract: contract}, SmartAccountFilterer: SmartAccountFilterer{contract: contract}}, nil
+}
+
+// NewSmartAccountCaller creates a new read-only instance of SmartAccount, bound to a specific deployed contract.
+func NewSmartAccountCaller(address common.Address, caller bind.ContractCaller) (*SmartAccountCaller, error) {
+	contract, err := bindSmartAccount(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountCaller{contract: contract}, nil
+}
+
+// NewSmartAccountTransactor creates a new write-only instance of SmartAccount, bound to a specific deployed contract.
+func NewSmartAccountTransactor(address common.Address, transactor bind.ContractTransactor) (*SmartAccountTransactor, error) {
+	contract, err := bindSmartAccount(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountTransactor{contract: contract}, nil
+}
+
+// NewSmartAccountFilterer creates a new log filterer instance of SmartAccount, bound to a specific deployed contract.
+func NewSmartAccountFilterer(address common.Address, filterer bind.ContractFilterer) (*SmartAccountFilterer, error) {
+	contract, err := bindSmartAccount(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountFilterer{contract: contract}, nil
+}
+
+// bindSmartAccount binds a generic wrapper to an already deployed contract.
+func bindSmartAccount(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := SmartAccountMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SmartAccount *SmartAccountRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SmartAccount.Contract.SmartAccountCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SmartAccount *SmartAccountRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SmartAccount.Contract.SmartAccountTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SmartAccount *SmartAccountRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SmartAccount.Contract.SmartAccountTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SmartAccount *SmartAccountCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SmartAccount.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SmartAccount *SmartAccountTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SmartAccount.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SmartAccount *SmartAccountTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SmartAccount.Contract.contract.Transact(opts, method, params...)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_SmartAccount *SmartAccountCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _SmartAccount.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_SmartAccount *SmartAccountSession) Owner() (common.Address, error) {
+	return _SmartAccount.Contract.Owner(&_SmartAccount.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_SmartAccount *SmartAccountCallerSession) Owner() (common.Address, error) {
+	return _SmartAccount.Contract.Owner(&_SmartAccount.CallOpts)
+}
+
+// SessionKeys is a free data retrieval call binding the contract method 0xb7b8d604.
+//
+// Solidity: function sessionKeys(address ) view returns(uint256)
+func (_SmartAccount *SmartAccountCaller) SessionKeys(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _SmartAccount.contract.Call(opts, &out, "sessionKeys", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SessionKeys is a free data retrieval call binding the contract method 0xb7b8d604.
+//
+// Solidity: function sessionKeys(address ) view returns(uint256)
+func (_SmartAccount *SmartAccountSession) SessionKeys(arg0 common.Address) (*big.Int, error) {
+	return _SmartAccount.Contract.SessionKeys(&_SmartAccount.CallOpts, arg0)
+}
+
+// SessionKeys is a free data retrieval call binding the contract method 0xb7b8d604.
+//
+// Solidity: function sessionKeys(address ) view returns(uint256)
+func (_SmartAccount *SmartAccountCallerSession) SessionKeys(arg0 common.Address) (*big.Int, error) {
+	return _SmartAccount.Contract.SessionKeys(&_SmartAccount.CallOpts, arg0)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x1cff79cd.
+//
+// Solidity: function execute(address target, bytes data) returns()
+func (_SmartAccount *SmartAccountTransactor) Execute(opts *bind.TransactOpts, target common.Address, data []byte) (*types.Transaction, error) {
+	return _SmartAccount.contract.Transact(opts, "execute", target, data)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x1cff79cd.
+//
+// Solidity: function execute(address target, bytes data) returns()
+func (_SmartAccount *SmartAccountSession) Execute(target common.Address, data []byte) (*types.Transaction, error) {
+	return _SmartAccount.Contract.Execute(&_SmartAccount.TransactOpts, target, data)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x1cff79cd.
+//
+// Solidity: function execute(address target, bytes data) returns()
+func (_SmartAccount *SmartAccountTransactorSession) Execute(target common.Address, data []byte) (*types.Transaction, error) {
+	return _SmartAccount.Contract.Execute(&_SmartAccount.TransactOpts, target, data)
+}
+
+// SetSessionKey is a paid mutator transaction binding the contract method 0x1af99774.
+//
+// Solidity: function setSessionKey(address key, uint256 expiresAt) returns()
+func (_SmartAccount *SmartAccountTransactor) SetSessionKey(opts *bind.TransactOpts, key common.Address, expiresAt *big.Int) (*types.Transaction, error) {
+	return _SmartAccount.contract.Transact(opts, "setSessionKey", key, expiresAt)
+}
+
+// SetSessionKey is a paid mutator transaction binding the contract method 0x1af99774.
+//
+// Solidity: function setSessionKey(address key, uint256 expiresAt) returns()
+func (_SmartAccount *SmartAccountSession) SetSessionKey(key common.Address, expiresAt *big.Int) (*types.Transaction, error) {
+	return _SmartAccount.Contract.SetSessionKey(&_SmartAccount.TransactOpts, key, expiresAt)
+}
+
+// SetSessionKey is a paid mutator transaction binding the contract method 0x1af99774.
+//
+// Solidity: function setSessionKey(address key, uint256 expiresAt) returns()
+func (_SmartAccount *SmartAccountTransactorSession) SetSessionKey(key common.Address, expiresAt *big.Int) (*types.Transaction, error) {
+	return _SmartAccount.Contract.SetSessionKey(&_SmartAccount.TransactOpts, key, expiresAt)
+}
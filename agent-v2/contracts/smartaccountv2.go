@@ -0,0 +1,1702 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// SmartAccountV2Call is an auto generated low-level Go binding around an user-defined struct.
+type SmartAccountV2Call struct {
+	Target common.Address
+	Value  *big.Int
+	Data   []byte
+}
+
+// SmartAccountV2DCAStrategy is an auto generated low-level Go binding around an user-defined struct.
+type SmartAccountV2DCAStrategy struct {
+	Active             bool
+	TokenIn            common.Address
+	TokenOut           common.Address
+	AmountPerExecution *big.Int
+	Interval           *big.Int
+	LastExecution      *big.Int
+	TotalExecutions    *big.Int
+	MaxExecutions      *big.Int
+}
+
+// SmartAccountV2PackedUserOperation is an auto generated low-level Go binding around an user-defined struct.
+type SmartAccountV2PackedUserOperation struct {
+	Sender             common.Address
+	Nonce              *big.Int
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   [32]byte
+	PreVerificationGas *big.Int
+	GasFees            [32]byte
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+// SmartAccountV2MetaData contains all meta data concerning the SmartAccountV2 contract.
+var SmartAccountV2MetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"constructor\",\"inputs\":[{\"name\":\"_owner\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"receive\",\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"ENTRY_POINT\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"addSupportedChain\",\"inputs\":[{\"name\":\"chainId\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"contractAddress\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"createDCAStrategy\",\"inputs\":[{\"name\":\"tokenIn\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"tokenOut\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amountPerExecution\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"interval\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"maxExecutions\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"strategyId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"createGridStrategy\",\"inputs\":[{\"name\":\"tokenA\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"tokenB\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"gridSize\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"priceStep\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"basePrice\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"strategyId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"createRebalanceStrategy\",\"inputs\":[{\"name\":\"tokens\",\"type\":\"address[]\",\"internalType\":\"address[]\"},{\"name\":\"targetPercentages\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"},{\"name\":\"rebalanceThreshold\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"minInterval\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"strategyId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"crossChainContracts\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"dcaStrategies\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"active\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"tokenIn\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"tokenOut\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amountPerExecution\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"interval\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"lastExecution\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"totalExecutions\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"maxExecutions\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"execute\",\"inputs\":[{\"name\":\"target\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"data\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"executeCalls\",\"inputs\":[{\"name\":\"calls\",\"type\":\"tuple[]\",\"internalType\":\"structSmartAccountV2.Call[]\",\"components\":[{\"name\":\"target\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"data\",\"type\":\"bytes\",\"internalType\":\"bytes\"}]},{\"name\":\"allowFailure\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"outputs\":[{\"name\":\"successes\",\"type\":\"bool[]\",\"internalType\":\"bool[]\"},{\"name\":\"results\",\"type\":\"bytes[]\",\"internalType\":\"bytes[]\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"executeDCA\",\"inputs\":[{\"name\":\"strategyId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"executeRebalance\",\"inputs\":[{\"name\":\"strategyId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"getCrossChainContract\",\"inputs\":[{\"name\":\"chainId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getDCAStrategy\",\"inputs\":[{\"name\":\"strategyId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"tuple\",\"internalType\":\"structSmartAccountV2.DCAStrategy\",\"components\":[{\"name\":\"active\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"tokenIn\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"tokenOut\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amountPerExecution\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"interval\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"lastExecution\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"totalExecutions\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"maxExecutions\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"gridStrategies\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"active\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"tokenA\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"tokenB\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"gridSize\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"priceStep\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"basePrice\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"isChainSupported\",\"inputs\":[{\"name\":\"chainId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"nextStrategyId\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"pauseStrategy\",\"inputs\":[{\"name\":\"strategyId\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"strategyType\",\"type\":\"string\",\"internalType\":\"string\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"rebalanceStrategies\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"active\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"rebalanceThreshold\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"lastRebalance\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"minInterval\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"removeSupportedChain\",\"inputs\":[{\"name\":\"chainId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"sessionKeys\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"setSessionKey\",\"inputs\":[{\"name\":\"key\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"expiresAt\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"supportedChains\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"validateUserOp\",\"inputs\":[{\"name\":\"userOp\",\"type\":\"tuple\",\"internalType\":\"structSmartAccountV2.PackedUserOperation\",\"components\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"nonce\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"initCode\",\"type\":\"bytes\",\"internalType\":\"bytes\"},{\"name\":\"callData\",\"type\":\"bytes\",\"internalType\":\"bytes\"},{\"name\":\"accountGasLimits\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"preVerificationGas\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"gasFees\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"paymasterAndData\",\"type\":\"bytes\",\"internalType\":\"bytes\"},{\"name\":\"signature\",\"type\":\"bytes\",\"internalType\":\"bytes\"}]},{\"name\":\"userOpHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"missingAccountFunds\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"validationData\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"event\",\"name\":\"ChainAdded\",\"inputs\":[{\"name\":\"chainId\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"contractAddress\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"CallExecuted\",\"inputs\":[{\"name\":\"index\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"target\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"success\",\"type\":\"bool\",\"indexed\":false,\"internalType\":\"bool\"},{\"name\":\"returnData\",\"type\":\"bytes\",\"indexed\":false,\"internalType\":\"bytes\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"StrategyCreated\",\"inputs\":[{\"name\":\"strategyId\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"strategyType\",\"type\":\"string\",\"indexed\":false,\"internalType\":\"string\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"StrategyExecuted\",\"inputs\":[{\"name\":\"strategyId\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"executor\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"StrategyPaused\",\"inputs\":[{\"name\":\"strategyId\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"error\",\"name\":\"CallFailed\",\"inputs\":[{\"name\":\"index\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"returnData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}]}]",
+}
+
+// SmartAccountV2ABI is the input ABI used to generate the binding from.
+// Deprecated: Use SmartAccountV2MetaData.ABI instead.
+var SmartAccountV2ABI = SmartAccountV2MetaData.ABI
+
+// SmartAccountV2 is an auto generated Go binding around an Ethereum contract.
+type SmartAccountV2 struct {
+	SmartAccountV2Caller     // Read-only binding to the contract
+	SmartAccountV2Transactor // Write-only binding to the contract
+	SmartAccountV2Filterer   // Log filterer for contract events
+}
+
+// SmartAccountV2Caller is an auto generated read-only Go binding around an Ethereum contract.
+type SmartAccountV2Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SmartAccountV2Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type SmartAccountV2Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SmartAccountV2Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type SmartAccountV2Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SmartAccountV2Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type SmartAccountV2Session struct {
+	Contract     *SmartAccountV2   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// SmartAccountV2CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type SmartAccountV2CallerSession struct {
+	Contract *SmartAccountV2Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// SmartAccountV2TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type SmartAccountV2TransactorSession struct {
+	Contract     *SmartAccountV2Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// SmartAccountV2Raw is an auto generated low-level Go binding around an Ethereum contract.
+type SmartAccountV2Raw struct {
+	Contract *SmartAccountV2 // Generic contract binding to access the raw methods on
+}
+
+// SmartAccountV2CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type SmartAccountV2CallerRaw struct {
+	Contract *SmartAccountV2Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// SmartAccountV2TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type SmartAccountV2TransactorRaw struct {
+	Contract *SmartAccountV2Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewSmartAccountV2 creates a new instance of SmartAccountV2, bound to a specific deployed contract.
+func NewSmartAccountV2(address common.Address, backend bind.ContractBackend) (*SmartAccountV2, error) {
+	contract, err := bindSmartAccountV2(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountV2{SmartAccountV2Caller: SmartAccountV2Caller{contract: contract}, SmartAccountV2Transactor: SmartAccountV2Transactor{contract: contract}, SmartAccountV2Filterer: SmartAccountV2Filterer{contract: contract}}, nil
+}
+
+// NewSmartAccountV2Caller creates a new read-only instance of SmartAccountV2, bound to a specific deployed contract.
+func NewSmartAccountV2Caller(address common.Address, caller bind.ContractCaller) (*SmartAccountV2Caller, error) {
+	contract, err := bindSmartAccountV2(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountV2Caller{contract: contract}, nil
+}
+
+// NewSmartAccountV2Transactor creates a new write-only instance of SmartAccountV2, bound to a specific deployed contract.
+func NewSmartAccountV2Transactor(address common.Address, transactor bind.ContractTransactor) (*SmartAccountV2Transactor, error) {
+	contract, err := bindSmartAccountV2(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountV2Transactor{contract: contract}, nil
+}
+
+// NewSmartAccountV2Filterer creates a new log filterer instance of SmartAccountV2, bound to a specific deployed contract.
+func NewSmartAccountV2Filterer(address common.Address, filterer bind.ContractFilterer) (*SmartAccountV2Filterer, error) {
+	contract, err := bindSmartAccountV2(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountV2Filterer{contract: contract}, nil
+}
+
+// bindSmartAccountV2 binds a generic wrapper to an already deployed contract.
+func bindSmartAccountV2(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := SmartAccountV2MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SmartAccountV2 *SmartAccountV2Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SmartAccountV2.Contract.SmartAccountV2Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SmartAccountV2 *SmartAccountV2Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.SmartAccountV2Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SmartAccountV2 *SmartAccountV2Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.SmartAccountV2Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SmartAccountV2 *SmartAccountV2CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SmartAccountV2.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SmartAccountV2 *SmartAccountV2TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SmartAccountV2 *SmartAccountV2TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.contract.Transact(opts, method, params...)
+}
+
+// ENTRYPOINT is a free data retrieval call binding the contract method 0x94430fa5.
+//
+// Solidity: function ENTRY_POINT() view returns(address)
+func (_SmartAccountV2 *SmartAccountV2Caller) ENTRYPOINT(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "ENTRY_POINT")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// ENTRYPOINT is a free data retrieval call binding the contract method 0x94430fa5.
+//
+// Solidity: function ENTRY_POINT() view returns(address)
+func (_SmartAccountV2 *SmartAccountV2Session) ENTRYPOINT() (common.Address, error) {
+	return _SmartAccountV2.Contract.ENTRYPOINT(&_SmartAccountV2.CallOpts)
+}
+
+// ENTRYPOINT is a free data retrieval call binding the contract method 0x94430fa5.
+//
+// Solidity: function ENTRY_POINT() view returns(address)
+func (_SmartAccountV2 *SmartAccountV2CallerSession) ENTRYPOINT() (common.Address, error) {
+	return _SmartAccountV2.Contract.ENTRYPOINT(&_SmartAccountV2.CallOpts)
+}
+
+// CrossChainContracts is a free data retrieval call binding the contract method 0x09474ae2.
+//
+// Solidity: function crossChainContracts(uint256 ) view returns(address)
+func (_SmartAccountV2 *SmartAccountV2Caller) CrossChainContracts(opts *bind.CallOpts, arg0 *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "crossChainContracts", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// CrossChainContracts is a free data retrieval call binding the contract method 0x09474ae2.
+//
+// Solidity: function crossChainContracts(uint256 ) view returns(address)
+func (_SmartAccountV2 *SmartAccountV2Session) CrossChainContracts(arg0 *big.Int) (common.Address, error) {
+	return _SmartAccountV2.Contract.CrossChainContracts(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// CrossChainContracts is a free data retrieval call binding the contract method 0x09474ae2.
+//
+// Solidity: function crossChainContracts(uint256 ) view returns(address)
+func (_SmartAccountV2 *SmartAccountV2CallerSession) CrossChainContracts(arg0 *big.Int) (common.Address, error) {
+	return _SmartAccountV2.Contract.CrossChainContracts(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// DcaStrategies is a free data retrieval call binding the contract method 0xf5ec18f5.
+//
+// Solidity: function dcaStrategies(uint256 ) view returns(bool active, address tokenIn, address tokenOut, uint256 amountPerExecution, uint256 interval, uint256 lastExecution, uint256 totalExecutions, uint256 maxExecutions)
+func (_SmartAccountV2 *SmartAccountV2Caller) DcaStrategies(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Active             bool
+	TokenIn            common.Address
+	TokenOut           common.Address
+	AmountPerExecution *big.Int
+	Interval           *big.Int
+	LastExecution      *big.Int
+	TotalExecutions    *big.Int
+	MaxExecutions      *big.Int
+}, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "dcaStrategies", arg0)
+
+	outstruct := new(struct {
+		Active             bool
+		TokenIn            common.Address
+		TokenOut           common.Address
+		AmountPerExecution *big.Int
+		Interval           *big.Int
+		LastExecution      *big.Int
+		TotalExecutions    *big.Int
+		MaxExecutions      *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Active = *abi.ConvertType(out[0], new(bool)).(*bool)
+	outstruct.TokenIn = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.TokenOut = *abi.ConvertType(out[2], new(common.Address)).(*common.Address)
+	outstruct.AmountPerExecution = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.Interval = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.LastExecution = *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+	outstruct.TotalExecutions = *abi.ConvertType(out[6], new(*big.Int)).(**big.Int)
+	outstruct.MaxExecutions = *abi.ConvertType(out[7], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// DcaStrategies is a free data retrieval call binding the contract method 0xf5ec18f5.
+//
+// Solidity: function dcaStrategies(uint256 ) view returns(bool active, address tokenIn, address tokenOut, uint256 amountPerExecution, uint256 interval, uint256 lastExecution, uint256 totalExecutions, uint256 maxExecutions)
+func (_SmartAccountV2 *SmartAccountV2Session) DcaStrategies(arg0 *big.Int) (struct {
+	Active             bool
+	TokenIn            common.Address
+	TokenOut           common.Address
+	AmountPerExecution *big.Int
+	Interval           *big.Int
+	LastExecution      *big.Int
+	TotalExecutions    *big.Int
+	MaxExecutions      *big.Int
+}, error) {
+	return _SmartAccountV2.Contract.DcaStrategies(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// DcaStrategies is a free data retrieval call binding the contract method 0xf5ec18f5.
+//
+// Solidity: function dcaStrategies(uint256 ) view returns(bool active, address tokenIn, address tokenOut, uint256 amountPerExecution, uint256 interval, uint256 lastExecution, uint256 totalExecutions, uint256 maxExecutions)
+func (_SmartAccountV2 *SmartAccountV2CallerSession) DcaStrategies(arg0 *big.Int) (struct {
+	Active             bool
+	TokenIn            common.Address
+	TokenOut           common.Address
+	AmountPerExecution *big.Int
+	Interval           *big.Int
+	LastExecution      *big.Int
+	TotalExecutions    *big.Int
+	MaxExecutions      *big.Int
+}, error) {
+	return _SmartAccountV2.Contract.DcaStrategies(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// GetCrossChainContract is a free data retrieval call binding the contract method 0x9ca8a65e.
+//
+// Solidity: function getCrossChainContract(uint256 chainId) view returns(address)
+func (_SmartAccountV2 *SmartAccountV2Caller) GetCrossChainContract(opts *bind.CallOpts, chainId *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "getCrossChainContract", chainId)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetCrossChainContract is a free data retrieval call binding the contract method 0x9ca8a65e.
+//
+// Solidity: function getCrossChainContract(uint256 chainId) view returns(address)
+func (_SmartAccountV2 *SmartAccountV2Session) GetCrossChainContract(chainId *big.Int) (common.Address, error) {
+	return _SmartAccountV2.Contract.GetCrossChainContract(&_SmartAccountV2.CallOpts, chainId)
+}
+
+// GetCrossChainContract is a free data retrieval call binding the contract method 0x9ca8a65e.
+//
+// Solidity: function getCrossChainContract(uint256 chainId) view returns(address)
+func (_SmartAccountV2 *SmartAccountV2CallerSession) GetCrossChainContract(chainId *big.Int) (common.Address, error) {
+	return _SmartAccountV2.Contract.GetCrossChainContract(&_SmartAccountV2.CallOpts, chainId)
+}
+
+// GetDCAStrategy is a free data retrieval call binding the contract method 0xcd87e5e5.
+//
+// Solidity: function getDCAStrategy(uint256 strategyId) view returns((bool,address,address,uint256,uint256,uint256,uint256,uint256))
+func (_SmartAccountV2 *SmartAccountV2Caller) GetDCAStrategy(opts *bind.CallOpts, strategyId *big.Int) (SmartAccountV2DCAStrategy, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "getDCAStrategy", strategyId)
+
+	if err != nil {
+		return *new(SmartAccountV2DCAStrategy), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(SmartAccountV2DCAStrategy)).(*SmartAccountV2DCAStrategy)
+
+	return out0, err
+
+}
+
+// GetDCAStrategy is a free data retrieval call binding the contract method 0xcd87e5e5.
+//
+// Solidity: function getDCAStrategy(uint256 strategyId) view returns((bool,address,address,uint256,uint256,uint256,uint256,uint256))
+func (_SmartAccountV2 *SmartAccountV2Session) GetDCAStrategy(strategyId *big.Int) (SmartAccountV2DCAStrategy, error) {
+	return _SmartAccountV2.Contract.GetDCAStrategy(&_SmartAccountV2.CallOpts, strategyId)
+}
+
+// GetDCAStrategy is a free data retrieval call binding the contract method 0xcd87e5e5.
+//
+// Solidity: function getDCAStrategy(uint256 strategyId) view returns((bool,address,address,uint256,uint256,uint256,uint256,uint256))
+func (_SmartAccountV2 *SmartAccountV2CallerSession) GetDCAStrategy(strategyId *big.Int) (SmartAccountV2DCAStrategy, error) {
+	return _SmartAccountV2.Contract.GetDCAStrategy(&_SmartAccountV2.CallOpts, strategyId)
+}
+
+// GridStrategies is a free data retrieval call binding the contract method 0xc5685e44.
+//
+// Solidity: function gridStrategies(uint256 ) view returns(bool active, address tokenA, address tokenB, uint256 gridSize, uint256 priceStep, uint256 basePrice)
+func (_SmartAccountV2 *SmartAccountV2Caller) GridStrategies(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Active    bool
+	TokenA    common.Address
+	TokenB    common.Address
+	GridSize  *big.Int
+	PriceStep *big.Int
+	BasePrice *big.Int
+}, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "gridStrategies", arg0)
+
+	outstruct := new(struct {
+		Active    bool
+		TokenA    common.Address
+		TokenB    common.Address
+		GridSize  *big.Int
+		PriceStep *big.Int
+		BasePrice *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Active = *abi.ConvertType(out[0], new(bool)).(*bool)
+	outstruct.TokenA = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.TokenB = *abi.ConvertType(out[2], new(common.Address)).(*common.Address)
+	outstruct.GridSize = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.PriceStep = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.BasePrice = *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GridStrategies is a free data retrieval call binding the contract method 0xc5685e44.
+//
+// Solidity: function gridStrategies(uint256 ) view returns(bool active, address tokenA, address tokenB, uint256 gridSize, uint256 priceStep, uint256 basePrice)
+func (_SmartAccountV2 *SmartAccountV2Session) GridStrategies(arg0 *big.Int) (struct {
+	Active    bool
+	TokenA    common.Address
+	TokenB    common.Address
+	GridSize  *big.Int
+	PriceStep *big.Int
+	BasePrice *big.Int
+}, error) {
+	return _SmartAccountV2.Contract.GridStrategies(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// GridStrategies is a free data retrieval call binding the contract method 0xc5685e44.
+//
+// Solidity: function gridStrategies(uint256 ) view returns(bool active, address tokenA, address tokenB, uint256 gridSize, uint256 priceStep, uint256 basePrice)
+func (_SmartAccountV2 *SmartAccountV2CallerSession) GridStrategies(arg0 *big.Int) (struct {
+	Active    bool
+	TokenA    common.Address
+	TokenB    common.Address
+	GridSize  *big.Int
+	PriceStep *big.Int
+	BasePrice *big.Int
+}, error) {
+	return _SmartAccountV2.Contract.GridStrategies(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// IsChainSupported is a free data retrieval call binding the contract method 0x5221c1f0.
+//
+// Solidity: function isChainSupported(uint256 chainId) view returns(bool)
+func (_SmartAccountV2 *SmartAccountV2Caller) IsChainSupported(opts *bind.CallOpts, chainId *big.Int) (bool, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "isChainSupported", chainId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsChainSupported is a free data retrieval call binding the contract method 0x5221c1f0.
+//
+// Solidity: function isChainSupported(uint256 chainId) view returns(bool)
+func (_SmartAccountV2 *SmartAccountV2Session) IsChainSupported(chainId *big.Int) (bool, error) {
+	return _SmartAccountV2.Contract.IsChainSupported(&_SmartAccountV2.CallOpts, chainId)
+}
+
+// IsChainSupported is a free data retrieval call binding the contract method 0x5221c1f0.
+//
+// Solidity: function isChainSupported(uint256 chainId) view returns(bool)
+func (_SmartAccountV2 *SmartAccountV2CallerSession) IsChainSupported(chainId *big.Int) (bool, error) {
+	return _SmartAccountV2.Contract.IsChainSupported(&_SmartAccountV2.CallOpts, chainId)
+}
+
+// NextStrategyId is a free data retrieval call binding the contract method 0x185f8447.
+//
+// Solidity: function nextStrategyId() view returns(uint256)
+func (_SmartAccountV2 *SmartAccountV2Caller) NextStrategyId(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "nextStrategyId")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NextStrategyId is a free data retrieval call binding the contract method 0x185f8447.
+//
+// Solidity: function nextStrategyId() view returns(uint256)
+func (_SmartAccountV2 *SmartAccountV2Session) NextStrategyId() (*big.Int, error) {
+	return _SmartAccountV2.Contract.NextStrategyId(&_SmartAccountV2.CallOpts)
+}
+
+// NextStrategyId is a free data retrieval call binding the contract method 0x185f8447.
+//
+// Solidity: function nextStrategyId() view returns(uint256)
+func (_SmartAccountV2 *SmartAccountV2CallerSession) NextStrategyId() (*big.Int, error) {
+	return _SmartAccountV2.Contract.NextStrategyId(&_SmartAccountV2.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_SmartAccountV2 *SmartAccountV2Caller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_SmartAccountV2 *SmartAccountV2Session) Owner() (common.Address, error) {
+	return _SmartAccountV2.Contract.Owner(&_SmartAccountV2.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_SmartAccountV2 *SmartAccountV2CallerSession) Owner() (common.Address, error) {
+	return _SmartAccountV2.Contract.Owner(&_SmartAccountV2.CallOpts)
+}
+
+// RebalanceStrategies is a free data retrieval call binding the contract method 0xa37aa3c3.
+//
+// Solidity: function rebalanceStrategies(uint256 ) view returns(bool active, uint256 rebalanceThreshold, uint256 lastRebalance, uint256 minInterval)
+func (_SmartAccountV2 *SmartAccountV2Caller) RebalanceStrategies(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Active             bool
+	RebalanceThreshold *big.Int
+	LastRebalance      *big.Int
+	MinInterval        *big.Int
+}, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "rebalanceStrategies", arg0)
+
+	outstruct := new(struct {
+		Active             bool
+		RebalanceThreshold *big.Int
+		LastRebalance      *big.Int
+		MinInterval        *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Active = *abi.ConvertType(out[0], new(bool)).(*bool)
+	outstruct.RebalanceThreshold = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.LastRebalance = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.MinInterval = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// RebalanceStrategies is a free data retrieval call binding the contract method 0xa37aa3c3.
+//
+// Solidity: function rebalanceStrategies(uint256 ) view returns(bool active, uint256 rebalanceThreshold, uint256 lastRebalance, uint256 minInterval)
+func (_SmartAccountV2 *SmartAccountV2Session) RebalanceStrategies(arg0 *big.Int) (struct {
+	Active             bool
+	RebalanceThreshold *big.Int
+	LastRebalance      *big.Int
+	MinInterval        *big.Int
+}, error) {
+	return _SmartAccountV2.Contract.RebalanceStrategies(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// RebalanceStrategies is a free data retrieval call binding the contract method 0xa37aa3c3.
+//
+// Solidity: function rebalanceStrategies(uint256 ) view returns(bool active, uint256 rebalanceThreshold, uint256 lastRebalance, uint256 minInterval)
+func (_SmartAccountV2 *SmartAccountV2CallerSession) RebalanceStrategies(arg0 *big.Int) (struct {
+	Active             bool
+	RebalanceThreshold *big.Int
+	LastRebalance      *big.Int
+	MinInterval        *big.Int
+}, error) {
+	return _SmartAccountV2.Contract.RebalanceStrategies(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// SessionKeys is a free data retrieval call binding the contract method 0xb7b8d604.
+//
+// Solidity: function sessionKeys(address ) view returns(uint256)
+func (_SmartAccountV2 *SmartAccountV2Caller) SessionKeys(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "sessionKeys", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SessionKeys is a free data retrieval call binding the contract method 0xb7b8d604.
+//
+// Solidity: function sessionKeys(address ) view returns(uint256)
+func (_SmartAccountV2 *SmartAccountV2Session) SessionKeys(arg0 common.Address) (*big.Int, error) {
+	return _SmartAccountV2.Contract.SessionKeys(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// SessionKeys is a free data retrieval call binding the contract method 0xb7b8d604.
+//
+// Solidity: function sessionKeys(address ) view returns(uint256)
+func (_SmartAccountV2 *SmartAccountV2CallerSession) SessionKeys(arg0 common.Address) (*big.Int, error) {
+	return _SmartAccountV2.Contract.SessionKeys(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// SupportedChains is a free data retrieval call binding the contract method 0x548d496f.
+//
+// Solidity: function supportedChains(uint256 ) view returns(bool)
+func (_SmartAccountV2 *SmartAccountV2Caller) SupportedChains(opts *bind.CallOpts, arg0 *big.Int) (bool, error) {
+	var out []interface{}
+	err := _SmartAccountV2.contract.Call(opts, &out, "supportedChains", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SupportedChains is a free data retrieval call binding the contract method 0x548d496f.
+//
+// Solidity: function supportedChains(uint256 ) view returns(bool)
+func (_SmartAccountV2 *SmartAccountV2Session) SupportedChains(arg0 *big.Int) (bool, error) {
+	return _SmartAccountV2.Contract.SupportedChains(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// SupportedChains is a free data retrieval call binding the contract method 0x548d496f.
+//
+// Solidity: function supportedChains(uint256 ) view returns(bool)
+func (_SmartAccountV2 *SmartAccountV2CallerSession) SupportedChains(arg0 *big.Int) (bool, error) {
+	return _SmartAccountV2.Contract.SupportedChains(&_SmartAccountV2.CallOpts, arg0)
+}
+
+// AddSupportedChain is a paid mutator transaction binding the contract method 0x17011baf.
+//
+// Solidity: function addSupportedChain(uint256 chainId, address contractAddress) returns()
+func (_SmartAccountV2 *SmartAccountV2Transactor) AddSupportedChain(opts *bind.TransactOpts, chainId *big.Int, contractAddress common.Address) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "addSupportedChain", chainId, contractAddress)
+}
+
+// AddSupportedChain is a paid mutator transaction binding the contract method 0x17011baf.
+//
+// Solidity: function addSupportedChain(uint256 chainId, address contractAddress) returns()
+func (_SmartAccountV2 *SmartAccountV2Session) AddSupportedChain(chainId *big.Int, contractAddress common.Address) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.AddSupportedChain(&_SmartAccountV2.TransactOpts, chainId, contractAddress)
+}
+
+// AddSupportedChain is a paid mutator transaction binding the contract method 0x17011baf.
+//
+// Solidity: function addSupportedChain(uint256 chainId, address contractAddress) returns()
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) AddSupportedChain(chainId *big.Int, contractAddress common.Address) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.AddSupportedChain(&_SmartAccountV2.TransactOpts, chainId, contractAddress)
+}
+
+// CreateDCAStrategy is a paid mutator transaction binding the contract method 0x49bd5df2.
+//
+// Solidity: function createDCAStrategy(address tokenIn, address tokenOut, uint256 amountPerExecution, uint256 interval, uint256 maxExecutions) returns(uint256 strategyId)
+func (_SmartAccountV2 *SmartAccountV2Transactor) CreateDCAStrategy(opts *bind.TransactOpts, tokenIn common.Address, tokenOut common.Address, amountPerExecution *big.Int, interval *big.Int, maxExecutions *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "createDCAStrategy", tokenIn, tokenOut, amountPerExecution, interval, maxExecutions)
+}
+
+// CreateDCAStrategy is a paid mutator transaction binding the contract method 0x49bd5df2.
+//
+// Solidity: function createDCAStrategy(address tokenIn, address tokenOut, uint256 amountPerExecution, uint256 interval, uint256 maxExecutions) returns(uint256 strategyId)
+func (_SmartAccountV2 *SmartAccountV2Session) CreateDCAStrategy(tokenIn common.Address, tokenOut common.Address, amountPerExecution *big.Int, interval *big.Int, maxExecutions *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.CreateDCAStrategy(&_SmartAccountV2.TransactOpts, tokenIn, tokenOut, amountPerExecution, interval, maxExecutions)
+}
+
+// CreateDCAStrategy is a paid mutator transaction binding the contract method 0x49bd5df2.
+//
+// Solidity: function createDCAStrategy(address tokenIn, address tokenOut, uint256 amountPerExecution, uint256 interval, uint256 maxExecutions) returns(uint256 strategyId)
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) CreateDCAStrategy(tokenIn common.Address, tokenOut common.Address, amountPerExecution *big.Int, interval *big.Int, maxExecutions *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.CreateDCAStrategy(&_SmartAccountV2.TransactOpts, tokenIn, tokenOut, amountPerExecution, interval, maxExecutions)
+}
+
+// CreateGridStrategy is a paid mutator transaction binding the contract method 0x72202517.
+//
+// Solidity: function createGridStrategy(address tokenA, address tokenB, uint256 gridSize, uint256 priceStep, uint256 basePrice) returns(uint256 strategyId)
+func (_SmartAccountV2 *SmartAccountV2Transactor) CreateGridStrategy(opts *bind.TransactOpts, tokenA common.Address, tokenB common.Address, gridSize *big.Int, priceStep *big.Int, basePrice *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "createGridStrategy", tokenA, tokenB, gridSize, priceStep, basePrice)
+}
+
+// CreateGridStrategy is a paid mutator transaction binding the contract method 0x72202517.
+//
+// Solidity: function createGridStrategy(address tokenA, address tokenB, uint256 gridSize, uint256 priceStep, uint256 basePrice) returns(uint256 strategyId)
+func (_SmartAccountV2 *SmartAccountV2Session) CreateGridStrategy(tokenA common.Address, tokenB common.Address, gridSize *big.Int, priceStep *big.Int, basePrice *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.CreateGridStrategy(&_SmartAccountV2.TransactOpts, tokenA, tokenB, gridSize, priceStep, basePrice)
+}
+
+// CreateGridStrategy is a paid mutator transaction binding the contract method 0x72202517.
+//
+// Solidity: function createGridStrategy(address tokenA, address tokenB, uint256 gridSize, uint256 priceStep, uint256 basePrice) returns(uint256 strategyId)
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) CreateGridStrategy(tokenA common.Address, tokenB common.Address, gridSize *big.Int, priceStep *big.Int, basePrice *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.CreateGridStrategy(&_SmartAccountV2.TransactOpts, tokenA, tokenB, gridSize, priceStep, basePrice)
+}
+
+// CreateRebalanceStrategy is a paid mutator transaction binding the contract method 0x6132b719.
+//
+// Solidity: function createRebalanceStrategy(address[] tokens, uint256[] targetPercentages, uint256 rebalanceThreshold, uint256 minInterval) returns(uint256 strategyId)
+func (_SmartAccountV2 *SmartAccountV2Transactor) CreateRebalanceStrategy(opts *bind.TransactOpts, tokens []common.Address, targetPercentages []*big.Int, rebalanceThreshold *big.Int, minInterval *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "createRebalanceStrategy", tokens, targetPercentages, rebalanceThreshold, minInterval)
+}
+
+// CreateRebalanceStrategy is a paid mutator transaction binding the contract method 0x6132b719.
+//
+// Solidity: function createRebalanceStrategy(address[] tokens, uint256[] targetPercentages, uint256 rebalanceThreshold, uint256 minInterval) returns(uint256 strategyId)
+func (_SmartAccountV2 *SmartAccountV2Session) CreateRebalanceStrategy(tokens []common.Address, targetPercentages []*big.Int, rebalanceThreshold *big.Int, minInterval *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.CreateRebalanceStrategy(&_SmartAccountV2.TransactOpts, tokens, targetPercentages, rebalanceThreshold, minInterval)
+}
+
+// CreateRebalanceStrategy is a paid mutator transaction binding the contract method 0x6132b719.
+//
+// Solidity: function createRebalanceStrategy(address[] tokens, uint256[] targetPercentages, uint256 rebalanceThreshold, uint256 minInterval) returns(uint256 strategyId)
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) CreateRebalanceStrategy(tokens []common.Address, targetPercentages []*big.Int, rebalanceThreshold *big.Int, minInterval *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.CreateRebalanceStrategy(&_SmartAccountV2.TransactOpts, tokens, targetPercentages, rebalanceThreshold, minInterval)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x1cff79cd.
+//
+// Solidity: function execute(address target, bytes data) returns()
+func (_SmartAccountV2 *SmartAccountV2Transactor) Execute(opts *bind.TransactOpts, target common.Address, data []byte) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "execute", target, data)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x1cff79cd.
+//
+// Solidity: function execute(address target, bytes data) returns()
+func (_SmartAccountV2 *SmartAccountV2Session) Execute(target common.Address, data []byte) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.Execute(&_SmartAccountV2.TransactOpts, target, data)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x1cff79cd.
+//
+// Solidity: function execute(address target, bytes data) returns()
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) Execute(target common.Address, data []byte) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.Execute(&_SmartAccountV2.TransactOpts, target, data)
+}
+
+// ExecuteCalls is a paid mutator transaction binding the contract method 0xe2456d2e.
+//
+// Solidity: function executeCalls((address,uint256,bytes)[] calls, bool allowFailure) returns(bool[] successes, bytes[] results)
+func (_SmartAccountV2 *SmartAccountV2Transactor) ExecuteCalls(opts *bind.TransactOpts, calls []SmartAccountV2Call, allowFailure bool) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "executeCalls", calls, allowFailure)
+}
+
+// ExecuteCalls is a paid mutator transaction binding the contract method 0xe2456d2e.
+//
+// Solidity: function executeCalls((address,uint256,bytes)[] calls, bool allowFailure) returns(bool[] successes, bytes[] results)
+func (_SmartAccountV2 *SmartAccountV2Session) ExecuteCalls(calls []SmartAccountV2Call, allowFailure bool) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.ExecuteCalls(&_SmartAccountV2.TransactOpts, calls, allowFailure)
+}
+
+// ExecuteCalls is a paid mutator transaction binding the contract method 0xe2456d2e.
+//
+// Solidity: function executeCalls((address,uint256,bytes)[] calls, bool allowFailure) returns(bool[] successes, bytes[] results)
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) ExecuteCalls(calls []SmartAccountV2Call, allowFailure bool) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.ExecuteCalls(&_SmartAccountV2.TransactOpts, calls, allowFailure)
+}
+
+// ExecuteDCA is a paid mutator transaction binding the contract method 0x1f7d5689.
+//
+// Solidity: function executeDCA(uint256 strategyId) returns()
+func (_SmartAccountV2 *SmartAccountV2Transactor) ExecuteDCA(opts *bind.TransactOpts, strategyId *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "executeDCA", strategyId)
+}
+
+// ExecuteDCA is a paid mutator transaction binding the contract method 0x1f7d5689.
+//
+// Solidity: function executeDCA(uint256 strategyId) returns()
+func (_SmartAccountV2 *SmartAccountV2Session) ExecuteDCA(strategyId *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.ExecuteDCA(&_SmartAccountV2.TransactOpts, strategyId)
+}
+
+// ExecuteDCA is a paid mutator transaction binding the contract method 0x1f7d5689.
+//
+// Solidity: function executeDCA(uint256 strategyId) returns()
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) ExecuteDCA(strategyId *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.ExecuteDCA(&_SmartAccountV2.TransactOpts, strategyId)
+}
+
+// ExecuteRebalance is a paid mutator transaction binding the contract method 0x7e2d41a0.
+//
+// Solidity: function executeRebalance(uint256 strategyId) returns()
+func (_SmartAccountV2 *SmartAccountV2Transactor) ExecuteRebalance(opts *bind.TransactOpts, strategyId *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "executeRebalance", strategyId)
+}
+
+// ExecuteRebalance is a paid mutator transaction binding the contract method 0x7e2d41a0.
+//
+// Solidity: function executeRebalance(uint256 strategyId) returns()
+func (_SmartAccountV2 *SmartAccountV2Session) ExecuteRebalance(strategyId *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.ExecuteRebalance(&_SmartAccountV2.TransactOpts, strategyId)
+}
+
+// ExecuteRebalance is a paid mutator transaction binding the contract method 0x7e2d41a0.
+//
+// Solidity: function executeRebalance(uint256 strategyId) returns()
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) ExecuteRebalance(strategyId *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.ExecuteRebalance(&_SmartAccountV2.TransactOpts, strategyId)
+}
+
+// PauseStrategy is a paid mutator transaction binding the contract method 0x96f52486.
+//
+// Solidity: function pauseStrategy(uint256 strategyId, string strategyType) returns()
+func (_SmartAccountV2 *SmartAccountV2Transactor) PauseStrategy(opts *bind.TransactOpts, strategyId *big.Int, strategyType string) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "pauseStrategy", strategyId, strategyType)
+}
+
+// PauseStrategy is a paid mutator transaction binding the contract method 0x96f52486.
+//
+// Solidity: function pauseStrategy(uint256 strategyId, string strategyType) returns()
+func (_SmartAccountV2 *SmartAccountV2Session) PauseStrategy(strategyId *big.Int, strategyType string) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.PauseStrategy(&_SmartAccountV2.TransactOpts, strategyId, strategyType)
+}
+
+// PauseStrategy is a paid mutator transaction binding the contract method 0x96f52486.
+//
+// Solidity: function pauseStrategy(uint256 strategyId, string strategyType) returns()
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) PauseStrategy(strategyId *big.Int, strategyType string) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.PauseStrategy(&_SmartAccountV2.TransactOpts, strategyId, strategyType)
+}
+
+// RemoveSupportedChain is a paid mutator transaction binding the contract method 0x0c4844aa.
+//
+// Solidity: function removeSupportedChain(uint256 chainId) returns()
+func (_SmartAccountV2 *SmartAccountV2Transactor) RemoveSupportedChain(opts *bind.TransactOpts, chainId *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "removeSupportedChain", chainId)
+}
+
+// RemoveSupportedChain is a paid mutator transaction binding the contract method 0x0c4844aa.
+//
+// Solidity: function removeSupportedChain(uint256 chainId) returns()
+func (_SmartAccountV2 *SmartAccountV2Session) RemoveSupportedChain(chainId *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.RemoveSupportedChain(&_SmartAccountV2.TransactOpts, chainId)
+}
+
+// RemoveSupportedChain is a paid mutator transaction binding the contract method 0x0c4844aa.
+//
+// Solidity: function removeSupportedChain(uint256 chainId) returns()
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) RemoveSupportedChain(chainId *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.RemoveSupportedChain(&_SmartAccountV2.TransactOpts, chainId)
+}
+
+// SetSessionKey is a paid mutator transaction binding the contract method 0x1af99774.
+//
+// Solidity: function setSessionKey(address key, uint256 expiresAt) returns()
+func (_SmartAccountV2 *SmartAccountV2Transactor) SetSessionKey(opts *bind.TransactOpts, key common.Address, expiresAt *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "setSessionKey", key, expiresAt)
+}
+
+// SetSessionKey is a paid mutator transaction binding the contract method 0x1af99774.
+//
+// Solidity: function setSessionKey(address key, uint256 expiresAt) returns()
+func (_SmartAccountV2 *SmartAccountV2Session) SetSessionKey(key common.Address, expiresAt *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.SetSessionKey(&_SmartAccountV2.TransactOpts, key, expiresAt)
+}
+
+// SetSessionKey is a paid mutator transaction binding the contract method 0x1af99774.
+//
+// Solidity: function setSessionKey(address key, uint256 expiresAt) returns()
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) SetSessionKey(key common.Address, expiresAt *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.SetSessionKey(&_SmartAccountV2.TransactOpts, key, expiresAt)
+}
+
+// ValidateUserOp is a paid mutator transaction binding the contract method 0x19822f7c.
+//
+// Solidity: function validateUserOp((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes) userOp, bytes32 userOpHash, uint256 missingAccountFunds) returns(uint256 validationData)
+func (_SmartAccountV2 *SmartAccountV2Transactor) ValidateUserOp(opts *bind.TransactOpts, userOp SmartAccountV2PackedUserOperation, userOpHash [32]byte, missingAccountFunds *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.Transact(opts, "validateUserOp", userOp, userOpHash, missingAccountFunds)
+}
+
+// ValidateUserOp is a paid mutator transaction binding the contract method 0x19822f7c.
+//
+// Solidity: function validateUserOp((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes) userOp, bytes32 userOpHash, uint256 missingAccountFunds) returns(uint256 validationData)
+func (_SmartAccountV2 *SmartAccountV2Session) ValidateUserOp(userOp SmartAccountV2PackedUserOperation, userOpHash [32]byte, missingAccountFunds *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.ValidateUserOp(&_SmartAccountV2.TransactOpts, userOp, userOpHash, missingAccountFunds)
+}
+
+// ValidateUserOp is a paid mutator transaction binding the contract method 0x19822f7c.
+//
+// Solidity: function validateUserOp((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes) userOp, bytes32 userOpHash, uint256 missingAccountFunds) returns(uint256 validationData)
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) ValidateUserOp(userOp SmartAccountV2PackedUserOperation, userOpHash [32]byte, missingAccountFunds *big.Int) (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.ValidateUserOp(&_SmartAccountV2.TransactOpts, userOp, userOpHash, missingAccountFunds)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_SmartAccountV2 *SmartAccountV2Transactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SmartAccountV2.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_SmartAccountV2 *SmartAccountV2Session) Receive() (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.Receive(&_SmartAccountV2.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_SmartAccountV2 *SmartAccountV2TransactorSession) Receive() (*types.Transaction, error) {
+	return _SmartAccountV2.Contract.Receive(&_SmartAccountV2.TransactOpts)
+}
+
+// SmartAccountV2CallExecutedIterator is returned from FilterCallExecuted and is used to iterate over the raw logs and unpacked data for CallExecuted events raised by the SmartAccountV2 contract.
+type SmartAccountV2CallExecutedIterator struct {
+	Event *SmartAccountV2CallExecuted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SmartAccountV2CallExecutedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SmartAccountV2CallExecuted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SmartAccountV2CallExecuted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SmartAccountV2CallExecutedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SmartAccountV2CallExecutedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SmartAccountV2CallExecuted represents a CallExecuted event raised by the SmartAccountV2 contract.
+type SmartAccountV2CallExecuted struct {
+	Index      *big.Int
+	Target     common.Address
+	Success    bool
+	ReturnData []byte
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterCallExecuted is a free log retrieval operation binding the contract event 0x0378e2a9e0a22e9b709d8c548904f8eeeba80a3374479df4868407462fdeb63a.
+//
+// Solidity: event CallExecuted(uint256 indexed index, address indexed target, bool success, bytes returnData)
+func (_SmartAccountV2 *SmartAccountV2Filterer) FilterCallExecuted(opts *bind.FilterOpts, index []*big.Int, target []common.Address) (*SmartAccountV2CallExecutedIterator, error) {
+
+	var indexRule []interface{}
+	for _, indexItem := range index {
+		indexRule = append(indexRule, indexItem)
+	}
+	var targetRule []interface{}
+	for _, targetItem := range target {
+		targetRule = append(targetRule, targetItem)
+	}
+
+	logs, sub, err := _SmartAccountV2.contract.FilterLogs(opts, "CallExecuted", indexRule, targetRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountV2CallExecutedIterator{contract: _SmartAccountV2.contract, event: "CallExecuted", logs: logs, sub: sub}, nil
+}
+
+// WatchCallExecuted is a free log subscription operation binding the contract event 0x0378e2a9e0a22e9b709d8c548904f8eeeba80a3374479df4868407462fdeb63a.
+//
+// Solidity: event CallExecuted(uint256 indexed index, address indexed target, bool success, bytes returnData)
+func (_SmartAccountV2 *SmartAccountV2Filterer) WatchCallExecuted(opts *bind.WatchOpts, sink chan<- *SmartAccountV2CallExecuted, index []*big.Int, target []common.Address) (event.Subscription, error) {
+
+	var indexRule []interface{}
+	for _, indexItem := range index {
+		indexRule = append(indexRule, indexItem)
+	}
+	var targetRule []interface{}
+	for _, targetItem := range target {
+		targetRule = append(targetRule, targetItem)
+	}
+
+	logs, sub, err := _SmartAccountV2.contract.WatchLogs(opts, "CallExecuted", indexRule, targetRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SmartAccountV2CallExecuted)
+				if err := _SmartAccountV2.contract.UnpackLog(event, "CallExecuted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCallExecuted is a log parse operation binding the contract event 0x0378e2a9e0a22e9b709d8c548904f8eeeba80a3374479df4868407462fdeb63a.
+//
+// Solidity: event CallExecuted(uint256 indexed index, address indexed target, bool success, bytes returnData)
+func (_SmartAccountV2 *SmartAccountV2Filterer) ParseCallExecuted(log types.Log) (*SmartAccountV2CallExecuted, error) {
+	event := new(SmartAccountV2CallExecuted)
+	if err := _SmartAccountV2.contract.UnpackLog(event, "CallExecuted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SmartAccountV2ChainAddedIterator is returned from FilterChainAdded and is used to iterate over the raw logs and unpacked data for ChainAdded events raised by the SmartAccountV2 contract.
+type SmartAccountV2ChainAddedIterator struct {
+	Event *SmartAccountV2ChainAdded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SmartAccountV2ChainAddedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SmartAccountV2ChainAdded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SmartAccountV2ChainAdded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SmartAccountV2ChainAddedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SmartAccountV2ChainAddedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SmartAccountV2ChainAdded represents a ChainAdded event raised by the SmartAccountV2 contract.
+type SmartAccountV2ChainAdded struct {
+	ChainId         *big.Int
+	ContractAddress common.Address
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterChainAdded is a free log retrieval operation binding the contract event 0xd34413f9daf08c09e55b3ad5e2f7f18249f3ff26c061cb904f99597dd14ad53e.
+//
+// Solidity: event ChainAdded(uint256 indexed chainId, address contractAddress)
+func (_SmartAccountV2 *SmartAccountV2Filterer) FilterChainAdded(opts *bind.FilterOpts, chainId []*big.Int) (*SmartAccountV2ChainAddedIterator, error) {
+
+	var chainIdRule []interface{}
+	for _, chainIdItem := range chainId {
+		chainIdRule = append(chainIdRule, chainIdItem)
+	}
+
+	logs, sub, err := _SmartAccountV2.contract.FilterLogs(opts, "ChainAdded", chainIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountV2ChainAddedIterator{contract: _SmartAccountV2.contract, event: "ChainAdded", logs: logs, sub: sub}, nil
+}
+
+// WatchChainAdded is a free log subscription operation binding the contract event 0xd34413f9daf08c09e55b3ad5e2f7f18249f3ff26c061cb904f99597dd14ad53e.
+//
+// Solidity: event ChainAdded(uint256 indexed chainId, address contractAddress)
+func (_SmartAccountV2 *SmartAccountV2Filterer) WatchChainAdded(opts *bind.WatchOpts, sink chan<- *SmartAccountV2ChainAdded, chainId []*big.Int) (event.Subscription, error) {
+
+	var chainIdRule []interface{}
+	for _, chainIdItem := range chainId {
+		chainIdRule = append(chainIdRule, chainIdItem)
+	}
+
+	logs, sub, err := _SmartAccountV2.contract.WatchLogs(opts, "ChainAdded", chainIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SmartAccountV2ChainAdded)
+				if err := _SmartAccountV2.contract.UnpackLog(event, "ChainAdded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseChainAdded is a log parse operation binding the contract event 0xd34413f9daf08c09e55b3ad5e2f7f18249f3ff26c061cb904f99597dd14ad53e.
+//
+// Solidity: event ChainAdded(uint256 indexed chainId, address contractAddress)
+func (_SmartAccountV2 *SmartAccountV2Filterer) ParseChainAdded(log types.Log) (*SmartAccountV2ChainAdded, error) {
+	event := new(SmartAccountV2ChainAdded)
+	if err := _SmartAccountV2.contract.UnpackLog(event, "ChainAdded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SmartAccountV2StrategyCreatedIterator is returned from FilterStrategyCreated and is used to iterate over the raw logs and unpacked data for StrategyCreated events raised by the SmartAccountV2 contract.
+type SmartAccountV2StrategyCreatedIterator struct {
+	Event *SmartAccountV2StrategyCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SmartAccountV2StrategyCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SmartAccountV2StrategyCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SmartAccountV2StrategyCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SmartAccountV2StrategyCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SmartAccountV2StrategyCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SmartAccountV2StrategyCreated represents a StrategyCreated event raised by the SmartAccountV2 contract.
+type SmartAccountV2StrategyCreated struct {
+	StrategyId   *big.Int
+	StrategyType string
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterStrategyCreated is a free log retrieval operation binding the contract event 0x791d353c5c57df93d06f37cdb30733f17973417f0d7bf6d635c792ec19d5bdaf.
+//
+// Solidity: event StrategyCreated(uint256 indexed strategyId, string strategyType)
+func (_SmartAccountV2 *SmartAccountV2Filterer) FilterStrategyCreated(opts *bind.FilterOpts, strategyId []*big.Int) (*SmartAccountV2StrategyCreatedIterator, error) {
+
+	var strategyIdRule []interface{}
+	for _, strategyIdItem := range strategyId {
+		strategyIdRule = append(strategyIdRule, strategyIdItem)
+	}
+
+	logs, sub, err := _SmartAccountV2.contract.FilterLogs(opts, "StrategyCreated", strategyIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountV2StrategyCreatedIterator{contract: _SmartAccountV2.contract, event: "StrategyCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchStrategyCreated is a free log subscription operation binding the contract event 0x791d353c5c57df93d06f37cdb30733f17973417f0d7bf6d635c792ec19d5bdaf.
+//
+// Solidity: event StrategyCreated(uint256 indexed strategyId, string strategyType)
+func (_SmartAccountV2 *SmartAccountV2Filterer) WatchStrategyCreated(opts *bind.WatchOpts, sink chan<- *SmartAccountV2StrategyCreated, strategyId []*big.Int) (event.Subscription, error) {
+
+	var strategyIdRule []interface{}
+	for _, strategyIdItem := range strategyId {
+		strategyIdRule = append(strategyIdRule, strategyIdItem)
+	}
+
+	logs, sub, err := _SmartAccountV2.contract.WatchLogs(opts, "StrategyCreated", strategyIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SmartAccountV2StrategyCreated)
+				if err := _SmartAccountV2.contract.UnpackLog(event, "StrategyCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStrategyCreated is a log parse operation binding the contract event 0x791d353c5c57df93d06f37cdb30733f17973417f0d7bf6d635c792ec19d5bdaf.
+//
+// Solidity: event StrategyCreated(uint256 indexed strategyId, string strategyType)
+func (_SmartAccountV2 *SmartAccountV2Filterer) ParseStrategyCreated(log types.Log) (*SmartAccountV2StrategyCreated, error) {
+	event := new(SmartAccountV2StrategyCreated)
+	if err := _SmartAccountV2.contract.UnpackLog(event, "StrategyCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SmartAccountV2StrategyExecutedIterator is returned from FilterStrategyExecuted and is used to iterate over the raw logs and unpacked data for StrategyExecuted events raised by the SmartAccountV2 contract.
+type SmartAccountV2StrategyExecutedIterator struct {
+	Event *SmartAccountV2StrategyExecuted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SmartAccountV2StrategyExecutedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SmartAccountV2StrategyExecuted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SmartAccountV2StrategyExecuted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SmartAccountV2StrategyExecutedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SmartAccountV2StrategyExecutedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SmartAccountV2StrategyExecuted represents a StrategyExecuted event raised by the SmartAccountV2 contract.
+type SmartAccountV2StrategyExecuted struct {
+	StrategyId *big.Int
+	Executor   common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterStrategyExecuted is a free log retrieval operation binding the contract event 0x2fd17d9174467b0d119082b4ec8677cc640a36e5c30ed81469d8f7d7b8b2ca78.
+//
+// Solidity: event StrategyExecuted(uint256 indexed strategyId, address indexed executor)
+func (_SmartAccountV2 *SmartAccountV2Filterer) FilterStrategyExecuted(opts *bind.FilterOpts, strategyId []*big.Int, executor []common.Address) (*SmartAccountV2StrategyExecutedIterator, error) {
+
+	var strategyIdRule []interface{}
+	for _, strategyIdItem := range strategyId {
+		strategyIdRule = append(strategyIdRule, strategyIdItem)
+	}
+	var executorRule []interface{}
+	for _, executorItem := range executor {
+		executorRule = append(executorRule, executorItem)
+	}
+
+	logs, sub, err := _SmartAccountV2.contract.FilterLogs(opts, "StrategyExecuted", strategyIdRule, executorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountV2StrategyExecutedIterator{contract: _SmartAccountV2.contract, event: "StrategyExecuted", logs: logs, sub: sub}, nil
+}
+
+// WatchStrategyExecuted is a free log subscription operation binding the contract event 0x2fd17d9174467b0d119082b4ec8677cc640a36e5c30ed81469d8f7d7b8b2ca78.
+//
+// Solidity: event StrategyExecuted(uint256 indexed strategyId, address indexed executor)
+func (_SmartAccountV2 *SmartAccountV2Filterer) WatchStrategyExecuted(opts *bind.WatchOpts, sink chan<- *SmartAccountV2StrategyExecuted, strategyId []*big.Int, executor []common.Address) (event.Subscription, error) {
+
+	var strategyIdRule []interface{}
+	for _, strategyIdItem := range strategyId {
+		strategyIdRule = append(strategyIdRule, strategyIdItem)
+	}
+	var executorRule []interface{}
+	for _, executorItem := range executor {
+		executorRule = append(executorRule, executorItem)
+	}
+
+	logs, sub, err := _SmartAccountV2.contract.WatchLogs(opts, "StrategyExecuted", strategyIdRule, executorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SmartAccountV2StrategyExecuted)
+				if err := _SmartAccountV2.contract.UnpackLog(event, "StrategyExecuted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStrategyExecuted is a log parse operation binding the contract event 0x2fd17d9174467b0d119082b4ec8677cc640a36e5c30ed81469d8f7d7b8b2ca78.
+//
+// Solidity: event StrategyExecuted(uint256 indexed strategyId, address indexed executor)
+func (_SmartAccountV2 *SmartAccountV2Filterer) ParseStrategyExecuted(log types.Log) (*SmartAccountV2StrategyExecuted, error) {
+	event := new(SmartAccountV2StrategyExecuted)
+	if err := _SmartAccountV2.contract.UnpackLog(event, "StrategyExecuted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SmartAccountV2StrategyPausedIterator is returned from FilterStrategyPaused and is used to iterate over the raw logs and unpacked data for StrategyPaused events raised by the SmartAccountV2 contract.
+type SmartAccountV2StrategyPausedIterator struct {
+	Event *SmartAccountV2StrategyPaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SmartAccountV2StrategyPausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SmartAccountV2StrategyPaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SmartAccountV2StrategyPaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SmartAccountV2StrategyPausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SmartAccountV2StrategyPausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SmartAccountV2StrategyPaused represents a StrategyPaused event raised by the SmartAccountV2 contract.
+type SmartAccountV2StrategyPaused struct {
+	StrategyId *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterStrategyPaused is a free log retrieval operation binding the contract event 0x2b4292bae371ce359109660914c10ea93a31019eda2b7e1725b257ff1fcbd4f9.
+//
+// Solidity: event StrategyPaused(uint256 indexed strategyId)
+func (_SmartAccountV2 *SmartAccountV2Filterer) FilterStrategyPaused(opts *bind.FilterOpts, strategyId []*big.Int) (*SmartAccountV2StrategyPausedIterator, error) {
+
+	var strategyIdRule []interface{}
+	for _, strategyIdItem := range strategyId {
+		strategyIdRule = append(strategyIdRule, strategyIdItem)
+	}
+
+	logs, sub, err := _SmartAccountV2.contract.FilterLogs(opts, "StrategyPaused", strategyIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SmartAccountV2StrategyPausedIterator{contract: _SmartAccountV2.contract, event: "StrategyPaused", logs: logs, sub: sub}, nil
+}
+
+// WatchStrategyPaused is a free log subscription operation binding the contract event 0x2b4292bae371ce359109660914c10ea93a31019eda2b7e1725b257ff1fcbd4f9.
+//
+// Solidity: event StrategyPaused(uint256 indexed strategyId)
+func (_SmartAccountV2 *SmartAccountV2Filterer) WatchStrategyPaused(opts *bind.WatchOpts, sink chan<- *SmartAccountV2StrategyPaused, strategyId []*big.Int) (event.Subscription, error) {
+
+	var strategyIdRule []interface{}
+	for _, strategyIdItem := range strategyId {
+		strategyIdRule = append(strategyIdRule, strategyIdItem)
+	}
+
+	logs, sub, err := _SmartAccountV2.contract.WatchLogs(opts, "StrategyPaused", strategyIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SmartAccountV2StrategyPaused)
+				if err := _SmartAccountV2.contract.UnpackLog(event, "StrategyPaused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStrategyPaused is a log parse operation binding the contract event 0x2b4292bae371ce359109660914c10ea93a31019eda2b7e1725b257ff1fcbd4f9.
+//
+// Solidity: event StrategyPaused(uint256 indexed strategyId)
+func (_SmartAccountV2 *SmartAccountV2Filterer) ParseStrategyPaused(log types.Log) (*SmartAccountV2StrategyPaused, error) {
+	event := new(SmartAccountV2StrategyPaused)
+	if err := _SmartAccountV2.contract.UnpackLog(event, "StrategyPaused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,117 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GasValuer converts a gas amount into units of a token at the current gas price
+type GasValuer interface {
+	GasCostIn(ctx context.Context, chainID uint64, gas uint64, token common.Address) (*big.Int, error)
+}
+
+// BestOf queries several aggregators in parallel and returns the quote with
+// the highest output net of its estimated gas cost. The winning quote keeps
+// the Venue of the aggregator that produced it.
+type BestOf struct {
+	aggregators []Aggregator
+	gas         GasValuer // optional; without it quotes are compared gross
+	wins        map[string]int
+	mu          sync.Mutex
+}
+
+func NewBestOf(gas GasValuer, aggregators ...Aggregator) *BestOf {
+	return &BestOf{
+		aggregators: aggregators,
+		gas:         gas,
+		wins:        make(map[string]int),
+	}
+}
+
+func (b *BestOf) Name() string {
+	names := make([]string, len(b.aggregators))
+	for i, aggregator := range b.aggregators {
+		names[i] = aggregator.Name()
+	}
+	return "best(" + strings.Join(names, ",") + ")"
+}
+
+func (b *BestOf) Quote(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	return b.best(ctx, req, Aggregator.Quote)
+}
+
+func (b *BestOf) Swap(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	return b.best(ctx, req, Aggregator.Swap)
+}
+
+// Wins returns how often each venue has produced the best quote
+func (b *BestOf) Wins() map[string]int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wins := make(map[string]int, len(b.wins))
+	for venue, count := range b.wins {
+		wins[venue] = count
+	}
+	return wins
+}
+
+func (b *BestOf) best(ctx context.Context, req *QuoteRequest, call func(Aggregator, context.Context, *QuoteRequest) (*Quote, error)) (*Quote, error) {
+	quotes := make([]*Quote, len(b.aggregators))
+	errs := make([]error, len(b.aggregators))
+
+	var wg sync.WaitGroup
+	for i, aggregator := range b.aggregators {
+		wg.Add(1)
+		go func(i int, aggregator Aggregator) {
+			defer wg.Done()
+			quotes[i], errs[i] = call(aggregator, ctx, req)
+		}(i, aggregator)
+	}
+	wg.Wait()
+
+	var best *Quote
+	var bestNet *big.Int
+	var failures []string
+	for i, quote := range quotes {
+		if errs[i] != nil {
+			failures = append(failures, errs[i].Error())
+			continue
+		}
+		net := b.netOutput(ctx, quote)
+		if best == nil || net.Cmp(bestNet) > 0 {
+			best, bestNet = quote, net
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("no venue could quote: %s", strings.Join(failures, "; "))
+	}
+
+	b.mu.Lock()
+	b.wins[best.Venue]++
+	b.mu.Unlock()
+	log.Printf("🏅 %s won %s → %s on chain %d with %s net (%d of %d venues quoted)",
+		best.Venue, req.TokenIn.Hex(), req.TokenOut.Hex(), req.ChainID, bestNet, len(b.aggregators)-len(failures), len(b.aggregators))
+	return best, nil
+}
+
+// netOutput subtracts the quote's gas cost, valued in the output token, from its output
+func (b *BestOf) netOutput(ctx context.Context, quote *Quote) *big.Int {
+	net := new(big.Int).Set(quote.AmountOut)
+	if b.gas == nil || quote.EstimatedGas == 0 {
+		return net
+	}
+
+	cost, err := b.gas.GasCostIn(ctx, quote.ChainID, quote.EstimatedGas, quote.TokenOut)
+	if err != nil {
+		return net
+	}
+	return net.Sub(net, cost)
+}
@@ -0,0 +1,204 @@
+package dex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NativeToken is the placeholder address aggregators use for a chain's native currency
+var NativeToken = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+// QuoteRequest describes a swap of an exact input amount
+type QuoteRequest struct {
+	ChainID  uint64
+	TokenIn  common.Address
+	TokenOut common.Address
+	AmountIn *big.Int
+	From     common.Address // account executing the swap; required by Swap
+
+	// Token decimals, zero when unknown; venues such as ParaSwap need them for unlisted tokens
+	DecimalsIn  uint8
+	DecimalsOut uint8
+
+	SlippageBps uint32 // tolerance passed to the venue by Swap; zero means DefaultSlippageBps
+}
+
+// Quote is the expected outcome of a swap on one venue
+type Quote struct {
+	Venue        string
+	ChainID      uint64
+	TokenIn      common.Address
+	TokenOut     common.Address
+	AmountIn     *big.Int
+	AmountOut    *big.Int
+	MinAmountOut *big.Int // least the swap accepts before reverting; set by Swap only
+	EstimatedGas uint64
+	Tx           *Transaction   // calldata to execute the swap; set by Swap only
+	Spender      common.Address // needs an allowance of TokenIn before Tx; set by Swap for ERC-20 input
+}
+
+// Transaction is the call that executes a quoted swap
+type Transaction struct {
+	To    common.Address
+	Data  []byte
+	Value *big.Int
+	Gas   uint64
+}
+
+// Aggregator quotes and builds swaps through a DEX aggregation API
+type Aggregator interface {
+	Name() string
+	Quote(ctx context.Context, req *QuoteRequest) (*Quote, error)
+	Swap(ctx context.Context, req *QuoteRequest) (*Quote, error)
+}
+
+// Router dispatches requests to the aggregator configured for each chain
+type Router struct {
+	aggregators map[uint64]Aggregator
+	mu          sync.RWMutex
+}
+
+func NewRouter() *Router {
+	return &Router{aggregators: make(map[uint64]Aggregator)}
+}
+
+func (r *Router) SetAggregator(chainID uint64, aggregator Aggregator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.aggregators[chainID] = aggregator
+}
+
+func (r *Router) aggregator(chainID uint64) (Aggregator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	aggregator, exists := r.aggregators[chainID]
+	if !exists {
+		return nil, fmt.Errorf("no DEX aggregator configured for chain %d", chainID)
+	}
+	return aggregator, nil
+}
+
+func (r *Router) Quote(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	aggregator, err := r.aggregator(req.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	return aggregator.Quote(ctx, req)
+}
+
+func (r *Router) Swap(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	aggregator, err := r.aggregator(req.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	return aggregator.Swap(ctx, req)
+}
+
+// NewAggregatorForURL picks the adapter matching a configured aggregator base URL
+func NewAggregatorForURL(baseURL string, chainID uint64, credentials Credentials) (Aggregator, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregator URL %q: %v", baseURL, err)
+	}
+
+	switch {
+	case strings.HasSuffix(parsed.Host, "okx.com"):
+		return NewOKXAggregator(baseURL, chainID, credentials.OKX), nil
+	case strings.HasSuffix(parsed.Host, "1inch.io"), strings.HasSuffix(parsed.Host, "1inch.dev"):
+		return NewOneInchAggregator(baseURL, credentials.OneInchAPIKey), nil
+	case strings.HasSuffix(parsed.Host, "0x.org"):
+		return NewZeroExAggregator(baseURL, chainID, credentials.ZeroExAPIKey), nil
+	case strings.HasSuffix(parsed.Host, "paraswap.io"):
+		return NewParaSwapAggregator(baseURL, chainID, credentials.ParaSwapAPIKey), nil
+	default:
+		return nil, fmt.Errorf("unsupported aggregator %s", parsed.Host)
+	}
+}
+
+// Credentials holds optional API keys for the aggregator adapters
+type Credentials struct {
+	OKX            OKXCredentials
+	OneInchAPIKey  string
+	ZeroExAPIKey   string // required by the 0x API
+	ParaSwapAPIKey string
+}
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// getJSON performs a GET request and decodes a JSON response body
+func getJSON(ctx context.Context, endpoint string, headers map[string]string, out interface{}) error {
+	return doJSON(ctx, http.MethodGet, endpoint, headers, nil, out)
+}
+
+// postJSON sends body as JSON and decodes a JSON response body
+func postJSON(ctx context.Context, endpoint string, headers map[string]string, body, out interface{}) error {
+	return doJSON(ctx, http.MethodPost, endpoint, headers, body, out)
+}
+
+func doJSON(ctx context.Context, method, endpoint string, headers map[string]string, body, out interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %v", err)
+		}
+		payload = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, payload)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, truncate(string(data), 200))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+// parseAmount parses a base-10 integer amount returned by an API
+func parseAmount(value string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
@@ -0,0 +1,127 @@
+package dex
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Hop is one swap of a route through a single pool
+type Hop struct {
+	Pool      *Pool
+	TokenIn   common.Address
+	TokenOut  common.Address
+	AmountIn  *big.Int
+	AmountOut *big.Int
+}
+
+// Route is a sequence of hops simulated for a fixed input amount
+type Route struct {
+	Hops      []*Hop
+	AmountIn  *big.Int
+	AmountOut *big.Int
+}
+
+// TokenIn is the token the route starts from
+func (r *Route) TokenIn() common.Address {
+	return r.Hops[0].TokenIn
+}
+
+// TokenOut is the token the route ends with
+func (r *Route) TokenOut() common.Address {
+	return r.Hops[len(r.Hops)-1].TokenOut
+}
+
+// Calls builds the swap calls of every hop, executed in order by recipient.
+// Each hop requires its simulated output, so the sequence reverts as a whole
+// if any pool moved against the route since it was read.
+func (r *Route) Calls(recipient common.Address) ([]*Transaction, error) {
+	var calls []*Transaction
+	for _, hop := range r.Hops {
+		hopCalls, err := hop.Pool.SwapCalls(hop.TokenIn, hop.AmountIn, hop.AmountOut, recipient)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, hopCalls...)
+	}
+	return calls, nil
+}
+
+// Graph connects tokens through the pools that trade them
+type Graph struct {
+	edges map[common.Address][]*Pool
+}
+
+func NewGraph(pools []*Pool) *Graph {
+	g := &Graph{edges: make(map[common.Address][]*Pool)}
+	for _, pool := range pools {
+		g.edges[pool.Token0] = append(g.edges[pool.Token0], pool)
+		g.edges[pool.Token1] = append(g.edges[pool.Token1], pool)
+	}
+	return g
+}
+
+// Cycles simulates every route of two to maxHops hops that starts and ends at
+// start, never reusing a pool or revisiting an intermediate token. Routes are
+// returned most profitable first; unprofitable ones are included.
+func (g *Graph) Cycles(start common.Address, amountIn *big.Int, maxHops int) []*Route {
+	var routes []*Route
+	g.walk(start, start, amountIn, maxHops, nil, func(route *Route) bool {
+		return route.TokenOut() == start && len(route.Hops) >= 2
+	}, &routes)
+
+	sort.Slice(routes, func(i, j int) bool { return routes[i].AmountOut.Cmp(routes[j].AmountOut) > 0 })
+	return routes
+}
+
+// Routes simulates every route of up to maxHops hops from tokenIn to tokenOut,
+// best output first
+func (g *Graph) Routes(tokenIn, tokenOut common.Address, amountIn *big.Int, maxHops int) []*Route {
+	var routes []*Route
+	g.walk(tokenIn, tokenIn, amountIn, maxHops, nil, func(route *Route) bool {
+		return route.TokenOut() == tokenOut
+	}, &routes)
+
+	sort.Slice(routes, func(i, j int) bool { return routes[i].AmountOut.Cmp(routes[j].AmountOut) > 0 })
+	return routes
+}
+
+// walk extends hops from token depth-first, collecting the routes accepted by done.
+// Accepted routes are not extended further.
+func (g *Graph) walk(start, token common.Address, amount *big.Int, maxHops int, hops []*Hop, done func(*Route) bool, routes *[]*Route) {
+	if len(hops) >= maxHops {
+		return
+	}
+
+next:
+	for _, pool := range g.edges[token] {
+		tokenOut := pool.Other(token)
+		for _, hop := range hops {
+			if hop.Pool == pool || (hop.TokenOut == tokenOut && tokenOut != start) {
+				continue next
+			}
+		}
+		amountOut, err := pool.AmountOut(token, amount)
+		if err != nil || amountOut.Sign() == 0 {
+			continue
+		}
+
+		extended := append(append([]*Hop{}, hops...), &Hop{
+			Pool:      pool,
+			TokenIn:   token,
+			TokenOut:  tokenOut,
+			AmountIn:  amount,
+			AmountOut: amountOut,
+		})
+		route := &Route{Hops: extended, AmountIn: extended[0].AmountIn, AmountOut: amountOut}
+		if done(route) {
+			*routes = append(*routes, route)
+			continue
+		}
+		if tokenOut == start {
+			continue
+		}
+		g.walk(start, tokenOut, amountOut, maxHops, extended, done, routes)
+	}
+}
@@ -0,0 +1,213 @@
+package dex
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DefaultOKXURL is the OKX DEX aggregator API; the chain is passed per request
+const DefaultOKXURL = "https://www.okx.com/api/v5/dex/aggregator"
+
+// OKXCredentials authenticate requests to the OKX DEX API; all fields are optional
+type OKXCredentials struct {
+	APIKey     string
+	SecretKey  string
+	Passphrase string
+	ProjectID  string
+}
+
+// OKXAggregator quotes swaps through the OKX DEX aggregator API
+type OKXAggregator struct {
+	baseURL     string
+	chainID     uint64
+	credentials OKXCredentials
+}
+
+func NewOKXAggregator(baseURL string, chainID uint64, credentials OKXCredentials) *OKXAggregator {
+	return &OKXAggregator{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		chainID:     chainID,
+		credentials: credentials,
+	}
+}
+
+func (o *OKXAggregator) Name() string {
+	return "okx"
+}
+
+type okxResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		ToTokenAmount  string `json:"toTokenAmount"`
+		EstimateGasFee string `json:"estimateGasFee"`
+		RouterResult   struct {
+			ToTokenAmount  string `json:"toTokenAmount"`
+			EstimateGasFee string `json:"estimateGasFee"`
+		} `json:"routerResult"`
+		Tx struct {
+			To               string `json:"to"`
+			Data             string `json:"data"`
+			Value            string `json:"value"`
+			Gas              string `json:"gas"`
+			MinReceiveAmount string `json:"minReceiveAmount"`
+		} `json:"tx"`
+	} `json:"data"`
+}
+
+func (o *OKXAggregator) Quote(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	var resp okxResponse
+	if err := o.get(ctx, "/quote", o.params(req), &resp); err != nil {
+		return nil, err
+	}
+
+	data := resp.Data[0]
+	amountOut, err := parseAmount(data.ToTokenAmount)
+	if err != nil {
+		return nil, fmt.Errorf("OKX quote: %v", err)
+	}
+	gas, _ := strconv.ParseUint(data.EstimateGasFee, 10, 64)
+
+	return o.quote(req, amountOut, gas), nil
+}
+
+func (o *OKXAggregator) Swap(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	params := o.params(req)
+	params.Set("slippage", strconv.FormatFloat(float64(req.Slippage())/10000, 'f', -1, 64))
+	params.Set("userWalletAddress", req.From.Hex())
+
+	var resp okxResponse
+	if err := o.get(ctx, "/swap", params, &resp); err != nil {
+		return nil, err
+	}
+
+	data := resp.Data[0]
+	amountOut, err := parseAmount(data.RouterResult.ToTokenAmount)
+	if err != nil {
+		return nil, fmt.Errorf("OKX swap: %v", err)
+	}
+	calldata, err := hexutil.Decode(data.Tx.Data)
+	if err != nil {
+		return nil, fmt.Errorf("OKX swap: invalid calldata: %v", err)
+	}
+	value, err := parseAmount(data.Tx.Value)
+	if err != nil {
+		return nil, fmt.Errorf("OKX swap: %v", err)
+	}
+	gas, _ := strconv.ParseUint(data.Tx.Gas, 10, 64)
+
+	minOut, err := parseAmount(data.Tx.MinReceiveAmount)
+	if err != nil {
+		return nil, fmt.Errorf("OKX swap: minReceiveAmount: %v", err)
+	}
+
+	quote := o.quote(req, amountOut, gas)
+	quote.MinAmountOut = minOut
+	quote.Tx = &Transaction{
+		To:    common.HexToAddress(data.Tx.To),
+		Data:  calldata,
+		Value: value,
+		Gas:   gas,
+	}
+	if req.TokenIn != NativeToken {
+		quote.Spender, err = o.spender(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return quote, nil
+}
+
+// spender returns the OKX approval contract, which differs from the router called by Tx
+func (o *OKXAggregator) spender(ctx context.Context, req *QuoteRequest) (common.Address, error) {
+	params := url.Values{}
+	params.Set("chainId", strconv.FormatUint(o.chainID, 10))
+	params.Set("tokenContractAddress", req.TokenIn.Hex())
+	params.Set("approveAmount", req.AmountIn.String())
+
+	var resp struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+		Data []struct {
+			DexContractAddress string `json:"dexContractAddress"`
+		} `json:"data"`
+	}
+	if err := o.request(ctx, "/approve-transaction", params, &resp); err != nil {
+		return common.Address{}, err
+	}
+	if resp.Code != "0" || len(resp.Data) == 0 || !common.IsHexAddress(resp.Data[0].DexContractAddress) {
+		return common.Address{}, fmt.Errorf("OKX /approve-transaction error %s: %s", resp.Code, resp.Msg)
+	}
+	return common.HexToAddress(resp.Data[0].DexContractAddress), nil
+}
+
+func (o *OKXAggregator) quote(req *QuoteRequest, amountOut *big.Int, gas uint64) *Quote {
+	return &Quote{
+		Venue:        o.Name(),
+		ChainID:      req.ChainID,
+		TokenIn:      req.TokenIn,
+		TokenOut:     req.TokenOut,
+		AmountIn:     req.AmountIn,
+		AmountOut:    amountOut,
+		EstimatedGas: gas,
+	}
+}
+
+func (o *OKXAggregator) params(req *QuoteRequest) url.Values {
+	params := url.Values{}
+	params.Set("chainId", strconv.FormatUint(o.chainID, 10))
+	params.Set("amount", req.AmountIn.String())
+	params.Set("fromTokenAddress", req.TokenIn.Hex())
+	params.Set("toTokenAddress", req.TokenOut.Hex())
+	return params
+}
+
+func (o *OKXAggregator) get(ctx context.Context, path string, params url.Values, resp *okxResponse) error {
+	if err := o.request(ctx, path, params, resp); err != nil {
+		return err
+	}
+	if resp.Code != "0" || len(resp.Data) == 0 {
+		return fmt.Errorf("OKX %s error %s: %s", path, resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// request signs the call when credentials are configured and decodes the response
+func (o *OKXAggregator) request(ctx context.Context, path string, params url.Values, resp interface{}) error {
+	endpoint := o.baseURL + path + "?" + params.Encode()
+
+	headers := map[string]string{}
+	if o.credentials.APIKey != "" {
+		parsed, err := url.Parse(endpoint)
+		if err != nil {
+			return err
+		}
+		timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+		mac := hmac.New(sha256.New, []byte(o.credentials.SecretKey))
+		mac.Write([]byte(timestamp + "GET" + parsed.RequestURI()))
+
+		headers["OK-ACCESS-KEY"] = o.credentials.APIKey
+		headers["OK-ACCESS-SIGN"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		headers["OK-ACCESS-TIMESTAMP"] = timestamp
+		headers["OK-ACCESS-PASSPHRASE"] = o.credentials.Passphrase
+		if o.credentials.ProjectID != "" {
+			headers["OK-ACCESS-PROJECT"] = o.credentials.ProjectID
+		}
+	}
+
+	if err := getJSON(ctx, endpoint, headers, resp); err != nil {
+		return fmt.Errorf("OKX %s failed: %v", path, err)
+	}
+	return nil
+}
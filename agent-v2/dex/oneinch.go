@@ -0,0 +1,125 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// OneInchAggregator quotes swaps through the 1inch aggregation API.
+// The base URL already identifies the chain, e.g. https://api.1inch.io/v5.0/137.
+type OneInchAggregator struct {
+	baseURL string
+	apiKey  string
+}
+
+func NewOneInchAggregator(baseURL, apiKey string) *OneInchAggregator {
+	return &OneInchAggregator{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+	}
+}
+
+func (o *OneInchAggregator) Name() string {
+	return "1inch"
+}
+
+type oneInchResponse struct {
+	ToTokenAmount string `json:"toTokenAmount"`
+	EstimatedGas  uint64 `json:"estimatedGas"`
+	Tx            struct {
+		To    string `json:"to"`
+		Data  string `json:"data"`
+		Value string `json:"value"`
+		Gas   uint64 `json:"gas"`
+	} `json:"tx"`
+}
+
+func (o *OneInchAggregator) Quote(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	var resp oneInchResponse
+	if err := o.get(ctx, "/quote", o.params(req), &resp); err != nil {
+		return nil, err
+	}
+
+	amountOut, err := parseAmount(resp.ToTokenAmount)
+	if err != nil {
+		return nil, fmt.Errorf("1inch quote: %v", err)
+	}
+	return o.quote(req, amountOut, resp.EstimatedGas), nil
+}
+
+func (o *OneInchAggregator) Swap(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	params := o.params(req)
+	params.Set("fromAddress", req.From.Hex())
+	params.Set("slippage", req.slippagePercent())
+	params.Set("disableEstimate", "true")
+
+	var resp oneInchResponse
+	if err := o.get(ctx, "/swap", params, &resp); err != nil {
+		return nil, err
+	}
+
+	amountOut, err := parseAmount(resp.ToTokenAmount)
+	if err != nil {
+		return nil, fmt.Errorf("1inch swap: %v", err)
+	}
+	calldata, err := hexutil.Decode(resp.Tx.Data)
+	if err != nil {
+		return nil, fmt.Errorf("1inch swap: invalid calldata: %v", err)
+	}
+	value, err := parseAmount(resp.Tx.Value)
+	if err != nil {
+		return nil, fmt.Errorf("1inch swap: %v", err)
+	}
+
+	quote := o.quote(req, amountOut, resp.Tx.Gas)
+	// 1inch does not report the minimum it encoded; it applies the requested slippage
+	quote.MinAmountOut = MinAmountOut(amountOut, req.Slippage())
+	quote.Tx = &Transaction{
+		To:    common.HexToAddress(resp.Tx.To),
+		Data:  calldata,
+		Value: value,
+		Gas:   resp.Tx.Gas,
+	}
+	if req.TokenIn != NativeToken {
+		quote.Spender = quote.Tx.To
+	}
+	return quote, nil
+}
+
+func (o *OneInchAggregator) quote(req *QuoteRequest, amountOut *big.Int, gas uint64) *Quote {
+	return &Quote{
+		Venue:        o.Name(),
+		ChainID:      req.ChainID,
+		TokenIn:      req.TokenIn,
+		TokenOut:     req.TokenOut,
+		AmountIn:     req.AmountIn,
+		AmountOut:    amountOut,
+		EstimatedGas: gas,
+	}
+}
+
+func (o *OneInchAggregator) params(req *QuoteRequest) url.Values {
+	params := url.Values{}
+	params.Set("fromTokenAddress", req.TokenIn.Hex())
+	params.Set("toTokenAddress", req.TokenOut.Hex())
+	params.Set("amount", req.AmountIn.String())
+	return params
+}
+
+func (o *OneInchAggregator) get(ctx context.Context, path string, params url.Values, resp *oneInchResponse) error {
+	headers := map[string]string{}
+	if o.apiKey != "" {
+		headers["Authorization"] = "Bearer " + o.apiKey
+	}
+
+	if err := getJSON(ctx, o.baseURL+path+"?"+params.Encode(), headers, resp); err != nil {
+		return fmt.Errorf("1inch %s failed: %v", path, err)
+	}
+	return nil
+}
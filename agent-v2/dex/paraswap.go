@@ -0,0 +1,173 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DefaultParaSwapURL is the ParaSwap API; the chain is passed per request
+const DefaultParaSwapURL = "https://api.paraswap.io"
+
+// paraSwapVersion selects the Augustus V6 contracts, which are also the token spender
+const paraSwapVersion = "6.2"
+
+// ParaSwapAggregator quotes swaps through the ParaSwap API
+type ParaSwapAggregator struct {
+	baseURL string
+	chainID uint64
+	apiKey  string
+}
+
+func NewParaSwapAggregator(baseURL string, chainID uint64, apiKey string) *ParaSwapAggregator {
+	return &ParaSwapAggregator{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		chainID: chainID,
+		apiKey:  apiKey,
+	}
+}
+
+func (p *ParaSwapAggregator) Name() string {
+	return "paraswap"
+}
+
+type paraSwapPriceRoute struct {
+	DestAmount      string `json:"destAmount"`
+	GasCost         string `json:"gasCost"`
+	ContractAddress string `json:"contractAddress"`
+}
+
+type paraSwapPriceResponse struct {
+	PriceRoute json.RawMessage `json:"priceRoute"` // posted back unchanged to build the transaction
+}
+
+func (p *ParaSwapAggregator) Quote(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	_, route, err := p.prices(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return p.quote(req, route)
+}
+
+func (p *ParaSwapAggregator) Swap(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	raw, route, err := p.prices(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := p.quote(req, route)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"srcToken":    req.TokenIn.Hex(),
+		"destToken":   req.TokenOut.Hex(),
+		"srcAmount":   req.AmountIn.String(),
+		"slippage":    req.Slippage(), // basis points
+		"priceRoute":  raw,
+		"userAddress": req.From.Hex(),
+	}
+	if req.DecimalsIn != 0 && req.DecimalsOut != 0 {
+		body["srcDecimals"] = req.DecimalsIn
+		body["destDecimals"] = req.DecimalsOut
+	}
+
+	var tx struct {
+		To    string `json:"to"`
+		Data  string `json:"data"`
+		Value string `json:"value"`
+		Gas   string `json:"gas"`
+	}
+	path := fmt.Sprintf("/transactions/%d", p.chainID)
+	endpoint := p.baseURL + path + "?ignoreChecks=true&ignoreGasEstimate=true"
+	if err := postJSON(ctx, endpoint, p.headers(), body, &tx); err != nil {
+		return nil, fmt.Errorf("ParaSwap %s failed: %v", path, err)
+	}
+
+	calldata, err := hexutil.Decode(tx.Data)
+	if err != nil {
+		return nil, fmt.Errorf("ParaSwap transaction: invalid calldata: %v", err)
+	}
+	value, err := parseAmount(tx.Value)
+	if err != nil {
+		return nil, fmt.Errorf("ParaSwap transaction: %v", err)
+	}
+	gas := quote.EstimatedGas
+	if parsed, err := strconv.ParseUint(tx.Gas, 10, 64); err == nil {
+		gas = parsed
+	}
+
+	// ParaSwap does not report the minimum it encoded; it applies the requested slippage
+	quote.MinAmountOut = MinAmountOut(quote.AmountOut, req.Slippage())
+	quote.Tx = &Transaction{
+		To:    common.HexToAddress(tx.To),
+		Data:  calldata,
+		Value: value,
+		Gas:   gas,
+	}
+	if req.TokenIn != NativeToken {
+		quote.Spender = common.HexToAddress(route.ContractAddress)
+	}
+	return quote, nil
+}
+
+// prices fetches the best route, returning it both raw and decoded
+func (p *ParaSwapAggregator) prices(ctx context.Context, req *QuoteRequest) (json.RawMessage, *paraSwapPriceRoute, error) {
+	params := url.Values{}
+	params.Set("srcToken", req.TokenIn.Hex())
+	params.Set("destToken", req.TokenOut.Hex())
+	params.Set("amount", req.AmountIn.String())
+	params.Set("side", "SELL")
+	params.Set("network", strconv.FormatUint(p.chainID, 10))
+	params.Set("version", paraSwapVersion)
+	if req.DecimalsIn != 0 && req.DecimalsOut != 0 {
+		params.Set("srcDecimals", strconv.Itoa(int(req.DecimalsIn)))
+		params.Set("destDecimals", strconv.Itoa(int(req.DecimalsOut)))
+	}
+	if req.From != (common.Address{}) {
+		params.Set("userAddress", req.From.Hex())
+	}
+
+	var resp paraSwapPriceResponse
+	if err := getJSON(ctx, p.baseURL+"/prices?"+params.Encode(), p.headers(), &resp); err != nil {
+		return nil, nil, fmt.Errorf("ParaSwap /prices failed: %v", err)
+	}
+
+	var route paraSwapPriceRoute
+	if len(resp.PriceRoute) == 0 || json.Unmarshal(resp.PriceRoute, &route) != nil {
+		return nil, nil, fmt.Errorf("ParaSwap /prices returned no route")
+	}
+	return resp.PriceRoute, &route, nil
+}
+
+func (p *ParaSwapAggregator) quote(req *QuoteRequest, route *paraSwapPriceRoute) (*Quote, error) {
+	amountOut, err := parseAmount(route.DestAmount)
+	if err != nil {
+		return nil, fmt.Errorf("ParaSwap quote: %v", err)
+	}
+	gas, _ := strconv.ParseUint(route.GasCost, 10, 64)
+
+	return &Quote{
+		Venue:        p.Name(),
+		ChainID:      req.ChainID,
+		TokenIn:      req.TokenIn,
+		TokenOut:     req.TokenOut,
+		AmountIn:     req.AmountIn,
+		AmountOut:    amountOut,
+		EstimatedGas: gas,
+	}, nil
+}
+
+func (p *ParaSwapAggregator) headers() map[string]string {
+	headers := map[string]string{}
+	if p.apiKey != "" {
+		headers["X-API-KEY"] = p.apiKey
+	}
+	return headers
+}
@@ -0,0 +1,229 @@
+package dex
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PoolKind distinguishes constant-product pools from concentrated-liquidity pools
+type PoolKind string
+
+const (
+	PoolV2 PoolKind = "v2" // Uniswap V2 and forks: reserves, fee on input
+	PoolV3 PoolKind = "v3" // Uniswap V3 and forks: sqrt price and in-range liquidity
+)
+
+// feeDenominator is the scale of pool fees: 3000 is 0.3%
+const feeDenominator = 1000000
+
+var q96 = new(big.Int).Lsh(big.NewInt(1), 96)
+
+const poolABIJSON = `[
+	{"inputs":[{"internalType":"address","name":"tokenA","type":"address"},{"internalType":"address","name":"tokenB","type":"address"}],"name":"getPair","outputs":[{"internalType":"address","name":"pair","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"address","name":"tokenA","type":"address"},{"internalType":"address","name":"tokenB","type":"address"},{"internalType":"uint24","name":"fee","type":"uint24"}],"name":"getPool","outputs":[{"internalType":"address","name":"pool","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"getReserves","outputs":[{"internalType":"uint112","name":"reserve0","type":"uint112"},{"internalType":"uint112","name":"reserve1","type":"uint112"},{"internalType":"uint32","name":"blockTimestampLast","type":"uint32"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"slot0","outputs":[{"internalType":"uint160","name":"sqrtPriceX96","type":"uint160"},{"internalType":"int24","name":"tick","type":"int24"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"liquidity","outputs":[{"internalType":"uint128","name":"","type":"uint128"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"uint256","name":"amount0Out","type":"uint256"},{"internalType":"uint256","name":"amount1Out","type":"uint256"},{"internalType":"address","name":"to","type":"address"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"swap","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"components":[{"internalType":"address","name":"tokenIn","type":"address"},{"internalType":"address","name":"tokenOut","type":"address"},{"internalType":"uint24","name":"fee","type":"uint24"},{"internalType":"address","name":"recipient","type":"address"},{"internalType":"uint256","name":"amountIn","type":"uint256"},{"internalType":"uint256","name":"amountOutMinimum","type":"uint256"},{"internalType":"uint160","name":"sqrtPriceLimitX96","type":"uint160"}],"internalType":"struct IV3SwapRouter.ExactInputSingleParams","name":"params","type":"tuple"}],"name":"exactInputSingle","outputs":[{"internalType":"uint256","name":"amountOut","type":"uint256"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"}],"name":"transfer","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"address","name":"spender","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"}],"name":"approve","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}
+]`
+
+var poolABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(poolABIJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid ABI: %v", err))
+	}
+	return parsed
+}()
+
+// Pool is a liquidity pool and its last read state
+type Pool struct {
+	Kind    PoolKind
+	Address common.Address
+	Token0  common.Address
+	Token1  common.Address
+	Fee     uint32         // in millionths of the input amount
+	Router  common.Address // Router02 (V2) or SwapRouter02 (V3) of the pool's deployment
+	Quoter  common.Address // QuoterV2 of the pool's deployment, V3 only
+
+	Reserve0     *big.Int // V2
+	Reserve1     *big.Int // V2
+	SqrtPriceX96 *big.Int // V3
+	Liquidity    *big.Int // V3
+}
+
+// SortTokens orders two tokens the way pool contracts do
+func SortTokens(a, b common.Address) (common.Address, common.Address) {
+	if strings.ToLower(a.Hex()) < strings.ToLower(b.Hex()) {
+		return a, b
+	}
+	return b, a
+}
+
+// PackGetPair encodes a V2 factory lookup
+func PackGetPair(tokenA, tokenB common.Address) []byte {
+	data, _ := poolABI.Pack("getPair", tokenA, tokenB)
+	return data
+}
+
+// PackGetPool encodes a V3 factory lookup for one fee tier
+func PackGetPool(tokenA, tokenB common.Address, fee uint32) []byte {
+	data, _ := poolABI.Pack("getPool", tokenA, tokenB, new(big.Int).SetUint64(uint64(fee)))
+	return data
+}
+
+// StateCalls returns the read-only calls whose results UpdateState expects
+func (p *Pool) StateCalls() [][]byte {
+	if p.Kind == PoolV2 {
+		data, _ := poolABI.Pack("getReserves")
+		return [][]byte{data}
+	}
+	slot0, _ := poolABI.Pack("slot0")
+	liquidity, _ := poolABI.Pack("liquidity")
+	return [][]byte{slot0, liquidity}
+}
+
+// UpdateState stores the results of StateCalls. Only the leading words are
+// decoded, so forks that append fields to slot0 are supported.
+func (p *Pool) UpdateState(results [][]byte) error {
+	for _, result := range results {
+		if len(result) < 32 {
+			return fmt.Errorf("pool %s returned %d bytes", p.Address.Hex(), len(result))
+		}
+	}
+	word := func(data []byte, i int) *big.Int { return new(big.Int).SetBytes(data[i*32 : (i+1)*32]) }
+
+	if p.Kind == PoolV2 {
+		if len(results) != 1 || len(results[0]) < 64 {
+			return fmt.Errorf("invalid reserves for pool %s", p.Address.Hex())
+		}
+		p.Reserve0, p.Reserve1 = word(results[0], 0), word(results[0], 1)
+		return nil
+	}
+
+	if len(results) != 2 {
+		return fmt.Errorf("invalid state for pool %s", p.Address.Hex())
+	}
+	p.SqrtPriceX96, p.Liquidity = word(results[0], 0), word(results[1], 0)
+	return nil
+}
+
+// Other returns the token on the other side of the pool
+func (p *Pool) Other(token common.Address) common.Address {
+	if token == p.Token0 {
+		return p.Token1
+	}
+	return p.Token0
+}
+
+// AmountOut simulates an exact-input swap against the pool's last read state.
+// V2 is exact; V3 assumes the swap stays within the current tick range, which
+// overestimates output for swaps large enough to cross ticks.
+func (p *Pool) AmountOut(tokenIn common.Address, amountIn *big.Int) (*big.Int, error) {
+	if tokenIn != p.Token0 && tokenIn != p.Token1 {
+		return nil, fmt.Errorf("token %s not in pool %s", tokenIn.Hex(), p.Address.Hex())
+	}
+	zeroForOne := tokenIn == p.Token0
+
+	amountInLessFee := new(big.Int).Mul(amountIn, big.NewInt(int64(feeDenominator-p.Fee)))
+
+	if p.Kind == PoolV2 {
+		if p.Reserve0 == nil || p.Reserve0.Sign() == 0 || p.Reserve1.Sign() == 0 {
+			return nil, fmt.Errorf("pool %s has no reserves", p.Address.Hex())
+		}
+		reserveIn, reserveOut := p.Reserve0, p.Reserve1
+		if !zeroForOne {
+			reserveIn, reserveOut = p.Reserve1, p.Reserve0
+		}
+		numerator := new(big.Int).Mul(amountInLessFee, reserveOut)
+		denominator := new(big.Int).Mul(reserveIn, big.NewInt(feeDenominator))
+		denominator.Add(denominator, amountInLessFee)
+		return numerator.Div(numerator, denominator), nil
+	}
+
+	if p.Liquidity == nil || p.Liquidity.Sign() == 0 || p.SqrtPriceX96 == nil || p.SqrtPriceX96.Sign() == 0 {
+		return nil, fmt.Errorf("pool %s has no liquidity in range", p.Address.Hex())
+	}
+	amountInLessFee.Div(amountInLessFee, big.NewInt(feeDenominator))
+	liquidity, sqrtPrice := p.Liquidity, p.SqrtPriceX96
+
+	if zeroForOne {
+		// sqrtNext = L*sqrtP / (L + amountIn*sqrtP/Q96); amountOut = L*(sqrtP - sqrtNext)/Q96
+		numerator := new(big.Int).Mul(liquidity, q96)
+		numerator.Mul(numerator, sqrtPrice)
+		denominator := new(big.Int).Mul(liquidity, q96)
+		denominator.Add(denominator, new(big.Int).Mul(amountInLessFee, sqrtPrice))
+		sqrtNext := numerator.Div(numerator, denominator)
+
+		out := new(big.Int).Sub(sqrtPrice, sqrtNext)
+		out.Mul(out, liquidity)
+		return out.Div(out, q96), nil
+	}
+
+	// sqrtNext = sqrtP + amountIn*Q96/L; amountOut = L*Q96*(sqrtNext - sqrtP)/(sqrtNext*sqrtP)
+	sqrtNext := new(big.Int).Mul(amountInLessFee, q96)
+	sqrtNext.Div(sqrtNext, liquidity)
+	sqrtNext.Add(sqrtNext, sqrtPrice)
+
+	out := new(big.Int).Sub(sqrtNext, sqrtPrice)
+	out.Mul(out, liquidity)
+	out.Mul(out, q96)
+	return out.Div(out, new(big.Int).Mul(sqrtNext, sqrtPrice)), nil
+}
+
+// SwapCalls builds the calls that swap amountIn for at least amountOut, sent
+// from recipient itself (e.g. a smart account). V2 pairs are paid up front and
+// release exactly amountOut; V3 pools swap through the router.
+func (p *Pool) SwapCalls(tokenIn common.Address, amountIn, amountOut *big.Int, recipient common.Address) ([]*Transaction, error) {
+	tokenOut := p.Other(tokenIn)
+
+	if p.Kind == PoolV2 {
+		transfer, err := poolABI.Pack("transfer", p.Address, amountIn)
+		if err != nil {
+			return nil, err
+		}
+		amount0Out, amount1Out := big.NewInt(0), amountOut
+		if tokenIn == p.Token1 {
+			amount0Out, amount1Out = amountOut, big.NewInt(0)
+		}
+		swap, err := poolABI.Pack("swap", amount0Out, amount1Out, recipient, []byte{})
+		if err != nil {
+			return nil, err
+		}
+		return []*Transaction{
+			{To: tokenIn, Data: transfer, Value: big.NewInt(0)},
+			{To: p.Address, Data: swap, Value: big.NewInt(0)},
+		}, nil
+	}
+
+	if p.Router == (common.Address{}) {
+		return nil, fmt.Errorf("pool %s has no router configured", p.Address.Hex())
+	}
+	approve, err := poolABI.Pack("approve", p.Router, amountIn)
+	if err != nil {
+		return nil, err
+	}
+	params := struct {
+		TokenIn           common.Address
+		TokenOut          common.Address
+		Fee               *big.Int
+		Recipient         common.Address
+		AmountIn          *big.Int
+		AmountOutMinimum  *big.Int
+		SqrtPriceLimitX96 *big.Int
+	}{tokenIn, tokenOut, new(big.Int).SetUint64(uint64(p.Fee)), recipient, amountIn, amountOut, big.NewInt(0)}
+	swap, err := poolABI.Pack("exactInputSingle", params)
+	if err != nil {
+		return nil, err
+	}
+	return []*Transaction{
+		{To: tokenIn, Data: approve, Value: big.NewInt(0)},
+		{To: p.Router, Data: swap, Value: big.NewInt(0)},
+	}, nil
+}
@@ -0,0 +1,76 @@
+package dex
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultSlippageBps is the tolerance used when a request does not set one
+const DefaultSlippageBps = 50
+
+// transferTopic is the topic0 of the ERC-20 Transfer event
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// Slippage returns the request's tolerance in basis points
+func (r *QuoteRequest) Slippage() uint32 {
+	if r.SlippageBps == 0 {
+		return DefaultSlippageBps
+	}
+	return r.SlippageBps
+}
+
+// slippagePercent formats the tolerance as a percentage, e.g. 50 bps -> "0.5"
+func (r *QuoteRequest) slippagePercent() string {
+	return strconv.FormatFloat(float64(r.Slippage())/100, 'f', -1, 64)
+}
+
+// MinAmountOut applies a slippage tolerance to an expected output
+func MinAmountOut(amountOut *big.Int, slippageBps uint32) *big.Int {
+	min := new(big.Int).Mul(amountOut, big.NewInt(10000-int64(slippageBps)))
+	return min.Div(min, big.NewInt(10000))
+}
+
+// CheckMinReceive verifies that a swap's minimum output, as reported by the
+// venue, is no looser than slippageBps below its expected output
+func CheckMinReceive(quote *Quote, slippageBps uint32) error {
+	if quote.MinAmountOut == nil || quote.MinAmountOut.Sign() == 0 {
+		return fmt.Errorf("%s swap has no minimum output", quote.Venue)
+	}
+	floor := MinAmountOut(quote.AmountOut, slippageBps)
+	if quote.MinAmountOut.Cmp(floor) < 0 {
+		return fmt.Errorf("%s swap accepts as little as %s for an expected %s, beyond the %d bps tolerance",
+			quote.Venue, quote.MinAmountOut, quote.AmountOut, slippageBps)
+	}
+	return nil
+}
+
+// AmountReceived sums the ERC-20 Transfer logs of token to recipient in a receipt
+func AmountReceived(receipt *types.Receipt, token, recipient common.Address) *big.Int {
+	received := big.NewInt(0)
+	for _, log := range receipt.Logs {
+		if log.Address != token || len(log.Topics) != 3 || log.Topics[0] != transferTopic {
+			continue
+		}
+		if common.BytesToAddress(log.Topics[2].Bytes()) != recipient || len(log.Data) < 32 {
+			continue
+		}
+		received.Add(received, new(big.Int).SetBytes(log.Data[:32]))
+	}
+	return received
+}
+
+// RealizedSlippageBps is how far actual fell short of expected, in basis
+// points; negative when the swap returned more than expected
+func RealizedSlippageBps(expected, actual *big.Int) int64 {
+	if expected.Sign() == 0 {
+		return 0
+	}
+	shortfall := new(big.Int).Sub(expected, actual)
+	shortfall.Mul(shortfall, big.NewInt(10000))
+	return shortfall.Div(shortfall, expected).Int64()
+}
@@ -0,0 +1,342 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Gas assumed for routed swaps that QuoterV2 does not estimate
+const (
+	v2SwapGas    = 120000
+	v2HopGas     = 50000
+	v3RouterGas  = 40000 // router overhead on top of QuoterV2's pool estimate
+	swapDeadline = 20 * time.Minute
+)
+
+// maxQuotedRoutes bounds how many simulated routes are re-quoted through QuoterV2
+const maxQuotedRoutes = 3
+
+// swapRouterThis is SwapRouter02's ADDRESS_THIS placeholder recipient, which
+// keeps the output in the router for a following unwrapWETH9
+var swapRouterThis = common.HexToAddress("0x0000000000000000000000000000000000000002")
+
+const uniswapABIJSON = `[
+	{"inputs":[{"internalType":"uint256","name":"amountIn","type":"uint256"},{"internalType":"uint256","name":"amountOutMin","type":"uint256"},{"internalType":"address[]","name":"path","type":"address[]"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"deadline","type":"uint256"}],"name":"swapExactTokensForTokens","outputs":[{"internalType":"uint256[]","name":"amounts","type":"uint256[]"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"uint256","name":"amountOutMin","type":"uint256"},{"internalType":"address[]","name":"path","type":"address[]"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"deadline","type":"uint256"}],"name":"swapExactETHForTokens","outputs":[{"internalType":"uint256[]","name":"amounts","type":"uint256[]"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"uint256","name":"amountIn","type":"uint256"},{"internalType":"uint256","name":"amountOutMin","type":"uint256"},{"internalType":"address[]","name":"path","type":"address[]"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"deadline","type":"uint256"}],"name":"swapExactTokensForETH","outputs":[{"internalType":"uint256[]","name":"amounts","type":"uint256[]"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"components":[{"internalType":"bytes","name":"path","type":"bytes"},{"internalType":"address","name":"recipient","type":"address"},{"internalType":"uint256","name":"amountIn","type":"uint256"},{"internalType":"uint256","name":"amountOutMinimum","type":"uint256"}],"internalType":"struct IV3SwapRouter.ExactInputParams","name":"params","type":"tuple"}],"name":"exactInput","outputs":[{"internalType":"uint256","name":"amountOut","type":"uint256"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"uint256","name":"amountMinimum","type":"uint256"},{"internalType":"address","name":"recipient","type":"address"}],"name":"unwrapWETH9","outputs":[],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"bytes[]","name":"data","type":"bytes[]"}],"name":"multicall","outputs":[{"internalType":"bytes[]","name":"results","type":"bytes[]"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"bytes","name":"path","type":"bytes"},{"internalType":"uint256","name":"amountIn","type":"uint256"}],"name":"quoteExactInput","outputs":[{"internalType":"uint256","name":"amountOut","type":"uint256"},{"internalType":"uint160[]","name":"sqrtPriceX96AfterList","type":"uint160[]"},{"internalType":"uint32[]","name":"initializedTicksCrossedList","type":"uint32[]"},{"internalType":"uint256","name":"gasEstimate","type":"uint256"}],"stateMutability":"nonpayable","type":"function"}
+]`
+
+var uniswapABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(uniswapABIJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid ABI: %v", err))
+	}
+	return parsed
+}()
+
+// PoolSource returns the pools of a chain with their current state
+type PoolSource func(ctx context.Context, chainID uint64) ([]*Pool, error)
+
+// CallerSource returns a client for read-only calls on a chain
+type CallerSource func(chainID uint64) (ethereum.ContractCaller, error)
+
+// UniswapRouter quotes and builds swaps directly against Uniswap V2 and V3
+// style pools, without an aggregation API. Routes are found over the pools
+// returned by the PoolSource; V3 routes are re-quoted through QuoterV2 so
+// the output accounts for tick crossings.
+type UniswapRouter struct {
+	MaxHops     int
+	SlippageBps uint32 // used when a request does not set its own
+	pools       PoolSource
+	callers     CallerSource
+	wrapped     map[uint64]common.Address
+	mu          sync.RWMutex
+}
+
+func NewUniswapRouter(pools PoolSource, callers CallerSource) *UniswapRouter {
+	return &UniswapRouter{
+		MaxHops:     3,
+		SlippageBps: 50,
+		pools:       pools,
+		callers:     callers,
+		wrapped:     make(map[uint64]common.Address),
+	}
+}
+
+// SetWrappedNative registers the wrapped native token that stands in for NativeToken on a chain
+func (u *UniswapRouter) SetWrappedNative(chainID uint64, token common.Address) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.wrapped[chainID] = token
+}
+
+func (u *UniswapRouter) Name() string {
+	return "uniswap"
+}
+
+func (u *UniswapRouter) Quote(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	route, gas, err := u.bestRoute(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return u.quote(req, route, gas), nil
+}
+
+func (u *UniswapRouter) Swap(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	route, gas, err := u.bestRoute(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := u.quote(req, route, gas)
+	slippage := u.SlippageBps
+	if req.SlippageBps != 0 {
+		slippage = req.SlippageBps
+	}
+	minOut := MinAmountOut(route.AmountOut, slippage)
+	quote.MinAmountOut = minOut
+
+	router := route.Hops[0].Pool.Router
+	if route.Hops[0].Pool.Kind == PoolV2 {
+		quote.Tx, err = u.v2Swap(req, route, minOut)
+	} else {
+		quote.Tx, err = u.v3Swap(req, route, minOut)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("uniswap swap: %v", err)
+	}
+	quote.Tx.To = router
+	quote.Tx.Gas = gas
+	if req.TokenIn != NativeToken {
+		quote.Spender = router
+	}
+	return quote, nil
+}
+
+func (u *UniswapRouter) quote(req *QuoteRequest, route *Route, gas uint64) *Quote {
+	return &Quote{
+		Venue:        u.Name(),
+		ChainID:      req.ChainID,
+		TokenIn:      req.TokenIn,
+		TokenOut:     req.TokenOut,
+		AmountIn:     req.AmountIn,
+		AmountOut:    route.AmountOut,
+		EstimatedGas: gas,
+	}
+}
+
+// bestRoute finds the executable route with the highest output and its gas estimate
+func (u *UniswapRouter) bestRoute(ctx context.Context, req *QuoteRequest) (*Route, uint64, error) {
+	tokenIn, err := u.poolToken(req.ChainID, req.TokenIn)
+	if err != nil {
+		return nil, 0, err
+	}
+	tokenOut, err := u.poolToken(req.ChainID, req.TokenOut)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pools, err := u.pools(ctx, req.ChainID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load pools: %v", err)
+	}
+
+	var best *Route
+	var bestGas uint64
+	quoted := 0
+	for _, route := range NewGraph(pools).Routes(tokenIn, tokenOut, req.AmountIn, u.MaxHops) {
+		if !routable(route) {
+			continue
+		}
+		if quoted == maxQuotedRoutes {
+			break
+		}
+		quoted++
+
+		gas := uint64(v2SwapGas + v2HopGas*(len(route.Hops)-1))
+		if route.Hops[0].Pool.Kind == PoolV3 {
+			route, gas, err = u.quoteV3(ctx, req.ChainID, route)
+			if err != nil {
+				continue
+			}
+		}
+		if best == nil || route.AmountOut.Cmp(best.AmountOut) > 0 {
+			best, bestGas = route, gas
+		}
+	}
+
+	if best == nil {
+		return nil, 0, fmt.Errorf("no Uniswap route from %s to %s on chain %d", req.TokenIn.Hex(), req.TokenOut.Hex(), req.ChainID)
+	}
+	return best, bestGas, nil
+}
+
+// routable reports whether one router call can execute the whole route
+func routable(route *Route) bool {
+	first := route.Hops[0].Pool
+	if first.Router == (common.Address{}) || (first.Kind == PoolV3 && first.Quoter == (common.Address{})) {
+		return false
+	}
+	for _, hop := range route.Hops[1:] {
+		if hop.Pool.Kind != first.Kind || hop.Pool.Router != first.Router {
+			return false
+		}
+	}
+	return true
+}
+
+// quoteV3 replaces the in-range simulation of a V3 route with QuoterV2's exact output
+func (u *UniswapRouter) quoteV3(ctx context.Context, chainID uint64, route *Route) (*Route, uint64, error) {
+	caller, err := u.callers(chainID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := uniswapABI.Pack("quoteExactInput", v3Path(route), route.AmountIn)
+	if err != nil {
+		return nil, 0, err
+	}
+	quoter := route.Hops[0].Pool.Quoter
+	output, err := caller.CallContract(ctx, ethereum.CallMsg{To: &quoter, Data: data}, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("QuoterV2 call failed: %v", err)
+	}
+	results, err := uniswapABI.Unpack("quoteExactInput", output)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode QuoterV2 result: %v", err)
+	}
+
+	quoted := *route
+	quoted.AmountOut = results[0].(*big.Int)
+	return &quoted, results[3].(*big.Int).Uint64() + v3RouterGas, nil
+}
+
+func (u *UniswapRouter) v2Swap(req *QuoteRequest, route *Route, minOut *big.Int) (*Transaction, error) {
+	path := []common.Address{route.TokenIn()}
+	for _, hop := range route.Hops {
+		path = append(path, hop.TokenOut)
+	}
+	deadline := big.NewInt(time.Now().Add(swapDeadline).Unix())
+
+	var data []byte
+	var err error
+	value := big.NewInt(0)
+	switch {
+	case req.TokenIn == NativeToken:
+		data, err = uniswapABI.Pack("swapExactETHForTokens", minOut, path, req.From, deadline)
+		value = req.AmountIn
+	case req.TokenOut == NativeToken:
+		data, err = uniswapABI.Pack("swapExactTokensForETH", req.AmountIn, minOut, path, req.From, deadline)
+	default:
+		data, err = uniswapABI.Pack("swapExactTokensForTokens", req.AmountIn, minOut, path, req.From, deadline)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{Data: data, Value: value}, nil
+}
+
+func (u *UniswapRouter) v3Swap(req *QuoteRequest, route *Route, minOut *big.Int) (*Transaction, error) {
+	recipient := req.From
+	if req.TokenOut == NativeToken {
+		recipient = swapRouterThis
+	}
+	params := struct {
+		Path             []byte
+		Recipient        common.Address
+		AmountIn         *big.Int
+		AmountOutMinimum *big.Int
+	}{v3Path(route), recipient, req.AmountIn, minOut}
+
+	data, err := uniswapABI.Pack("exactInput", params)
+	if err != nil {
+		return nil, err
+	}
+	if req.TokenOut == NativeToken {
+		unwrap, err := uniswapABI.Pack("unwrapWETH9", minOut, req.From)
+		if err != nil {
+			return nil, err
+		}
+		data, err = uniswapABI.Pack("multicall", [][]byte{data, unwrap})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	value := big.NewInt(0)
+	if req.TokenIn == NativeToken {
+		value = req.AmountIn // the router wraps attached value when paying the first pool
+	}
+	return &Transaction{Data: data, Value: value}, nil
+}
+
+// v3Path encodes a route as tokenIn | fee | token | fee | ... | tokenOut
+func v3Path(route *Route) []byte {
+	path := append([]byte{}, route.TokenIn().Bytes()...)
+	for _, hop := range route.Hops {
+		fee := hop.Pool.Fee
+		path = append(path, byte(fee>>16), byte(fee>>8), byte(fee))
+		path = append(path, hop.TokenOut.Bytes()...)
+	}
+	return path
+}
+
+// poolToken maps NativeToken to the chain's wrapped native token
+func (u *UniswapRouter) poolToken(chainID uint64, token common.Address) (common.Address, error) {
+	if token != NativeToken {
+		return token, nil
+	}
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	wrapped, exists := u.wrapped[chainID]
+	if !exists {
+		return common.Address{}, fmt.Errorf("no wrapped native token registered for chain %d", chainID)
+	}
+	return wrapped, nil
+}
+
+// Fallback tries a primary aggregator and falls back to a secondary when it errors
+type Fallback struct {
+	Primary   Aggregator
+	Secondary Aggregator
+}
+
+func (f *Fallback) Name() string {
+	return f.Primary.Name() + "+" + f.Secondary.Name()
+}
+
+func (f *Fallback) Quote(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	quote, err := f.Primary.Quote(ctx, req)
+	if err == nil {
+		return quote, nil
+	}
+	quote, fallbackErr := f.Secondary.Quote(ctx, req)
+	if fallbackErr != nil {
+		return nil, fmt.Errorf("%s: %v; %s: %v", f.Primary.Name(), err, f.Secondary.Name(), fallbackErr)
+	}
+	return quote, nil
+}
+
+func (f *Fallback) Swap(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	quote, err := f.Primary.Swap(ctx, req)
+	if err == nil {
+		return quote, nil
+	}
+	quote, fallbackErr := f.Secondary.Swap(ctx, req)
+	if fallbackErr != nil {
+		return nil, fmt.Errorf("%s: %v; %s: %v", f.Primary.Name(), err, f.Secondary.Name(), fallbackErr)
+	}
+	return quote, nil
+}
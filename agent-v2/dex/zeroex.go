@@ -0,0 +1,150 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DefaultZeroExURL is the 0x Swap API; the chain is passed per request
+const DefaultZeroExURL = "https://api.0x.org"
+
+// ZeroExAggregator quotes swaps through the 0x Swap API (v2, AllowanceHolder flow)
+type ZeroExAggregator struct {
+	baseURL string
+	chainID uint64
+	apiKey  string
+}
+
+func NewZeroExAggregator(baseURL string, chainID uint64, apiKey string) *ZeroExAggregator {
+	return &ZeroExAggregator{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		chainID: chainID,
+		apiKey:  apiKey,
+	}
+}
+
+func (z *ZeroExAggregator) Name() string {
+	return "0x"
+}
+
+type zeroExResponse struct {
+	LiquidityAvailable bool   `json:"liquidityAvailable"`
+	BuyAmount          string `json:"buyAmount"`
+	MinBuyAmount       string `json:"minBuyAmount"`
+	Gas                string `json:"gas"`
+	Issues             struct {
+		Allowance *struct {
+			Spender string `json:"spender"`
+		} `json:"allowance"`
+	} `json:"issues"`
+	Transaction struct {
+		To    string `json:"to"`
+		Data  string `json:"data"`
+		Value string `json:"value"`
+		Gas   string `json:"gas"`
+	} `json:"transaction"`
+}
+
+func (z *ZeroExAggregator) Quote(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	var resp zeroExResponse
+	if err := z.get(ctx, "/swap/allowance-holder/price", z.params(req), &resp); err != nil {
+		return nil, err
+	}
+
+	amountOut, err := parseAmount(resp.BuyAmount)
+	if err != nil {
+		return nil, fmt.Errorf("0x price: %v", err)
+	}
+	gas, _ := strconv.ParseUint(resp.Gas, 10, 64)
+	return z.quote(req, amountOut, gas), nil
+}
+
+func (z *ZeroExAggregator) Swap(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	params := z.params(req)
+	params.Set("taker", req.From.Hex())
+	params.Set("slippageBps", strconv.FormatUint(uint64(req.Slippage()), 10))
+
+	var resp zeroExResponse
+	if err := z.get(ctx, "/swap/allowance-holder/quote", params, &resp); err != nil {
+		return nil, err
+	}
+
+	amountOut, err := parseAmount(resp.BuyAmount)
+	if err != nil {
+		return nil, fmt.Errorf("0x quote: %v", err)
+	}
+	calldata, err := hexutil.Decode(resp.Transaction.Data)
+	if err != nil {
+		return nil, fmt.Errorf("0x quote: invalid calldata: %v", err)
+	}
+	value, err := parseAmount(resp.Transaction.Value)
+	if err != nil {
+		return nil, fmt.Errorf("0x quote: %v", err)
+	}
+	minOut, err := parseAmount(resp.MinBuyAmount)
+	if err != nil {
+		return nil, fmt.Errorf("0x quote: minBuyAmount: %v", err)
+	}
+	gas, _ := strconv.ParseUint(resp.Transaction.Gas, 10, 64)
+
+	quote := z.quote(req, amountOut, gas)
+	quote.MinAmountOut = minOut
+	quote.Tx = &Transaction{
+		To:    common.HexToAddress(resp.Transaction.To),
+		Data:  calldata,
+		Value: value,
+		Gas:   gas,
+	}
+	if req.TokenIn != NativeToken {
+		// AllowanceHolder is both the spender and the transaction target; prefer
+		// the spender the API reports when the allowance is insufficient
+		quote.Spender = quote.Tx.To
+		if resp.Issues.Allowance != nil && common.IsHexAddress(resp.Issues.Allowance.Spender) {
+			quote.Spender = common.HexToAddress(resp.Issues.Allowance.Spender)
+		}
+	}
+	return quote, nil
+}
+
+func (z *ZeroExAggregator) quote(req *QuoteRequest, amountOut *big.Int, gas uint64) *Quote {
+	return &Quote{
+		Venue:        z.Name(),
+		ChainID:      req.ChainID,
+		TokenIn:      req.TokenIn,
+		TokenOut:     req.TokenOut,
+		AmountIn:     req.AmountIn,
+		AmountOut:    amountOut,
+		EstimatedGas: gas,
+	}
+}
+
+func (z *ZeroExAggregator) params(req *QuoteRequest) url.Values {
+	params := url.Values{}
+	params.Set("chainId", strconv.FormatUint(z.chainID, 10))
+	params.Set("sellToken", req.TokenIn.Hex())
+	params.Set("buyToken", req.TokenOut.Hex())
+	params.Set("sellAmount", req.AmountIn.String())
+	return params
+}
+
+func (z *ZeroExAggregator) get(ctx context.Context, path string, params url.Values, resp *zeroExResponse) error {
+	headers := map[string]string{"0x-version": "v2"}
+	if z.apiKey != "" {
+		headers["0x-api-key"] = z.apiKey
+	}
+
+	if err := getJSON(ctx, z.baseURL+path+"?"+params.Encode(), headers, resp); err != nil {
+		return fmt.Errorf("0x %s failed: %v", path, err)
+	}
+	if !resp.LiquidityAvailable {
+		return fmt.Errorf("0x %s: no liquidity available", path)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -9,16 +10,25 @@ import (
 	"math/big"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	"agent/bridge"
+	"agent/contracts"
+	"agent/dex"
 	"agent/multichain"
+	"agent/notify"
+	"agent/session"
+	"agent/state"
 	"agent/strategies"
+	"agent/userop"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
@@ -34,20 +44,92 @@ type SentinelAgent struct {
 	strategies        []strategies.TradingStrategy
 	portfolio         *multichain.CrossChainPortfolio
 	gasOptimizer      *multichain.GasOptimizer
+	gasOracle         *multichain.GasOracle
+	gasGate           *strategies.GasGate
+	history           *multichain.PortfolioHistory
+	pools             *multichain.PoolScanner
+	router            *dex.Router // builds the swaps of every strategy
+	bridges           *bridge.Tracker
+	sender            bridge.Sender
+	accounts          map[uint64]*contracts.SmartAccountClient // chainID -> bound smart account
+	sessions          *session.Manager                         // signs smart account executions when enabled
+	userOps           *userop.Sender                           // submits smart account executions through a bundler when enabled
+	walletAllowances  *multichain.AllowanceManager             // approvals from the agent's EOA
+	accountAllowances map[uint64]*multichain.AllowanceManager  // chainID -> approvals from the smart account
+	strategySyncs     map[uint64]*strategies.StrategySync      // chainID -> discovers SmartAccountV2 strategies when enabled
+	indexer           *multichain.AccountIndexer               // records smart account events when enabled
+	scheduler         *strategies.Scheduler                    // runs the strategies when enabled
+	journal           *bridge.TxJournal                        // transactions sent but not yet mined
+	notifier          notify.Notifier                          // delivers alerts such as swaps beyond their slippage limit
+	store             *state.Store
 	config            *Config
 }
 
 type Config struct {
-	RPCEndpoints     map[uint64]string
-	PrivateKey       string
-	SmartAccounts    map[uint64]string // chainID -> smart account address
-	EnableStrategies bool
-	EnableMultiChain bool
+	RPCEndpoints      map[uint64]string
+	PrivateKey        string
+	OwnerKey          string // smart account owner; defaults to PrivateKey and only needed to authorize session keys
+	Owner             string // address every smart account must be owned by; defaults to the owner key's
+	SessionKeys       bool
+	SessionDuration   time.Duration
+	SessionFunding    *big.Int          // wei sent to each new session key per chain for gas
+	SmartAccounts     map[uint64]string // chainID -> smart account address
+	StrategyChains    []uint64          // chains whose smart accounts run DCA, grid and rebalance strategies
+	StrategyWorkers   int               // strategies checked or executed at once
+	StrategyTimeout   time.Duration     // limit on one check and execution of a strategy
+	StrategyPoll      time.Duration     // how often strategies without a schedule are checked
+	ShutdownTimeout   time.Duration     // how long a shutdown waits for in-flight transactions
+	ExecutionBackend  string            // "eoa" sends smart account calls as transactions, "userop" as ERC-4337 UserOperations
+	BundlerRPCs       map[uint64]string // chainID -> bundler RPC for the userop backend
+	Paymaster         string            // optional paymaster sponsoring UserOperations
+	PaymasterData     string            // hex data passed to the paymaster
+	TrackedTokens     map[uint64][]common.Address
+	EnableStrategies  bool
+	OnChainStrategies bool   // schedule the strategies stored in the SmartAccountV2 instead of the examples
+	OnChainFromBlock  uint64 // block to discover on-chain strategies from, e.g. the account's deployment
+	EnableMultiChain  bool
+	EnableDiscovery   bool
+	EnableIndexer     bool
+	StateDir          string
+	SnapshotInterval  time.Duration
+	APIListen         string // address the history and performance API listens on; empty disables it
+	GasWindow         time.Duration
+	DCAGasPolicy      *strategies.GasPolicy
+	SwapSlippageBps   uint32 // tolerance on DCA and grid swaps
+	AlertWebhook      string // Slack-compatible webhook alerts are posted to; empty only logs them
+	BridgeAPIURL      string
+	BridgeAPIKey      string
+	LocalBridge       string            // MockBridge address on the local chains
+	LocalBridgeRPCs   map[uint64]string // chainID -> RPC of each local chain
+	LocalRelayerKey   string
+	EnableArbitrage   bool
+	ArbitrageChains   []uint64
+	ArbitragePair     *multichain.ArbitragePair
+	ArbitrageMinUSD   *big.Int // minimum net profit per run
+	ArbitrageCooldown time.Duration
+	ArbitrageMaxUSD   *big.Int // max open notional per chain
+	DEXCredentials    dex.Credentials
+	DEXVenues         []string // aggregators compared per swap; empty enables all
+	EnableCycles      bool
+	CycleChain        uint64
+	CycleToken        string // symbol of the token cycles start and end in
+	CycleSize         uint64 // whole tokens per cycle
+	CycleMinUSD       *big.Int
+	CycleCooldown     time.Duration
+	DEXFactories      map[uint64][]*multichain.DEXFactory
+	DirectRouting     bool     // route through Uniswap pools when no aggregator serves a chain or it fails
+	DirectChains      []uint64 // chains that always route through Uniswap pools
+	ApprovalMode      multichain.ApprovalMode
+	ApprovalCap       int64                       // multiple of the swap amount approved in capped mode
+	Permit2Spenders   map[uint64][]common.Address // spenders that pull tokens through Permit2
+	ApprovalMaxIdle   time.Duration               // approvals unused for longer are revoked by revoke-approvals
 }
 
 func NewSentinelAgent() *SentinelAgent {
 	return &SentinelAgent{
-		strategies: make([]strategies.TradingStrategy, 0),
+		strategies:        make([]strategies.TradingStrategy, 0),
+		accountAllowances: make(map[uint64]*multichain.AllowanceManager),
+		strategySyncs:     make(map[uint64]*strategies.StrategySync),
 	}
 }
 
@@ -56,16 +138,73 @@ func (s *SentinelAgent) Initialize() error {
 
 	// Load configuration
 	s.config = s.loadConfiguration()
+	var err error
+
+	s.store, err = state.Open(s.config.StateDir)
+	if err != nil {
+		return fmt.Errorf("failed to open state store: %v", err)
+	}
+	if err := s.loadRegisteredAccounts(); err != nil {
+		return fmt.Errorf("failed to load registered smart accounts: %v", err)
+	}
+	s.journal = bridge.NewTxJournal(s.store)
+	if s.config.AlertWebhook != "" {
+		s.notifier = notify.NewWebhookNotifier(s.config.AlertWebhook)
+	} else {
+		s.notifier = notify.LogNotifier{}
+	}
 
 	// Initialize multi-chain manager
 	s.multiChainManager = multichain.NewMultiChainManager()
-	err := s.multiChainManager.Initialize()
+	err = s.multiChainManager.Initialize()
 	if err != nil {
 		return fmt.Errorf("failed to initialize multi-chain manager: %v", err)
 	}
 
+	// Resolve the transactions a previous run left pending at shutdown
+	if err := s.journal.Reconcile(context.Background(), s.multiChainManager.GetClient); err != nil {
+		log.Printf("⚠️  Failed to reconcile pending transactions: %v", err)
+	}
+
+	s.bindSmartAccounts()
+	if s.config.EnableIndexer {
+		s.indexer = multichain.NewAccountIndexer(s.multiChainManager, s.store, s.accounts)
+	}
+	if s.config.SessionKeys {
+		if err := s.initializeSessionKeys(); err != nil {
+			return fmt.Errorf("failed to initialize session keys: %v", err)
+		}
+	}
+
+	if s.config.ExecutionBackend == "userop" {
+		if err := s.initializeUserOps(); err != nil {
+			return fmt.Errorf("failed to initialize UserOperation backend: %v", err)
+		}
+	}
+	if err := s.verifyAccounts(context.Background()); err != nil {
+		return fmt.Errorf("smart account verification failed: %v", err)
+	}
+
+	prices := multichain.NewDefaultPriceSource(s.multiChainManager)
+
+	s.pools = multichain.NewPoolScanner(s.multiChainManager, prices)
+	for chainID, factories := range s.config.DEXFactories {
+		for _, factory := range factories {
+			s.pools.AddFactory(chainID, factory)
+		}
+	}
+
 	// Initialize gas optimizer
-	s.gasOptimizer = multichain.NewGasOptimizer(s.multiChainManager)
+	s.gasOptimizer = multichain.NewGasOptimizer(s.multiChainManager, prices)
+	s.multiChainManager.Gas = s.gasOptimizer
+	s.gasOracle, err = multichain.NewGasOracle(s.multiChainManager, s.store, s.config.GasWindow)
+	if err != nil {
+		return fmt.Errorf("failed to load gas history: %v", err)
+	}
+	s.gasGate, err = strategies.NewGasGate(s.gasOracle, s.store)
+	if err != nil {
+		return fmt.Errorf("failed to load gas deferrals: %v", err)
+	}
 
 	// Initialize cross-chain portfolio
 	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(s.config.PrivateKey, "0x"))
@@ -73,7 +212,48 @@ func (s *SentinelAgent) Initialize() error {
 		return fmt.Errorf("invalid private key: %v", err)
 	}
 	userAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
-	s.portfolio = multichain.NewCrossChainPortfolio(userAddress, s.multiChainManager)
+	s.portfolio = multichain.NewCrossChainPortfolio(userAddress, s.multiChainManager, prices)
+	for chainID, account := range s.config.SmartAccounts {
+		if common.IsHexAddress(account) {
+			s.portfolio.TrackAccount(chainID, common.HexToAddress(account))
+		}
+	}
+	for chainID, tokens := range s.config.TrackedTokens {
+		for _, token := range tokens {
+			s.portfolio.TrackToken(chainID, token)
+		}
+	}
+	if s.config.EnableDiscovery {
+		checkpoints := make(map[uint64]uint64)
+		if _, err := s.store.Get("discovery", "checkpoints", &checkpoints); err != nil {
+			log.Printf("⚠️  Failed to load discovery checkpoints: %v", err)
+		}
+		s.portfolio.EnableTokenDiscovery(checkpoints, multichain.DefaultDiscoveryLookback)
+	}
+
+	err = s.initializeBridges(privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to initialize bridges: %v", err)
+	}
+
+	var direct dex.Aggregator
+	if s.config.DirectRouting {
+		direct = s.pools.NewUniswapRouter()
+	}
+	s.router = s.multiChainManager.NewDEXRouter(&multichain.DEXRouting{
+		Credentials:  s.config.DEXCredentials,
+		Venues:       s.config.DEXVenues,
+		Direct:       direct,
+		DirectChains: s.config.DirectChains,
+	}, prices)
+
+	s.history, err = multichain.NewPortfolioHistory(s.portfolio, s.store, s.config.SnapshotInterval)
+	if err != nil {
+		return fmt.Errorf("failed to load portfolio history: %v", err)
+	}
+	if s.indexer != nil {
+		s.indexer.Transfers = s.history
+	}
 
 	// Initialize trading strategies if enabled
 	if s.config.EnableStrategies {
@@ -81,12 +261,182 @@ func (s *SentinelAgent) Initialize() error {
 		if err != nil {
 			log.Printf("⚠️  Failed to initialize trading strategies: %v", err)
 		}
+		if s.config.EnableArbitrage {
+			err = s.initializeCrossChainStrategy(prices)
+			if err != nil {
+				log.Printf("⚠️  Failed to initialize cross-chain strategy: %v", err)
+			}
+		}
+		if s.config.EnableCycles {
+			err = s.initializeCycleStrategy()
+			if err != nil {
+				log.Printf("⚠️  Failed to initialize cycle strategy: %v", err)
+			}
+		}
+
+		s.scheduler = strategies.NewScheduler(s.config.StrategyWorkers, s.gasGate)
+		s.scheduler.PollInterval = s.config.StrategyPoll
+		s.scheduler.Timeout = s.config.StrategyTimeout
+		for _, strategy := range s.strategies {
+			if reporter, ok := strategy.(strategies.TradeReporter); ok {
+				reporter.SetTradeRecorder(s.history)
+			}
+			s.scheduler.Add(strategy)
+		}
 	}
 
 	log.Println("✅ Sentinel Agent initialized successfully")
 	return nil
 }
 
+// bindSmartAccounts detects the version of every configured smart account on a connected chain
+func (s *SentinelAgent) bindSmartAccounts() {
+	s.accounts = make(map[uint64]*contracts.SmartAccountClient)
+	for chainID, address := range s.config.SmartAccounts {
+		if !common.IsHexAddress(address) {
+			continue
+		}
+		client, err := s.multiChainManager.GetClient(chainID)
+		if err != nil {
+			continue
+		}
+		account, err := contracts.NewSmartAccountClient(context.Background(), common.HexToAddress(address), client)
+		if err != nil {
+			log.Printf("⚠️  Failed to bind smart account on chain %d: %v", chainID, err)
+			continue
+		}
+		s.accounts[chainID] = account
+		log.Printf("🔐 Smart account %s on chain %d is %s", address, chainID, account.Version)
+	}
+}
+
+// initializeSessionKeys loads the session key, authorizing a new one if it is
+// missing or due for rotation and the owner key is available
+func (s *SentinelAgent) initializeSessionKeys() error {
+	var err error
+	s.sessions, err = session.NewManager(s.store, s.multiChainManager.GetClient, s.accounts, s.config.SessionDuration)
+	if err != nil {
+		return err
+	}
+	s.sessions.GasFunding = s.config.SessionFunding
+	s.sessions.Journal = s.journal
+
+	if s.sessions.NeedsRotation() {
+		s.sessions.MaybeRotate(context.Background(), s.ownerKey())
+	}
+	if key := s.sessions.Current(); key != nil {
+		log.Printf("🔑 Signing smart account executions with session key %s", key.Address.Hex())
+	}
+	return nil
+}
+
+// ownerKey returns the owner key if it owns every bound smart account, or nil when the owner is offline
+func (s *SentinelAgent) ownerKey() *ecdsa.PrivateKey {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(s.config.OwnerKey, "0x"))
+	if err != nil {
+		return nil
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	for _, account := range s.accounts {
+		owner, err := account.Owner(context.Background())
+		if err != nil || owner != address {
+			return nil
+		}
+	}
+	return key
+}
+
+// initializeUserOps connects to the bundler of every chain with a smart
+// account; UserOperations are signed by the session key when enabled
+func (s *SentinelAgent) initializeUserOps() error {
+	bundlers := make(map[uint64]*userop.Bundler)
+	for chainID, account := range s.accounts {
+		url, exists := s.config.BundlerRPCs[chainID]
+		if !exists {
+			return fmt.Errorf("no bundler configured for chain %d in BUNDLER_RPCS", chainID)
+		}
+		if account.Version != contracts.V2 {
+			return fmt.Errorf("smart account on chain %d is %s; validateUserOp needs SmartAccountV2", chainID, account.Version)
+		}
+		bundler, err := userop.DialBundler(context.Background(), url)
+		if err != nil {
+			return err
+		}
+		bundlers[chainID] = bundler
+	}
+
+	var signer userop.Signer
+	if s.sessions != nil {
+		signer = s.sessions
+	} else {
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(s.config.PrivateKey, "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid private key: %v", err)
+		}
+		signer = userop.NewKeySigner(key)
+	}
+
+	s.userOps = userop.NewSender(signer, s.multiChainManager.GetClient, bundlers)
+	if common.IsHexAddress(s.config.Paymaster) {
+		paymaster := common.HexToAddress(s.config.Paymaster)
+		s.userOps.Paymaster = &paymaster
+		data, err := hexutil.Decode(s.config.PaymasterData)
+		if s.config.PaymasterData != "" && err != nil {
+			return fmt.Errorf("invalid PAYMASTER_DATA: %v", err)
+		}
+		s.userOps.PaymasterData = data
+	}
+	log.Printf("📨 Submitting smart account executions as UserOperations on %d chains", len(bundlers))
+	return nil
+}
+
+// accountSigner signs transactions sent to the smart accounts
+func (s *SentinelAgent) accountSigner() bridge.Sender {
+	if s.userOps != nil {
+		return s.userOps
+	}
+	if s.sessions != nil {
+		return s.sessions
+	}
+	return s.sender
+}
+
+// authorizeSessionKey is the one-off CLI step in which the owner authorizes a fresh session key
+func authorizeSessionKey() error {
+	s := NewSentinelAgent()
+	s.config = s.loadConfiguration()
+
+	var err error
+	s.store, err = state.Open(s.config.StateDir)
+	if err != nil {
+		return fmt.Errorf("failed to open state store: %v", err)
+	}
+	if err := s.loadRegisteredAccounts(); err != nil {
+		return fmt.Errorf("failed to load registered smart accounts: %v", err)
+	}
+	s.multiChainManager = multichain.NewMultiChainManager()
+	if err := s.multiChainManager.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize multi-chain manager: %v", err)
+	}
+	s.bindSmartAccounts()
+	if len(s.accounts) == 0 {
+		return fmt.Errorf("no smart account could be bound")
+	}
+
+	owner := s.ownerKey()
+	if owner == nil {
+		return fmt.Errorf("OWNER_PRIVATE_KEY does not own every configured smart account")
+	}
+	s.sessions, err = session.NewManager(s.store, s.multiChainManager.GetClient, s.accounts, s.config.SessionDuration)
+	if err != nil {
+		return err
+	}
+	s.sessions.GasFunding = s.config.SessionFunding
+
+	_, err = s.sessions.Authorize(context.Background(), owner)
+	return err
+}
+
 func (s *SentinelAgent) loadConfiguration() *Config {
 	return &Config{
 		RPCEndpoints: map[uint64]string{
@@ -97,63 +447,613 @@ func (s *SentinelAgent) loadConfiguration() *Config {
 			8453:  os.Getenv("BASE_RPC"),
 			195:   os.Getenv("X_LAYER_RPC"),
 		},
-		PrivateKey: os.Getenv("PRIVATE_KEY"),
-		SmartAccounts: map[uint64]string{
-			195: os.Getenv("SMART_ACCOUNT"), // X Layer
+		PrivateKey:        os.Getenv("PRIVATE_KEY"),
+		OwnerKey:          getEnvOrDefault("OWNER_PRIVATE_KEY", os.Getenv("PRIVATE_KEY")),
+		Owner:             os.Getenv("OWNER"),
+		SessionKeys:       os.Getenv("ENABLE_SESSION_KEY") == "true",
+		SessionDuration:   time.Duration(getEnvUint("SESSION_KEY_DURATION", 86400)) * time.Second,
+		SessionFunding:    new(big.Int).SetUint64(getEnvUint("SESSION_KEY_FUNDING", 5000000000000000)),
+		SmartAccounts:     parseSmartAccounts(os.Getenv("SMART_ACCOUNTS"), os.Getenv("SMART_ACCOUNT")),
+		StrategyChains:    parseChainIDs(getEnvOrDefault("STRATEGY_CHAINS", "195")),
+		StrategyWorkers:   int(getEnvUint("STRATEGY_WORKERS", 4)),
+		StrategyTimeout:   time.Duration(getEnvUint("STRATEGY_TIMEOUT", 120)) * time.Second,
+		StrategyPoll:      time.Duration(getEnvUint("STRATEGY_POLL_INTERVAL", 30)) * time.Second,
+		ShutdownTimeout:   time.Duration(getEnvUint("SHUTDOWN_TIMEOUT", 60)) * time.Second,
+		ExecutionBackend:  getEnvOrDefault("EXECUTION_BACKEND", "eoa"),
+		BundlerRPCs:       parseChainRPCs("BUNDLER_RPCS", os.Getenv("BUNDLER_RPCS")),
+		Paymaster:         os.Getenv("PAYMASTER_ADDRESS"),
+		PaymasterData:     os.Getenv("PAYMASTER_DATA"),
+		TrackedTokens:     parseChainAddresses("TRACKED_TOKENS", os.Getenv("TRACKED_TOKENS")),
+		EnableStrategies:  os.Getenv("ENABLE_STRATEGIES") == "true",
+		OnChainStrategies: os.Getenv("ONCHAIN_STRATEGIES") == "true",
+		OnChainFromBlock:  getEnvUint("ONCHAIN_STRATEGIES_FROM_BLOCK", 0),
+		EnableMultiChain:  os.Getenv("ENABLE_MULTICHAIN") == "true",
+		EnableDiscovery:   os.Getenv("ENABLE_TOKEN_DISCOVERY") == "true",
+		EnableIndexer:     os.Getenv("ENABLE_EVENT_INDEXER") == "true",
+		StateDir:          getEnvOrDefault("STATE_DIR", "data"),
+		SnapshotInterval:  time.Duration(getEnvUint("SNAPSHOT_INTERVAL", 900)) * time.Second,
+		APIListen:         os.Getenv("API_LISTEN"),
+		GasWindow:         time.Duration(getEnvUint("GAS_HISTORY_WINDOW", 86400)) * time.Second,
+		DCAGasPolicy:      loadGasPolicy("DCA"),
+		SwapSlippageBps:   uint32(getEnvUint("SWAP_SLIPPAGE_BPS", 50)),
+		AlertWebhook:      os.Getenv("ALERT_WEBHOOK_URL"),
+		BridgeAPIURL:      getEnvOrDefault("BRIDGE_API_URL", bridge.DefaultLiFiURL),
+		BridgeAPIKey:      os.Getenv("BRIDGE_API_KEY"),
+		LocalBridge:       os.Getenv("LOCAL_BRIDGE_ADDRESS"),
+		LocalBridgeRPCs:   parseChainRPCs("LOCAL_BRIDGE_RPCS", os.Getenv("LOCAL_BRIDGE_RPCS")),
+		LocalRelayerKey:   os.Getenv("LOCAL_BRIDGE_RELAYER_KEY"),
+		EnableArbitrage:   os.Getenv("ENABLE_ARBITRAGE") == "true",
+		ArbitrageChains:   parseChainIDs(getEnvOrDefault("ARBITRAGE_CHAINS", "1,42161,10,8453")),
+		ArbitragePair: &multichain.ArbitragePair{
+			BaseSymbol:   getEnvOrDefault("ARBITRAGE_BASE", "ETH"),
+			QuoteSymbol:  getEnvOrDefault("ARBITRAGE_QUOTE", "USDC"),
+			TradeSize:    int64(getEnvUint("ARBITRAGE_TRADE_SIZE", 1000)),
+			SwapFeeBps:   int64(getEnvUint("ARBITRAGE_SWAP_FEE_BPS", 0)),
+			MinProfitBps: int64(getEnvUint("ARBITRAGE_MIN_PROFIT_BPS", 50)),
+			SlippageBps:  uint32(getEnvUint("ARBITRAGE_SLIPPAGE_BPS", 50)),
+		},
+		ArbitrageMinUSD:   usdAmount(getEnvUint("ARBITRAGE_MIN_PROFIT_USD", 5)),
+		ArbitrageCooldown: time.Duration(getEnvUint("ARBITRAGE_COOLDOWN", 300)) * time.Second,
+		ArbitrageMaxUSD:   usdAmount(getEnvUint("ARBITRAGE_MAX_EXPOSURE_USD", 5000)),
+		DEXCredentials: dex.Credentials{
+			OKX: dex.OKXCredentials{
+				APIKey:     os.Getenv("OKX_API_KEY"),
+				SecretKey:  os.Getenv("OKX_SECRET_KEY"),
+				Passphrase: os.Getenv("OKX_PASSPHRASE"),
+				ProjectID:  os.Getenv("OKX_PROJECT_ID"),
+			},
+			OneInchAPIKey:  os.Getenv("ONEINCH_API_KEY"),
+			ZeroExAPIKey:   os.Getenv("ZEROEX_API_KEY"),
+			ParaSwapAPIKey: os.Getenv("PARASWAP_API_KEY"),
 		},
-		EnableStrategies: os.Getenv("ENABLE_STRATEGIES") == "true",
-		EnableMultiChain: os.Getenv("ENABLE_MULTICHAIN") == "true",
+		DEXVenues:       parseList(os.Getenv("DEX_VENUES")),
+		EnableCycles:    os.Getenv("ENABLE_CYCLE_ARBITRAGE") == "true",
+		CycleChain:      getEnvUint("CYCLE_CHAIN", 8453),
+		CycleToken:      getEnvOrDefault("CYCLE_TOKEN", "USDC"),
+		CycleSize:       getEnvUint("CYCLE_SIZE", 1000),
+		CycleMinUSD:     usdAmount(getEnvUint("CYCLE_MIN_PROFIT_USD", 1)),
+		CycleCooldown:   time.Duration(getEnvUint("CYCLE_COOLDOWN", 60)) * time.Second,
+		DEXFactories:    parseDEXFactories(os.Getenv("DEX_FACTORIES")),
+		DirectRouting:   os.Getenv("ENABLE_DIRECT_ROUTING") == "true",
+		DirectChains:    parseChainIDs(os.Getenv("DIRECT_ROUTING_CHAINS")),
+		ApprovalMode:    multichain.ApprovalMode(getEnvOrDefault("APPROVAL_MODE", string(multichain.ApprovalExact))),
+		ApprovalCap:     int64(getEnvUint("APPROVAL_CAP_MULTIPLE", 10)),
+		Permit2Spenders: parseChainAddresses("PERMIT2_SPENDERS", os.Getenv("PERMIT2_SPENDERS")),
+		ApprovalMaxIdle: time.Duration(getEnvUint("APPROVAL_MAX_IDLE", 168)) * time.Hour,
 	}
 }
 
-func (s *SentinelAgent) initializeTradingStrategies() error {
-	log.Println("📊 Initializing trading strategies...")
+// usdAmount converts whole dollars to the fixed-point USD amounts used by multichain
+func usdAmount(dollars uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(dollars), big.NewInt(100000000))
+}
+
+// parseChainIDs parses a comma-separated list of chain IDs
+func parseChainIDs(value string) []uint64 {
+	chainIDs := []uint64{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		chainID, err := strconv.ParseUint(entry, 10, 64)
+		if err != nil {
+			log.Printf("⚠️  Ignoring invalid chain ID %q", entry)
+			continue
+		}
+		chainIDs = append(chainIDs, chainID)
+	}
+	return chainIDs
+}
+
+// initializeBridges sets up the canonical and aggregator bridges, plus the
+// local MockBridge stand-in when configured, and resumes in-flight transfers
+func (s *SentinelAgent) initializeBridges(privateKey *ecdsa.PrivateKey) error {
+	clients := bridge.ClientSource(s.multiChainManager.GetClient)
+	bridges := []bridge.Bridge{
+		bridge.NewOPStackBridge(clients),
+		bridge.NewArbitrumBridge(clients),
+		bridge.NewLiFiBridge(s.config.BridgeAPIURL, s.config.BridgeAPIKey, clients),
+	}
+
+	if common.IsHexAddress(s.config.LocalBridge) {
+		chainIDs := make([]uint64, 0, len(s.config.LocalBridgeRPCs))
+		for chainID, rpc := range s.config.LocalBridgeRPCs {
+			err := s.multiChainManager.AddChain(&multichain.ChainConfig{
+				ChainID:        chainID,
+				Name:           fmt.Sprintf("Local %d", chainID),
+				RPC:            rpc,
+				NativeToken:    bridge.NativeToken,
+				NativeSymbol:   "ETH",
+				NativeDecimals: 18,
+				IsTestnet:      true,
+				BlockTime:      1,
+			})
+			if err != nil {
+				return err
+			}
+			chainIDs = append(chainIDs, chainID)
+		}
+
+		local := bridge.NewLocalBridge(common.HexToAddress(s.config.LocalBridge), chainIDs, clients)
+		bridges = append(bridges, local)
+
+		if s.config.LocalRelayerKey != "" {
+			relayerKey, err := crypto.HexToECDSA(strings.TrimPrefix(s.config.LocalRelayerKey, "0x"))
+			if err != nil {
+				return fmt.Errorf("invalid local relayer key: %v", err)
+			}
+			relayer := bridge.NewMockRelayer(local, bridge.NewKeyedSender(relayerKey, clients))
+			go relayer.Run(context.Background(), 5*time.Second)
+		}
+		log.Printf("🧪 Local bridge enabled on chains %v", chainIDs)
+	}
+
+	var err error
+	sender := bridge.NewKeyedSender(privateKey, clients)
+	sender.Journal = s.journal
+	s.sender = sender
+	s.bridges, err = bridge.NewTracker(s.store, s.sender, bridges...)
+	if err != nil {
+		return err
+	}
+
+	s.initializeAllowances()
+	return nil
+}
+
+// initializeAllowances creates the allowance managers of the wallet and of every bound smart account
+func (s *SentinelAgent) initializeAllowances() {
+	s.walletAllowances = s.newAllowanceManager(s.sender)
+	for chainID, account := range s.accounts {
+		accountSender := bridge.NewSmartAccountSender(s.accountSigner(), account.Address)
+		s.accountAllowances[chainID] = s.newAllowanceManager(accountSender)
+	}
+}
+
+// newAllowanceManager applies the configured approval policy to a manager approving from sender
+func (s *SentinelAgent) newAllowanceManager(sender bridge.Sender) *multichain.AllowanceManager {
+	allowances := multichain.NewAllowanceManager(s.multiChainManager, sender, s.store)
+	allowances.Mode = s.config.ApprovalMode
+	allowances.CapMultiple = s.config.ApprovalCap
+	for chainID, spenders := range s.config.Permit2Spenders {
+		for _, spender := range spenders {
+			allowances.UsePermit2(chainID, spender)
+		}
+	}
+	return allowances
+}
+
+// revokeApprovals is the one-off CLI step that revokes stale approvals; it
+// connects only the chains, signers and allowance managers revocation needs
+func revokeApprovals(ctx context.Context) error {
+	s := NewSentinelAgent()
+	s.config = s.loadConfiguration()
+
+	var err error
+	s.store, err = state.Open(s.config.StateDir)
+	if err != nil {
+		return fmt.Errorf("failed to open state store: %v", err)
+	}
+	if err := s.loadRegisteredAccounts(); err != nil {
+		return fmt.Errorf("failed to load registered smart accounts: %v", err)
+	}
+	s.journal = bridge.NewTxJournal(s.store)
+	s.multiChainManager = multichain.NewMultiChainManager()
+	if err := s.multiChainManager.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize multi-chain manager: %v", err)
+	}
+	s.bindSmartAccounts()
+	if s.config.SessionKeys {
+		if err := s.initializeSessionKeys(); err != nil {
+			return fmt.Errorf("failed to initialize session keys: %v", err)
+		}
+	}
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(s.config.PrivateKey, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid private key: %v", err)
+	}
+	sender := bridge.NewKeyedSender(privateKey, s.multiChainManager.GetClient)
+	sender.Journal = s.journal
+	s.sender = sender
+
+	s.initializeAllowances()
+	return s.revokeStaleApprovals(ctx)
+}
+
+// revokeStaleApprovals revokes approvals granted by the agent that have not been used within ApprovalMaxIdle
+func (s *SentinelAgent) revokeStaleApprovals(ctx context.Context) error {
+	managers := []*multichain.AllowanceManager{s.walletAllowances}
+	for _, allowances := range s.accountAllowances {
+		managers = append(managers, allowances)
+	}
+	for _, allowances := range managers {
+		if allowances == nil {
+			continue
+		}
+		revoked, err := allowances.RevokeStale(ctx, s.config.ApprovalMaxIdle)
+		if err != nil {
+			return err
+		}
+		log.Printf("🔒 Revoked %d stale approvals", revoked)
+	}
+	return nil
+}
+
+// initializeCrossChainStrategy sets up inventory-mode arbitrage between the configured chains
+func (s *SentinelAgent) initializeCrossChainStrategy(prices multichain.PriceSource) error {
+	limits := make(map[uint64]*big.Int)
+	for _, chainID := range s.config.ArbitrageChains {
+		limits[chainID] = s.config.ArbitrageMaxUSD
+	}
+	executor, err := multichain.NewArbitrageExecutor(s.multiChainManager, s.router, s.bridges, s.sender, s.store, limits)
+	if err != nil {
+		return err
+	}
+	executor.Allowances = s.walletAllowances
+	executor.Notifier = s.notifier
+
+	arbitrage := multichain.NewCrossChainStrategy(
+		4, // ID
+		fmt.Sprintf("%s/%s arbitrage", s.config.ArbitragePair.BaseSymbol, s.config.ArbitragePair.QuoteSymbol),
+		s.config.ArbitrageChains,
+		s.config.ArbitragePair,
+		s.multiChainManager,
+		s.portfolio,
+		s.router,
+		s.gasOptimizer,
+		multichain.NewQuotedBridgeFees(s.bridges, s.multiChainManager, prices),
+		executor,
+	)
+	arbitrage.MinProfitUSD = s.config.ArbitrageMinUSD
+	arbitrage.Cooldown = s.config.ArbitrageCooldown
+
+	s.strategies = append(s.strategies, arbitrage)
+	log.Printf("✅ Initialized %s across chains %v", arbitrage.Name, s.config.ArbitrageChains)
+	return nil
+}
 
-	// Get X Layer client for strategies
-	client, err := s.multiChainManager.GetClient(195) // X Layer testnet
+// initializeCycleStrategy sets up same-chain cycle arbitrage executed through the Smart Account
+func (s *SentinelAgent) initializeCycleStrategy() error {
+	if err := s.checkAccountChain(context.Background(), s.config.CycleChain); err != nil {
+		return err
+	}
+	account := s.accounts[s.config.CycleChain]
+	if account.Version != contracts.V2 {
+		return fmt.Errorf("cycles are batched through executeCalls: %v", contracts.ErrNotV2)
+	}
+	chain, err := s.multiChainManager.GetChain(s.config.CycleChain)
 	if err != nil {
 		return err
 	}
 
-	privateKey, _ := crypto.HexToECDSA(strings.TrimPrefix(s.config.PrivateKey, "0x"))
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(195))
+	var start *multichain.TokenInfo
+	for _, token := range chain.Tokens {
+		if strings.EqualFold(token.Symbol, s.config.CycleToken) {
+			start = token
+		}
+	}
+	if start == nil {
+		return fmt.Errorf("token %s not known on %s", s.config.CycleToken, chain.Name)
+	}
+
+	amountIn := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(start.Decimals)), nil)
+	amountIn.Mul(amountIn, new(big.Int).SetUint64(s.config.CycleSize))
+
+	cycles := multichain.NewCycleArbitrageStrategy(
+		5, // ID
+		s.config.CycleChain,
+		start,
+		amountIn,
+		account.Address,
+		s.pools,
+		s.accountSigner(),
+	)
+	cycles.MinProfitUSD = s.config.CycleMinUSD
+	cycles.Cooldown = s.config.CycleCooldown
+
+	s.strategies = append(s.strategies, cycles)
+	log.Printf("✅ Initialized %s cycle arbitrage on %s", start.Symbol, chain.Name)
+	return nil
+}
+
+// parseDEXFactories parses a comma-separated list of chainID:v2:factory[:router]
+// or chainID:v3:factory:router[:quoter] entries
+func parseDEXFactories(value string) map[uint64][]*multichain.DEXFactory {
+	factories := make(map[uint64][]*multichain.DEXFactory)
+	for _, entry := range parseChainEntries("DEX_FACTORIES", value) {
+		name := fmt.Sprintf("%d:%s", entry.ChainID, entry.Value)
+		parts := strings.Split(entry.Value, ":")
+		if len(parts) < 2 || !common.IsHexAddress(parts[1]) {
+			log.Printf("⚠️  Ignoring invalid DEX factory %q", name)
+			continue
+		}
+
+		factory := &multichain.DEXFactory{
+			Name:    name,
+			Kind:    dex.PoolKind(strings.ToLower(parts[0])),
+			Address: common.HexToAddress(parts[1]),
+		}
+		if len(parts) > 2 && common.IsHexAddress(parts[2]) {
+			factory.Router = common.HexToAddress(parts[2])
+		}
+		if len(parts) > 3 && common.IsHexAddress(parts[3]) {
+			factory.Quoter = common.HexToAddress(parts[3])
+		}
+		if factory.Kind != dex.PoolV2 && (factory.Kind != dex.PoolV3 || factory.Router == (common.Address{})) {
+			log.Printf("⚠️  Ignoring invalid DEX factory %q", name)
+			continue
+		}
+		factories[entry.ChainID] = append(factories[entry.ChainID], factory)
+	}
+	return factories
+}
+
+// parseList parses a comma-separated list, skipping empty entries
+func parseList(value string) []string {
+	entries := []string{}
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// chainEntry is one chainID:value entry of a per-chain variable
+type chainEntry struct {
+	ChainID uint64
+	Value   string
+}
+
+// parseChainEntries parses the comma-separated chainID:value entries of the
+// variable name, which invalid entries are reported against
+func parseChainEntries(name, value string) []chainEntry {
+	entries := []chainEntry{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		chain, rest, found := strings.Cut(entry, ":")
+		chainID, err := strconv.ParseUint(chain, 10, 64)
+		if !found || err != nil || rest == "" {
+			log.Printf("⚠️  Ignoring invalid %s entry %q", name, entry)
+			continue
+		}
+		entries = append(entries, chainEntry{ChainID: chainID, Value: rest})
+	}
+	return entries
+}
+
+// parseChainRPCs parses the chainID:url entries of the variable name
+func parseChainRPCs(name, value string) map[uint64]string {
+	rpcs := make(map[uint64]string)
+	for _, entry := range parseChainEntries(name, value) {
+		rpcs[entry.ChainID] = entry.Value
+	}
+	return rpcs
+}
+
+// parseSmartAccounts parses chainID:address entries; SMART_ACCOUNT, if set,
+// is the X Layer account unless an entry overrides it
+func parseSmartAccounts(value, xLayer string) map[uint64]string {
+	accounts := make(map[uint64]string)
+	if common.IsHexAddress(xLayer) {
+		accounts[195] = xLayer
+	}
+	for _, entry := range parseChainEntries("SMART_ACCOUNTS", value) {
+		if !common.IsHexAddress(entry.Value) {
+			log.Printf("⚠️  Ignoring invalid smart account %q for chain %d", entry.Value, entry.ChainID)
+			continue
+		}
+		accounts[entry.ChainID] = entry.Value
+	}
+	return accounts
+}
+
+// loadGasPolicy reads <PREFIX>_MAX_GAS_PERCENTILE and <PREFIX>_MAX_GAS_DELAY;
+// strategies without a percentile execute as soon as they are due
+func loadGasPolicy(prefix string) *strategies.GasPolicy {
+	percentile := getEnvUint(prefix+"_MAX_GAS_PERCENTILE", 0)
+	if percentile == 0 {
+		return nil
+	}
+	return &strategies.GasPolicy{
+		MaxPercentile: float64(percentile),
+		MaxDelay:      time.Duration(getEnvUint(prefix+"_MAX_GAS_DELAY", 21600)) * time.Second,
+	}
+}
+
+func getEnvOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvUint(key string, fallback uint64) uint64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		log.Printf("⚠️  Invalid %s=%q, using %d", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// parseChainAddresses parses the chainID:address entries of the variable
+// name, allowing several addresses per chain
+func parseChainAddresses(name, value string) map[uint64][]common.Address {
+	addresses := make(map[uint64][]common.Address)
+	for _, entry := range parseChainEntries(name, value) {
+		if !common.IsHexAddress(entry.Value) {
+			log.Printf("⚠️  Ignoring invalid %s entry %d:%s", name, entry.ChainID, entry.Value)
+			continue
+		}
+		addresses[entry.ChainID] = append(addresses[entry.ChainID], common.HexToAddress(entry.Value))
+	}
+	return addresses
+}
+
+// strategyTarget is the smart account a chain's strategies execute through, and how to sign for it
+type strategyTarget struct {
+	ChainID    uint64
+	Client     *ethclient.Client
+	Account    *contracts.SmartAccountClient
+	Auth       *bind.TransactOpts
+	Allowances *multichain.AllowanceManager
+}
+
+// checkAccountChain verifies that a chain has a configured smart account with code at its address
+func (s *SentinelAgent) checkAccountChain(ctx context.Context, chainID uint64) error {
+	address := s.config.SmartAccounts[chainID]
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("no smart account configured on chain %d in SMART_ACCOUNTS", chainID)
+	}
+	client, err := s.multiChainManager.GetClient(chainID)
 	if err != nil {
 		return err
 	}
+	code, err := client.CodeAt(ctx, common.HexToAddress(address), nil)
+	if err != nil {
+		return fmt.Errorf("failed to read code at %s on chain %d: %v", address, chainID, err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("no contract deployed at %s on chain %d", address, chainID)
+	}
+	if _, bound := s.accounts[chainID]; !bound {
+		return fmt.Errorf("smart account %s on chain %d could not be bound", address, chainID)
+	}
+	return nil
+}
+
+// resolveStrategyTarget resolves the smart account and signer of a chain
+func (s *SentinelAgent) resolveStrategyTarget(chainID uint64) (*strategyTarget, error) {
+	if err := s.checkAccountChain(context.Background(), chainID); err != nil {
+		return nil, err
+	}
+	client, err := s.multiChainManager.GetClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	var auth *bind.TransactOpts
+	if s.sessions != nil {
+		auth, err = s.sessions.TransactOpts(chainID)
+	} else {
+		privateKey, _ := crypto.HexToECDSA(strings.TrimPrefix(s.config.PrivateKey, "0x"))
+		auth, err = bind.NewKeyedTransactorWithChainID(privateKey, new(big.Int).SetUint64(chainID))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &strategyTarget{
+		ChainID:    chainID,
+		Client:     client,
+		Account:    s.accounts[chainID],
+		Auth:       auth,
+		Allowances: s.accountAllowances[chainID],
+	}, nil
+}
+
+// initializeTradingStrategies starts strategies on every chain in
+// STRATEGY_CHAINS; chains without a deployed smart account are skipped
+func (s *SentinelAgent) initializeTradingStrategies() error {
+	log.Println("📊 Initializing trading strategies...")
+
+	ready := 0
+	for _, chainID := range s.config.StrategyChains {
+		target, err := s.resolveStrategyTarget(chainID)
+		if err != nil {
+			log.Printf("⚠️  Not starting strategies on chain %d: %v", chainID, err)
+			continue
+		}
+
+		if s.config.OnChainStrategies {
+			err = s.initializeOnChainStrategies(target)
+		} else {
+			s.initializeExampleStrategies(target)
+		}
+		if err != nil {
+			log.Printf("⚠️  Failed to initialize strategies on chain %d: %v", chainID, err)
+			continue
+		}
+		ready++
+	}
+	if ready == 0 {
+		return fmt.Errorf("no chain in STRATEGY_CHAINS %v has a usable smart account", s.config.StrategyChains)
+	}
 
-	smartAccountAddr := common.HexToAddress(s.config.SmartAccounts[195])
+	log.Printf("✅ Initialized %d trading strategies on %d chains", len(s.strategies), ready)
+	return nil
+}
+
+// initializeExampleStrategies adds the example DCA, grid and rebalance strategies for a chain
+func (s *SentinelAgent) initializeExampleStrategies(target *strategyTarget) {
+	if target.Account.Version != contracts.V2 {
+		log.Printf("⚠️  Not starting example strategies on chain %d: swaps are sent through executeCalls: %v", target.ChainID, contracts.ErrNotV2)
+		return
+	}
+	native := common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE") // ETH
+	var usdc common.Address
+	for _, token := range multichain.BuiltinTokens(target.ChainID) {
+		if token.Symbol == "USDC" {
+			usdc = token.Address
+		}
+	}
+	if usdc == (common.Address{}) {
+		log.Printf("⚠️  Not starting example strategies on chain %d: no known USDC address", target.ChainID)
+		return
+	}
 
 	// Example DCA Strategy: Buy USDC with ETH every hour
 	dcaStrategy := strategies.NewDCAStrategy(
 		1, // ID
-		common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"), // ETH
-		common.HexToAddress("0x74b7F16337b8972027F6196A17a631aC6dE26d22"), // USDC (example)
-		big.NewInt(100000000000000000),                                    // 0.1 ETH per execution
-		3600,                                                              // Every hour
-		24,                                                                // 24 executions total
-		client,
-		smartAccountAddr,
-		auth,
+		native,
+		usdc,
+		big.NewInt(100000000000000000), // 0.1 ETH per execution
+		3600,                           // Every hour
+		24,                             // 24 executions total
+		target.Client,
+		target.Account.Address,
+		target.Auth,
 	)
+	dcaStrategy.ChainID = target.ChainID
+	dcaStrategy.GasPolicy = s.config.DCAGasPolicy
+	dcaStrategy.SlippageBps = s.config.SwapSlippageBps
+	dcaStrategy.Swapper = s.router
+	dcaStrategy.Signer = s.accountSigner()
+	dcaStrategy.Approver = target.Allowances
+	dcaStrategy.Notifier = s.notifier
 
 	// Example Grid Strategy
 	gridStrategy := strategies.NewGridStrategy(
 		2, // ID
-		common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"), // ETH
-		common.HexToAddress("0x74b7F16337b8972027F6196A17a631aC6dE26d22"), // USDC
+		native,
+		usdc,
 		10,                            // 10 grid levels
 		big.NewInt(0).SetUint64(50),   // $50 price step
 		big.NewInt(0).SetUint64(2000), // $2000 base price
-		client,
-		smartAccountAddr,
-		auth,
+		target.Client,
+		target.Account.Address,
+		target.Auth,
 	)
+	gridStrategy.ChainID = target.ChainID
+	gridStrategy.SlippageBps = s.config.SwapSlippageBps
+	gridStrategy.Swapper = s.router
+	gridStrategy.Signer = s.accountSigner()
+	gridStrategy.Approver = target.Allowances
+	gridStrategy.Notifier = s.notifier
 
 	// Example Rebalancing Strategy
-	tokens := []common.Address{
-		common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"), // ETH
-		common.HexToAddress("0x74b7F16337b8972027F6196A17a631aC6dE26d22"), // USDC
-	}
+	tokens := []common.Address{native, usdc}
 	percentages := []uint64{6000, 4000} // 60% ETH, 40% USDC
 
 	rebalanceStrategy := strategies.NewRebalanceStrategy(
@@ -162,36 +1062,139 @@ func (s *SentinelAgent) initializeTradingStrategies() error {
 		percentages,
 		500,          // 5% deviation threshold
 		24*time.Hour, // Rebalance at most once per day
-		client,
-		smartAccountAddr,
-		auth,
+		target.Client,
+		target.Account.Address,
+		target.Auth,
 	)
+	rebalanceStrategy.ChainID = target.ChainID
 
 	s.strategies = append(s.strategies, dcaStrategy, gridStrategy, rebalanceStrategy)
+}
 
-	log.Printf("✅ Initialized %d trading strategies", len(s.strategies))
-	return nil
+// initializeOnChainStrategies discovers the strategies stored in a chain's
+// SmartAccountV2, which stay in sync with the contract as they run
+func (s *SentinelAgent) initializeOnChainStrategies(target *strategyTarget) error {
+	// Rescan from the block the first run started at, so restarts rediscover the same strategies
+	key := strconv.FormatUint(target.ChainID, 10)
+	fromBlock := s.config.OnChainFromBlock
+	if _, err := s.store.Get("onchain_strategies", key, &fromBlock); err != nil {
+		log.Printf("⚠️  Failed to load on-chain strategy start block of chain %d: %v", target.ChainID, err)
+	}
+
+	strategySync, err := strategies.NewStrategySync(target.ChainID, target.Account, target.Client, target.Auth, fromBlock)
+	if err != nil {
+		return err
+	}
+	strategySync.GasPolicy = s.config.DCAGasPolicy
+	strategySync.SlippageBps = s.config.SwapSlippageBps
+	strategySync.Swapper = s.router
+	strategySync.Signer = s.accountSigner()
+	strategySync.Approver = target.Allowances
+	strategySync.Notifier = s.notifier
+	s.strategySyncs[target.ChainID] = strategySync
+
+	return s.syncOnChainStrategies(context.Background(), target.ChainID)
 }
 
-func (s *SentinelAgent) Run() error {
+// syncOnChainStrategies adds strategies created on a chain's account since the last scan
+func (s *SentinelAgent) syncOnChainStrategies(ctx context.Context, chainID uint64) error {
+	strategySync := s.strategySyncs[chainID]
+	discovered, err := strategySync.Discover(ctx)
+	for _, strategy := range discovered {
+		if reporter, ok := strategy.(strategies.TradeReporter); ok && s.history != nil {
+			reporter.SetTradeRecorder(s.history)
+		}
+		s.strategies = append(s.strategies, strategy)
+		if s.scheduler != nil {
+			s.scheduler.Add(strategy)
+		}
+	}
+	if putErr := s.store.Put("onchain_strategies", strconv.FormatUint(chainID, 10), strategySync.StartBlock()); putErr != nil {
+		log.Printf("⚠️  Failed to save on-chain strategy start block of chain %d: %v", chainID, putErr)
+	}
+	return err
+}
+
+// Run executes the agent until ctx is cancelled, then stops scheduling new
+// work and waits up to ShutdownTimeout for work in progress. It returns an
+// error if transactions were left pending or the state could not be saved.
+func (s *SentinelAgent) Run(ctx context.Context) error {
 	log.Println("🏃 Starting Sentinel Agent execution loop...")
 
-	ctx := context.Background()
-	ticker := time.NewTicker(30 * time.Second) // Check every 30 seconds
-	defer ticker.Stop()
+	if s.scheduler != nil {
+		go s.scheduler.Run(ctx)
+	}
+	if s.config.APIListen != "" {
+		go s.serveAPI(ctx)
+	}
 
-	for {
-		select {
-		case <-ticker.C:
-			err := s.executeLoop(ctx)
-			if err != nil {
-				log.Printf("❌ Execution loop error: %v", err)
+	// A loop in progress at shutdown keeps its context so it can finish; it is only aborted past the deadline
+	loopCtx, abortLoop := context.WithCancel(context.WithoutCancel(ctx))
+	defer abortLoop()
+	loopDone := make(chan struct{})
+	go func() {
+		defer close(loopDone)
+		ticker := time.NewTicker(30 * time.Second) // Check every 30 seconds
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := s.executeLoop(loopCtx)
+				if err != nil {
+					log.Printf("❌ Execution loop error: %v", err)
+				}
+			case <-ctx.Done():
+				return
 			}
-		case <-ctx.Done():
-			log.Println("🛑 Stopping Sentinel Agent...")
-			return nil
 		}
+	}()
+
+	<-ctx.Done()
+	log.Printf("🛑 Stopping Sentinel Agent, waiting up to %s for work in progress...", s.config.ShutdownTimeout)
+	return s.shutdown(loopDone, abortLoop)
+}
+
+// shutdown drains the execution loop and the scheduler, records what is
+// still in flight and flushes the state store
+func (s *SentinelAgent) shutdown(loopDone <-chan struct{}, abortLoop context.CancelFunc) error {
+	deadline := time.Now().Add(s.config.ShutdownTimeout)
+	drained := true
+
+	timer := time.NewTimer(s.config.ShutdownTimeout)
+	select {
+	case <-loopDone:
+	case <-timer.C:
+		abortLoop()
+		<-loopDone
+		drained = false
+	}
+	timer.Stop()
+	if s.scheduler != nil && !s.scheduler.Drain(time.Until(deadline)) {
+		drained = false
+	}
+
+	pending := s.journal.InFlight()
+	for _, hash := range pending {
+		log.Printf("⏳ Transaction %s recorded as pending; it is checked on the next start", hash.Hex())
+	}
+
+	if s.config.EnableDiscovery {
+		if err := s.store.Put("discovery", "checkpoints", s.portfolio.DiscoveryCheckpoints()); err != nil {
+			log.Printf("⚠️  Failed to save discovery checkpoints: %v", err)
+		}
+	}
+	if err := s.store.Flush(); err != nil {
+		return fmt.Errorf("failed to flush state store: %v", err)
+	}
+
+	if !drained {
+		return fmt.Errorf("shutdown deadline passed before in-flight executions drained")
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("stopped with %d transactions pending", len(pending))
 	}
+	log.Println("✅ Sentinel Agent stopped cleanly")
+	return nil
 }
 
 func (s *SentinelAgent) executeLoop(ctx context.Context) error {
@@ -202,38 +1205,48 @@ func (s *SentinelAgent) executeLoop(ctx context.Context) error {
 		err := s.portfolio.UpdateBalances(ctx)
 		if err != nil {
 			log.Printf("⚠️  Failed to update portfolio: %v", err)
+		} else if err := s.history.MaybeSnapshot(ctx); err != nil {
+			log.Printf("⚠️  Failed to snapshot portfolio: %v", err)
 		}
-	}
 
-	// Execute trading strategies
-	if s.config.EnableStrategies {
-		for _, strategy := range s.strategies {
-			shouldExecute, err := strategy.ShouldExecute(ctx)
+		if s.config.EnableDiscovery {
+			err = s.store.Put("discovery", "checkpoints", s.portfolio.DiscoveryCheckpoints())
 			if err != nil {
-				log.Printf("⚠️  Error checking strategy %d: %v", strategy.GetID(), err)
-				continue
+				log.Printf("⚠️  Failed to save discovery checkpoints: %v", err)
 			}
+		}
+	}
 
-			if shouldExecute {
-				log.Printf("🎯 Executing %s strategy #%d", strategy.GetType(), strategy.GetID())
-				err = strategy.Execute(ctx)
-				if err != nil {
-					log.Printf("❌ Strategy execution failed: %v", err)
-				} else {
-					log.Printf("✅ Strategy #%d executed successfully", strategy.GetID())
-				}
-			}
+	// Rotate the session key before it expires, or warn if the owner is offline
+	if s.sessions != nil && s.sessions.NeedsRotation() {
+		s.sessions.MaybeRotate(ctx, s.ownerKey())
+	}
+
+	// Record fee history so deferred strategies can wait for cheap gas
+	s.gasOracle.Sample(ctx)
+
+	// Follow cross-chain transfers and claim those that need it
+	s.bridges.Poll(ctx)
+
+	// Record what the smart accounts did since the last loop
+	if s.indexer != nil {
+		s.indexer.Poll(ctx)
+	}
+
+	// Schedule strategies created on-chain since the last loop
+	for chainID := range s.strategySyncs {
+		if err := s.syncOnChainStrategies(ctx, chainID); err != nil {
+			log.Printf("⚠️  Failed to discover on-chain strategies on chain %d: %v", chainID, err)
 		}
 	}
 
 	// Find best chain for transactions
 	if s.config.EnableMultiChain {
-		bestChain, err := s.gasOptimizer.GetBestChainForTransaction(ctx, "swap")
+		costs, err := s.gasOptimizer.RankChainsForTransaction(ctx, multichain.TxTypeSwap)
 		if err != nil {
-			log.Printf("⚠️  Failed to find best chain: %v", err)
+			log.Printf("⚠️  Failed to rank chains: %v", err)
 		} else {
-			chain, _ := s.multiChainManager.GetChain(bestChain)
-			log.Printf("🏆 Best chain for swaps: %s", chain.Name)
+			log.Printf("🏆 Best chain for swaps: %s", costs[0].Name)
 		}
 	}
 
@@ -320,54 +1333,31 @@ func executeBasicSwap() error {
 		return fmt.Errorf("invalid private key: %v", err)
 	}
 
-	fromAddr := crypto.PubkeyToAddress(privateKey.PublicKey)
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddr)
-	if err != nil {
-		return fmt.Errorf("failed to get nonce: %v", err)
-	}
-
-	gasPrice, err := client.SuggestGasPrice(context.Background())
-	if err != nil {
-		return fmt.Errorf("failed to suggest gas price: %v", err)
-	}
-
 	decodedData, err := hex.DecodeString(strings.TrimPrefix(quote.Data, "0x"))
 	if err != nil {
 		return fmt.Errorf("failed to decode quote data: %v", err)
 	}
 
-	target := common.HexToAddress(quote.To)
-	smartAccount := common.HexToAddress(smartAccountAddr)
-
-	parsedABI, err := abi.JSON(strings.NewReader(`[{"inputs":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bytes","name":"data","type":"bytes"}],"name":"execute","outputs":[],"stateMutability":"nonpayable","type":"function"}]`))
+	chainID, err := client.ChainID(context.Background())
 	if err != nil {
-		return fmt.Errorf("failed to parse ABI: %v", err)
+		return fmt.Errorf("failed to get chain ID: %v", err)
 	}
-
-	calldata, err := parsedABI.Pack("execute", target, decodedData)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
 	if err != nil {
-		return fmt.Errorf("failed to pack calldata: %v", err)
-	}
-
-	tx := types.NewTransaction(nonce, smartAccount, big.NewInt(0), 300000, gasPrice, calldata)
-
-	chainID, err := client.NetworkID(context.Background())
-	if err != nil {
-		return fmt.Errorf("failed to get network ID: %v", err)
+		return err
 	}
 
-	signer := types.LatestSignerForChainID(chainID)
-	signedTx, err := types.SignTx(tx, signer, privateKey)
+	account, err := contracts.NewSmartAccountClient(context.Background(), common.HexToAddress(smartAccountAddr), client)
 	if err != nil {
-		return fmt.Errorf("failed to sign tx: %v", err)
+		return fmt.Errorf("failed to bind smart account: %v", err)
 	}
 
-	err = client.SendTransaction(context.Background(), signedTx)
+	tx, err := account.Execute(auth, common.HexToAddress(quote.To), decodedData)
 	if err != nil {
 		return fmt.Errorf("failed to send tx: %v", err)
 	}
 
-	fmt.Printf("✅ Basic swap executed: %s\n", signedTx.Hash().Hex())
+	fmt.Printf("✅ Basic swap executed: %s\n", tx.Hash().Hex())
 	return nil
 }
 
@@ -386,8 +1376,28 @@ func main() {
 	if privateKeyHex == "" || privateKeyHex == "your_priv_key_here" {
 		log.Fatal("PRIVATE_KEY environment variable is required")
 	}
-	if smartAccountAddr == "" || smartAccountAddr == "deployed_smart_account_address_here" {
-		log.Fatal("SMART_ACCOUNT environment variable is required")
+	if len(os.Args) > 1 && os.Args[1] == "deploy-account" {
+		if err := deployAccounts(); err != nil {
+			log.Fatalf("Failed to deploy smart account: %v", err)
+		}
+		return
+	}
+	if (smartAccountAddr == "" || smartAccountAddr == "deployed_smart_account_address_here") && os.Getenv("SMART_ACCOUNTS") == "" {
+		log.Fatal("SMART_ACCOUNT or SMART_ACCOUNTS environment variable is required")
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "session-key" {
+		if err := authorizeSessionKey(); err != nil {
+			log.Fatalf("Failed to authorize session key: %v", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "revoke-approvals" {
+		if err := revokeApprovals(context.Background()); err != nil {
+			log.Fatalf("Failed to revoke approvals: %v", err)
+		}
+		return
 	}
 
 	// Initialize and run advanced agent
@@ -407,8 +1417,10 @@ func main() {
 		return
 	}
 
-	// Run advanced agent
-	err = agent.Run()
+	// Run advanced agent until SIGINT or SIGTERM; a drain that did not complete exits non-zero
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err = agent.Run(ctx)
 	if err != nil {
 		log.Fatalf("Agent execution failed: %v", err)
 	}
@@ -0,0 +1,387 @@
+package multichain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"agent/bridge"
+	"agent/contracts"
+	"agent/state"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const approvalBucket = "approvals"
+
+// Permit2Address is the canonical Permit2 deployment, identical on every supported chain
+var Permit2Address = common.HexToAddress("0x000000000022D473030F116dDf19e8A8De7B9ba3")
+
+const permit2ABIJSON = `[
+	{"inputs":[{"internalType":"address","name":"user","type":"address"},{"internalType":"address","name":"token","type":"address"},{"internalType":"address","name":"spender","type":"address"}],"name":"allowance","outputs":[{"internalType":"uint160","name":"amount","type":"uint160"},{"internalType":"uint48","name":"expiration","type":"uint48"},{"internalType":"uint48","name":"nonce","type":"uint48"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"address","name":"token","type":"address"},{"internalType":"address","name":"spender","type":"address"},{"internalType":"uint160","name":"amount","type":"uint160"},{"internalType":"uint48","name":"expiration","type":"uint48"}],"name":"approve","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+
+var (
+	permit2ABI = mustParseABI(permit2ABIJSON)
+
+	// maxUint160 bounds Permit2 allowance amounts
+	maxUint160 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+)
+
+// ApprovalMode decides how much allowance is granted when a swap needs more
+type ApprovalMode string
+
+const (
+	ApprovalExact  ApprovalMode = "exact"  // exactly the amount the swap spends
+	ApprovalCapped ApprovalMode = "capped" // CapMultiple times that amount, so repeated swaps skip the approval
+)
+
+// Approval is an allowance granted by the manager, kept so it can be revoked later
+type Approval struct {
+	ChainID   uint64         `json:"chainId"`
+	Owner     common.Address `json:"owner"`
+	Token     common.Address `json:"token"`
+	Spender   common.Address `json:"spender"`
+	Amount    *big.Int       `json:"amount"`
+	Permit2   bool           `json:"permit2"` // granted on Permit2 rather than on the token
+	GrantedAt time.Time      `json:"grantedAt"`
+	UsedAt    time.Time      `json:"usedAt"`
+}
+
+func (a *Approval) key() string {
+	key := fmt.Sprintf("%d-%s-%s-%s", a.ChainID, a.Owner.Hex(), a.Token.Hex(), a.Spender.Hex())
+	if a.Permit2 {
+		key += "-permit2"
+	}
+	return key
+}
+
+// AllowanceManager grants spenders the allowances swaps need, from the
+// sender's address, and records every approval so stale ones can be revoked.
+// Spenders registered with UsePermit2 pull tokens through Permit2: the token
+// is approved to Permit2, which in turn grants the spender an expiring allowance.
+type AllowanceManager struct {
+	Mode          ApprovalMode
+	CapMultiple   int64         // capped mode only
+	Permit2Expiry time.Duration // lifetime of Permit2 allowances
+	manager       *MultiChainManager
+	sender        bridge.Sender
+	store         *state.Store
+	permit2       map[uint64]map[common.Address]bool // chainID -> spenders using Permit2
+	mu            sync.Mutex
+}
+
+func NewAllowanceManager(manager *MultiChainManager, sender bridge.Sender, store *state.Store) *AllowanceManager {
+	return &AllowanceManager{
+		Mode:          ApprovalExact,
+		CapMultiple:   10,
+		Permit2Expiry: 30 * 24 * time.Hour,
+		manager:       manager,
+		sender:        sender,
+		store:         store,
+		permit2:       make(map[uint64]map[common.Address]bool),
+	}
+}
+
+// UsePermit2 marks a spender on a chain as pulling tokens through Permit2
+func (a *AllowanceManager) UsePermit2(chainID uint64, spender common.Address) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.permit2[chainID] == nil {
+		a.permit2[chainID] = make(map[common.Address]bool)
+	}
+	a.permit2[chainID][spender] = true
+}
+
+func (a *AllowanceManager) usesPermit2(chainID uint64, spender common.Address) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.permit2[chainID][spender]
+}
+
+// Allowance returns the sender's ERC-20 allowance of token to spender
+func (a *AllowanceManager) Allowance(ctx context.Context, chainID uint64, token, spender common.Address) (*big.Int, error) {
+	client, err := a.manager.GetClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := tokenABI.Pack("allowance", a.sender.Address(), spender)
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allowance of %s on chain %d: %v", token.Hex(), chainID, err)
+	}
+	allowance := decodeUint256(CallResult{Success: true, ReturnData: output})
+	if allowance == nil {
+		return nil, fmt.Errorf("invalid allowance of %s on chain %d", token.Hex(), chainID)
+	}
+	return allowance, nil
+}
+
+// Permit2Allowance returns the sender's Permit2 allowance of token to spender and when it expires
+func (a *AllowanceManager) Permit2Allowance(ctx context.Context, chainID uint64, token, spender common.Address) (*big.Int, time.Time, error) {
+	client, err := a.manager.GetClient(chainID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	data, _ := permit2ABI.Pack("allowance", a.sender.Address(), token, spender)
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &Permit2Address, Data: data}, nil)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read Permit2 allowance on chain %d: %v", chainID, err)
+	}
+	out, err := permit2ABI.Unpack("allowance", output)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode Permit2 allowance on chain %d: %v", chainID, err)
+	}
+	return out[0].(*big.Int), time.Unix(out[1].(*big.Int).Int64(), 0), nil
+}
+
+// EnsureAllowance approves spender for at least amount of token before a swap.
+// Native tokens need no approval.
+func (a *AllowanceManager) EnsureAllowance(ctx context.Context, chainID uint64, token, spender common.Address, amount *big.Int) error {
+	if token == bridge.NativeToken || spender == (common.Address{}) {
+		return nil
+	}
+	if !a.usesPermit2(chainID, spender) {
+		return a.ensureTokenAllowance(ctx, chainID, token, spender, amount)
+	}
+
+	if err := a.ensureTokenAllowance(ctx, chainID, token, Permit2Address, amount); err != nil {
+		return err
+	}
+
+	current, expiration, err := a.Permit2Allowance(ctx, chainID, token, spender)
+	if err != nil {
+		return err
+	}
+	approval := a.approval(chainID, token, spender, true)
+	if current.Cmp(amount) >= 0 && time.Until(expiration) > time.Minute {
+		a.touch(approval)
+		return nil
+	}
+
+	granted := a.grantAmount(amount)
+	if granted.Cmp(maxUint160) > 0 {
+		granted = maxUint160
+	}
+	expiry := big.NewInt(time.Now().Add(a.Permit2Expiry).Unix())
+	data, err := permit2ABI.Pack("approve", token, spender, granted, expiry)
+	if err != nil {
+		return fmt.Errorf("failed to pack Permit2 approval: %v", err)
+	}
+	if _, err := a.sender.Send(ctx, chainID, Permit2Address, data, nil); err != nil {
+		return fmt.Errorf("failed to approve %s through Permit2: %v", spender.Hex(), err)
+	}
+
+	log.Printf("🔓 Permit2 allowance of %s for %s on chain %d set to %s", token.Hex(), spender.Hex(), chainID, granted)
+	a.record(approval, granted)
+	return nil
+}
+
+func (a *AllowanceManager) ensureTokenAllowance(ctx context.Context, chainID uint64, token, spender common.Address, amount *big.Int) error {
+	current, err := a.Allowance(ctx, chainID, token, spender)
+	if err != nil {
+		return err
+	}
+	approval := a.approval(chainID, token, spender, false)
+	if current.Cmp(amount) >= 0 {
+		a.touch(approval)
+		return nil
+	}
+
+	granted := a.grantAmount(amount)
+	if err := a.approve(ctx, chainID, token, spender, granted); err != nil {
+		// Tokens such as USDT refuse to change a non-zero allowance to another non-zero value
+		if current.Sign() == 0 {
+			return err
+		}
+		if err := a.approve(ctx, chainID, token, spender, big.NewInt(0)); err != nil {
+			return err
+		}
+		if err := a.approve(ctx, chainID, token, spender, granted); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("🔓 Allowance of %s for %s on chain %d set to %s", token.Hex(), spender.Hex(), chainID, granted)
+	a.record(approval, granted)
+	return nil
+}
+
+func (a *AllowanceManager) approve(ctx context.Context, chainID uint64, token, spender common.Address, amount *big.Int) error {
+	data, _ := tokenABI.Pack("approve", spender, amount)
+	if _, err := a.sender.Send(ctx, chainID, token, data, nil); err != nil {
+		return fmt.Errorf("failed to approve %s for %s: %v", spender.Hex(), token.Hex(), err)
+	}
+	return nil
+}
+
+// AddApprovals appends to batch the approvals the sender needs before spending
+// amount of token through spender, so a smart account grants them in the same
+// executeCalls batch as the swap and neither lands without the other. The
+// returned func records the approvals and is called once the batch is mined.
+func (a *AllowanceManager) AddApprovals(ctx context.Context, batch *contracts.Batch, chainID uint64, token, spender common.Address, amount *big.Int) (func(), error) {
+	if token == bridge.NativeToken || spender == (common.Address{}) {
+		return func() {}, nil
+	}
+	permit2 := a.usesPermit2(chainID, spender)
+	tokenSpender := spender
+	if permit2 {
+		tokenSpender = Permit2Address
+	}
+
+	current, err := a.Allowance(ctx, chainID, token, tokenSpender)
+	if err != nil {
+		return nil, err
+	}
+	approvals := []*Approval{a.approval(chainID, token, tokenSpender, false)}
+	grants := []*big.Int{nil}
+	if current.Cmp(amount) < 0 {
+		granted := a.grantAmount(amount)
+		// Tokens such as USDT refuse to change a non-zero allowance to another non-zero value
+		if current.Sign() != 0 {
+			data, _ := tokenABI.Pack("approve", tokenSpender, big.NewInt(0))
+			batch.Add(token, nil, data)
+		}
+		data, _ := tokenABI.Pack("approve", tokenSpender, granted)
+		batch.Add(token, nil, data)
+		grants[0] = granted
+	}
+
+	if permit2 {
+		current, expiration, err := a.Permit2Allowance(ctx, chainID, token, spender)
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, a.approval(chainID, token, spender, true))
+		grants = append(grants, nil)
+		if current.Cmp(amount) < 0 || time.Until(expiration) <= time.Minute {
+			granted := a.grantAmount(amount)
+			if granted.Cmp(maxUint160) > 0 {
+				granted = maxUint160
+			}
+			expiry := big.NewInt(time.Now().Add(a.Permit2Expiry).Unix())
+			data, err := permit2ABI.Pack("approve", token, spender, granted, expiry)
+			if err != nil {
+				return nil, fmt.Errorf("failed to pack Permit2 approval: %v", err)
+			}
+			batch.Add(Permit2Address, nil, data)
+			grants[1] = granted
+		}
+	}
+
+	return func() {
+		for i, approval := range approvals {
+			if grants[i] == nil {
+				a.touch(approval)
+				continue
+			}
+			log.Printf("🔓 Allowance of %s for %s on chain %d set to %s", token.Hex(), approval.Spender.Hex(), chainID, grants[i])
+			a.record(approval, grants[i])
+		}
+	}, nil
+}
+
+// grantAmount is the allowance granted for a swap spending amount
+func (a *AllowanceManager) grantAmount(amount *big.Int) *big.Int {
+	if a.Mode == ApprovalCapped && a.CapMultiple > 1 {
+		return new(big.Int).Mul(amount, big.NewInt(a.CapMultiple))
+	}
+	return new(big.Int).Set(amount)
+}
+
+func (a *AllowanceManager) approval(chainID uint64, token, spender common.Address, permit2 bool) *Approval {
+	return &Approval{ChainID: chainID, Owner: a.sender.Address(), Token: token, Spender: spender, Permit2: permit2}
+}
+
+// record stores a new approval
+func (a *AllowanceManager) record(approval *Approval, amount *big.Int) {
+	now := time.Now()
+	approval.Amount = amount
+	approval.GrantedAt = now
+	approval.UsedAt = now
+	if err := a.store.Put(approvalBucket, approval.key(), approval); err != nil {
+		log.Printf("⚠️  Failed to record approval of %s: %v", approval.Token.Hex(), err)
+	}
+}
+
+// touch marks a recorded approval as used; allowances granted outside the manager are not tracked
+func (a *AllowanceManager) touch(approval *Approval) {
+	var stored Approval
+	found, err := a.store.Get(approvalBucket, approval.key(), &stored)
+	if err != nil || !found {
+		return
+	}
+	stored.UsedAt = time.Now()
+	if err := a.store.Put(approvalBucket, approval.key(), &stored); err != nil {
+		log.Printf("⚠️  Failed to update approval of %s: %v", approval.Token.Hex(), err)
+	}
+}
+
+// Approvals returns the recorded approvals of the sender, least recently used first
+func (a *AllowanceManager) Approvals() ([]*Approval, error) {
+	keys, err := a.store.Keys(approvalBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := a.sender.Address()
+	approvals := make([]*Approval, 0, len(keys))
+	for _, key := range keys {
+		var approval Approval
+		if _, err := a.store.Get(approvalBucket, key, &approval); err != nil {
+			return nil, err
+		}
+		if approval.Owner == owner {
+			approvals = append(approvals, &approval)
+		}
+	}
+	sort.Slice(approvals, func(i, j int) bool { return approvals[i].UsedAt.Before(approvals[j].UsedAt) })
+	return approvals, nil
+}
+
+// Revoke sets a recorded allowance back to zero and forgets it
+func (a *AllowanceManager) Revoke(ctx context.Context, approval *Approval) error {
+	if approval.Permit2 {
+		data, err := permit2ABI.Pack("approve", approval.Token, approval.Spender, big.NewInt(0), big.NewInt(0))
+		if err != nil {
+			return fmt.Errorf("failed to pack Permit2 revocation: %v", err)
+		}
+		if _, err := a.sender.Send(ctx, approval.ChainID, Permit2Address, data, nil); err != nil {
+			return fmt.Errorf("failed to revoke Permit2 allowance of %s: %v", approval.Spender.Hex(), err)
+		}
+	} else if err := a.approve(ctx, approval.ChainID, approval.Token, approval.Spender, big.NewInt(0)); err != nil {
+		return err
+	}
+
+	log.Printf("🔒 Revoked allowance of %s for %s on chain %d", approval.Token.Hex(), approval.Spender.Hex(), approval.ChainID)
+	return a.store.Delete(approvalBucket, approval.key())
+}
+
+// RevokeStale revokes every recorded approval unused for longer than maxIdle
+// and returns how many were revoked
+func (a *AllowanceManager) RevokeStale(ctx context.Context, maxIdle time.Duration) (int, error) {
+	approvals, err := a.Approvals()
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, approval := range approvals {
+		if time.Since(approval.UsedAt) < maxIdle {
+			break
+		}
+		if err := a.Revoke(ctx, approval); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
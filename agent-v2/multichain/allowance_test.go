@@ -0,0 +1,253 @@
+package multichain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"agent/contracts"
+	"agent/state"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	testAccount = common.HexToAddress("0xAcc0000000000000000000000000000000000001")
+	testToken   = common.HexToAddress("0x70c0000000000000000000000000000000000002")
+	testSpender = common.HexToAddress("0x5e00000000000000000000000000000000000003")
+)
+
+// recordingSender records the calls it is asked to send
+type recordingSender struct {
+	address common.Address
+	sent    []contracts.SmartAccountV2Call
+}
+
+func (s *recordingSender) Address() common.Address {
+	return s.address
+}
+
+func (s *recordingSender) Send(ctx context.Context, chainID uint64, to common.Address, data []byte, value *big.Int) (*types.Receipt, error) {
+	s.sent = append(s.sent, contracts.SmartAccountV2Call{Target: to, Value: value, Data: data})
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
+
+// allowanceChain serves the ERC-20 allowance of testToken to each spender and
+// the Permit2 allowance of testSpender
+type allowanceChain struct {
+	allowances     map[common.Address]*big.Int
+	permit2        *big.Int
+	permit2Expires time.Time
+}
+
+func newAllowanceManager(t *testing.T, chain allowanceChain) (*AllowanceManager, *recordingSender) {
+	manager := NewMultiChainManager()
+	rpc := addFakeChain(t, manager, &ChainConfig{ChainID: 10, Name: "Test", NativeSymbol: "ETH", NativeDecimals: 18})
+	rpc.handleCall(testToken, func(data []byte) ([]byte, error) {
+		args, err := tokenABI.Methods["allowance"].Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		allowance := chain.allowances[args[1].(common.Address)]
+		if allowance == nil {
+			allowance = big.NewInt(0)
+		}
+		return tokenABI.Methods["allowance"].Outputs.Pack(allowance)
+	})
+	rpc.handleCall(Permit2Address, func([]byte) ([]byte, error) {
+		amount := chain.permit2
+		if amount == nil {
+			amount = big.NewInt(0)
+		}
+		expiration := big.NewInt(0)
+		if !chain.permit2Expires.IsZero() {
+			expiration.SetInt64(chain.permit2Expires.Unix())
+		}
+		return permit2ABI.Methods["allowance"].Outputs.Pack(amount, expiration, big.NewInt(0))
+	})
+
+	store, err := state.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender := &recordingSender{address: testAccount}
+	return NewAllowanceManager(manager, sender, store), sender
+}
+
+func approveCall(spender common.Address, amount int64) contracts.SmartAccountV2Call {
+	data, _ := tokenABI.Pack("approve", spender, big.NewInt(amount))
+	return contracts.SmartAccountV2Call{Target: testToken, Value: new(big.Int), Data: data}
+}
+
+func permit2ApproveCall(amount int64, expiry time.Time) contracts.SmartAccountV2Call {
+	data, _ := permit2ABI.Pack("approve", testToken, testSpender, big.NewInt(amount), big.NewInt(expiry.Unix()))
+	return contracts.SmartAccountV2Call{Target: Permit2Address, Value: new(big.Int), Data: data}
+}
+
+func TestAddApprovals(t *testing.T) {
+	expiry := time.Now().Add(30 * 24 * time.Hour)
+	cases := []struct {
+		name     string
+		chain    allowanceChain
+		permit2  bool
+		calls    []contracts.SmartAccountV2Call
+		recorded int // approvals recorded after the batch
+	}{
+		{
+			name:  "sufficient allowance",
+			chain: allowanceChain{allowances: map[common.Address]*big.Int{testSpender: big.NewInt(100)}},
+		},
+		{
+			name:     "no allowance",
+			calls:    []contracts.SmartAccountV2Call{approveCall(testSpender, 100)},
+			recorded: 1,
+		},
+		{
+			name:     "non-zero allowance is reset first",
+			chain:    allowanceChain{allowances: map[common.Address]*big.Int{testSpender: big.NewInt(40)}},
+			calls:    []contracts.SmartAccountV2Call{approveCall(testSpender, 0), approveCall(testSpender, 100)},
+			recorded: 1,
+		},
+		{
+			name:     "Permit2 without allowances",
+			permit2:  true,
+			calls:    []contracts.SmartAccountV2Call{approveCall(Permit2Address, 100), permit2ApproveCall(100, expiry)},
+			recorded: 2,
+		},
+		{
+			name:    "expiring Permit2 allowance",
+			permit2: true,
+			chain: allowanceChain{
+				allowances:     map[common.Address]*big.Int{Permit2Address: big.NewInt(1000)},
+				permit2:        big.NewInt(1000),
+				permit2Expires: time.Now().Add(30 * time.Second),
+			},
+			calls:    []contracts.SmartAccountV2Call{permit2ApproveCall(100, expiry)},
+			recorded: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			allowances, sender := newAllowanceManager(t, c.chain)
+			if c.permit2 {
+				allowances.UsePermit2(10, testSpender)
+			}
+
+			batch := contracts.NewBatch()
+			record, err := allowances.AddApprovals(context.Background(), batch, 10, testToken, testSpender, big.NewInt(100))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sender.sent) != 0 {
+				t.Errorf("sent %d transactions, expected approvals only in the batch", len(sender.sent))
+			}
+			if len(batch.Calls) != len(c.calls) {
+				t.Fatalf("%d calls, expected %d", len(batch.Calls), len(c.calls))
+			}
+			for i, call := range batch.Calls {
+				expected := c.calls[i]
+				// The Permit2 expiry depends on the current time, so compare all but its last word
+				if call.Target == Permit2Address {
+					call.Data, expected.Data = call.Data[:len(call.Data)-32], expected.Data[:len(expected.Data)-32]
+				}
+				if call.Target != expected.Target || string(call.Data) != string(expected.Data) {
+					t.Errorf("call %d is %s %x, expected %s %x", i, call.Target.Hex(), call.Data, expected.Target.Hex(), expected.Data)
+				}
+			}
+
+			record()
+			approvals, err := allowances.Approvals()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(approvals) != c.recorded {
+				t.Errorf("%d approvals recorded, expected %d", len(approvals), c.recorded)
+			}
+		})
+	}
+}
+
+func TestRevokeStale(t *testing.T) {
+	other := common.HexToAddress("0x5e00000000000000000000000000000000000004")
+	permit2Revoke, _ := permit2ABI.Pack("approve", testToken, testSpender, big.NewInt(0), big.NewInt(0))
+	cases := []struct {
+		name      string
+		approvals []*Approval
+		calls     []contracts.SmartAccountV2Call
+		remaining int
+	}{
+		{
+			name:      "recently used",
+			approvals: []*Approval{{Spender: testSpender, UsedAt: time.Now().Add(-time.Minute)}},
+			remaining: 1,
+		},
+		{
+			name:      "idle token allowance",
+			approvals: []*Approval{{Spender: testSpender, UsedAt: time.Now().Add(-2 * time.Hour)}},
+			calls:     []contracts.SmartAccountV2Call{approveCall(testSpender, 0)},
+		},
+		{
+			name:      "idle Permit2 allowance",
+			approvals: []*Approval{{Spender: testSpender, Permit2: true, UsedAt: time.Now().Add(-2 * time.Hour)}},
+			calls:     []contracts.SmartAccountV2Call{{Target: Permit2Address, Data: permit2Revoke}},
+		},
+		{
+			name: "only idle ones, least recently used first",
+			approvals: []*Approval{
+				{Spender: testSpender, UsedAt: time.Now().Add(-time.Minute)},
+				{Spender: other, UsedAt: time.Now().Add(-3 * time.Hour)},
+				{Spender: Permit2Address, UsedAt: time.Now().Add(-2 * time.Hour)},
+			},
+			calls:     []contracts.SmartAccountV2Call{approveCall(other, 0), approveCall(Permit2Address, 0)},
+			remaining: 1,
+		},
+		{
+			name:      "other owners are left alone",
+			approvals: []*Approval{{Owner: other, Spender: testSpender, UsedAt: time.Now().Add(-2 * time.Hour)}},
+			remaining: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			allowances, sender := newAllowanceManager(t, allowanceChain{})
+			for _, approval := range c.approvals {
+				approval.ChainID = 10
+				approval.Token = testToken
+				if approval.Owner == (common.Address{}) {
+					approval.Owner = testAccount
+				}
+				if err := allowances.store.Put(approvalBucket, approval.key(), approval); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			revoked, err := allowances.RevokeStale(context.Background(), time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if revoked != len(c.calls) {
+				t.Errorf("revoked %d, expected %d", revoked, len(c.calls))
+			}
+			if len(sender.sent) != len(c.calls) {
+				t.Fatalf("sent %d transactions, expected %d", len(sender.sent), len(c.calls))
+			}
+			for i, call := range sender.sent {
+				if call.Target != c.calls[i].Target || string(call.Data) != string(c.calls[i].Data) {
+					t.Errorf("transaction %d is %s %x, expected %s %x", i, call.Target.Hex(), call.Data, c.calls[i].Target.Hex(), c.calls[i].Data)
+				}
+			}
+
+			keys, err := allowances.store.Keys(approvalBucket)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(keys) != c.remaining {
+				t.Errorf("%d approvals remain recorded, expected %d", len(keys), c.remaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,639 @@
+package multichain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"agent/bridge"
+	"agent/dex"
+	"agent/strategies"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ArbitragePair is the market a cross-chain strategy watches: buy Base with
+// Quote on one chain, bridge Base, and sell it for Quote on another
+type ArbitragePair struct {
+	BaseSymbol   string // e.g. ETH; matches the native currency, a token symbol or its PriceSymbol
+	QuoteSymbol  string // e.g. USDC
+	TradeSize    int64  // whole Quote tokens spent on the buy leg
+	SwapFeeBps   int64  // fees charged on top of the quoted output on each leg, e.g. aggregator commission
+	MinProfitBps int64  // minimum net profit relative to the trade size
+	SlippageBps  uint32 // tolerance on each swap; zero uses dex.DefaultSlippageBps
+}
+
+// BridgeFeeEstimator prices moving a token between two chains, in USD scaled by USDDecimals
+type BridgeFeeEstimator interface {
+	EstimateBridgeFee(ctx context.Context, fromChainID, toChainID uint64, token *TokenInfo, amount, valueUSD *big.Int) (*big.Int, error)
+}
+
+// StaticBridgeFees charges a flat USD fee plus a proportional fee on the bridged value
+type StaticBridgeFees struct {
+	FlatUSD *big.Int
+	Bps     int64
+}
+
+func (f *StaticBridgeFees) EstimateBridgeFee(ctx context.Context, fromChainID, toChainID uint64, token *TokenInfo, amount, valueUSD *big.Int) (*big.Int, error) {
+	fee := new(big.Int).Mul(valueUSD, big.NewInt(f.Bps))
+	fee.Div(fee, big.NewInt(10000))
+	if f.FlatUSD != nil {
+		fee.Add(fee, f.FlatUSD)
+	}
+	return fee, nil
+}
+
+// QuotedBridgeFees prices bridging from live bridge quotes: the tokens lost in
+// transit plus the native fee paid on the source chain
+type QuotedBridgeFees struct {
+	bridges *bridge.Tracker
+	manager *MultiChainManager
+	prices  PriceSource
+}
+
+func NewQuotedBridgeFees(bridges *bridge.Tracker, manager *MultiChainManager, prices PriceSource) *QuotedBridgeFees {
+	return &QuotedBridgeFees{bridges: bridges, manager: manager, prices: prices}
+}
+
+func (f *QuotedBridgeFees) EstimateBridgeFee(ctx context.Context, fromChainID, toChainID uint64, token *TokenInfo, amount, valueUSD *big.Int) (*big.Int, error) {
+	destination, err := f.manager.GetChain(toChainID)
+	if err != nil {
+		return nil, err
+	}
+	symbol := token.PriceSymbol
+	if symbol == "" {
+		symbol = token.Symbol
+	}
+	tokenOut, ok := resolveToken(destination, symbol)
+	if !ok {
+		return nil, fmt.Errorf("%s is not available on %s", symbol, destination.Name)
+	}
+
+	quote, err := f.bridges.BestQuote(ctx, &bridge.TransferRequest{
+		FromChainID: fromChainID,
+		ToChainID:   toChainID,
+		Token:       token.Address,
+		TokenOut:    tokenOut.Address,
+		Amount:      amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens lost in transit, valued at the source price
+	lost := new(big.Int).Sub(amount, rescale(quote.AmountOut, tokenOut.Decimals, token.Decimals))
+	fee := new(big.Int).Mul(valueUSD, lost)
+	fee.Div(fee, amount)
+
+	if quote.NativeFee.Sign() > 0 {
+		source, err := f.manager.GetChain(fromChainID)
+		if err != nil {
+			return nil, err
+		}
+		price, err := f.prices.GetUSDPrice(ctx, fromChainID, source.NativeTokenInfo())
+		if err != nil {
+			return nil, err
+		}
+		fee.Add(fee, ValueInUSD(quote.NativeFee, source.NativeDecimals, price))
+	}
+	return fee, nil
+}
+
+// DEXRouting selects the venues NewDEXRouter quotes on each chain
+type DEXRouting struct {
+	Credentials  dex.Credentials
+	Venues       []string       // aggregators to compare, e.g. okx, 1inch, 0x, paraswap; empty enables all
+	Direct       dex.Aggregator // optional direct pool router
+	DirectChains []uint64       // chains that always use Direct
+}
+
+// NewDEXRouter builds a router that compares the enabled aggregators of each
+// chain in parallel and keeps the best quote net of gas. The chain's
+// configured DEXAggregator is always included; OKX, 0x and ParaSwap serve
+// every mainnet through their shared endpoints. When Direct is set it serves
+// DirectChains and chains without any aggregator, and takes over on the
+// other chains whenever every aggregator fails.
+func (m *MultiChainManager) NewDEXRouter(routing *DEXRouting, prices PriceSource) *dex.Router {
+	preferDirect := make(map[uint64]bool)
+	for _, chainID := range routing.DirectChains {
+		preferDirect[chainID] = true
+	}
+	valuer := &dexGasValuer{manager: m, prices: prices}
+
+	router := dex.NewRouter()
+	for chainID, chain := range m.chains {
+		if routing.Direct != nil && preferDirect[chainID] {
+			router.SetAggregator(chainID, routing.Direct)
+			continue
+		}
+
+		aggregators := m.aggregators(chain, routing)
+		var aggregator dex.Aggregator
+		switch len(aggregators) {
+		case 0:
+			if routing.Direct != nil {
+				router.SetAggregator(chainID, routing.Direct)
+			}
+			continue
+		case 1:
+			aggregator = aggregators[0]
+		default:
+			aggregator = dex.NewBestOf(valuer, aggregators...)
+		}
+
+		if routing.Direct != nil {
+			aggregator = &dex.Fallback{Primary: aggregator, Secondary: routing.Direct}
+		}
+		router.SetAggregator(chainID, aggregator)
+	}
+	return router
+}
+
+// aggregators returns the enabled aggregator adapters for a chain
+func (m *MultiChainManager) aggregators(chain *ChainConfig, routing *DEXRouting) []dex.Aggregator {
+	enabled := func(name string) bool {
+		if len(routing.Venues) == 0 {
+			return true
+		}
+		for _, venue := range routing.Venues {
+			if strings.EqualFold(venue, name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	urls := []string{}
+	if chain.DEXAggregator != "" {
+		urls = append(urls, chain.DEXAggregator)
+	}
+	if !chain.IsTestnet {
+		urls = append(urls, dex.DefaultOKXURL, dex.DefaultParaSwapURL)
+		if routing.Credentials.ZeroExAPIKey != "" {
+			urls = append(urls, dex.DefaultZeroExURL)
+		}
+	}
+
+	var aggregators []dex.Aggregator
+	seen := make(map[string]bool)
+	for _, url := range urls {
+		aggregator, err := dex.NewAggregatorForURL(url, chain.ChainID, routing.Credentials)
+		if err != nil {
+			log.Printf("⚠️  No DEX aggregator for %s: %v", chain.Name, err)
+			continue
+		}
+		if seen[aggregator.Name()] || !enabled(aggregator.Name()) {
+			continue
+		}
+		seen[aggregator.Name()] = true
+		aggregators = append(aggregators, aggregator)
+	}
+	return aggregators
+}
+
+// dexGasValuer prices swap gas in the output token so venues can be compared net of gas
+type dexGasValuer struct {
+	manager *MultiChainManager
+	prices  PriceSource
+}
+
+func (v *dexGasValuer) GasCostIn(ctx context.Context, chainID uint64, gas uint64, token common.Address) (*big.Int, error) {
+	chain, err := v.manager.GetChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	client, err := v.manager.GetClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenInfo := chain.NativeTokenInfo()
+	if token != dex.NativeToken && token != chain.NativeToken {
+		var found bool
+		tokenInfo, found = findToken(chain, token)
+		if !found {
+			return nil, fmt.Errorf("token %s not known on %s", token.Hex(), chain.Name)
+		}
+	}
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %v", err)
+	}
+	nativePrice, err := v.prices.GetUSDPrice(ctx, chainID, chain.NativeTokenInfo())
+	if err != nil {
+		return nil, err
+	}
+	tokenPrice, err := v.prices.GetUSDPrice(ctx, chainID, tokenInfo)
+	if err != nil {
+		return nil, err
+	}
+	if tokenPrice.Sign() == 0 {
+		return nil, fmt.Errorf("%s has no price", tokenInfo.Symbol)
+	}
+
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas))
+	cost := ValueInUSD(fee, chain.NativeDecimals, nativePrice)
+	cost.Mul(cost, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(tokenInfo.Decimals)), nil))
+	return cost.Div(cost, tokenPrice), nil
+}
+
+// findToken looks up a tracked ERC-20 token of a chain by address
+func findToken(chain *ChainConfig, address common.Address) (*TokenInfo, bool) {
+	for _, token := range chain.Tokens {
+		if token.Address == address {
+			return token, true
+		}
+	}
+	return nil, false
+}
+
+// resolveToken finds a symbol on a chain, preferring the native currency
+func resolveToken(chain *ChainConfig, symbol string) (*TokenInfo, bool) {
+	if strings.EqualFold(chain.NativeSymbol, symbol) {
+		return chain.NativeTokenInfo(), true
+	}
+	for _, token := range chain.Tokens {
+		if strings.EqualFold(token.Symbol, symbol) {
+			return token, true
+		}
+	}
+	for _, token := range chain.Tokens {
+		if strings.EqualFold(token.PriceSymbol, symbol) {
+			return token, true
+		}
+	}
+	return nil, false
+}
+
+// opportunityTTL is how long opportunities found by ShouldExecute stay valid for Execute
+const opportunityTTL = time.Minute
+
+// CrossChainStrategy represents a trading strategy that operates across multiple chains
+type CrossChainStrategy struct {
+	ID            uint64
+	Name          string
+	ChainIDs      []uint64
+	Pair          *ArbitragePair
+	MinProfitUSD  *big.Int      // optional; minimum net profit per run, scaled by USDDecimals
+	Cooldown      time.Duration // minimum time between two runs
+	LastExecution time.Time
+	Active        bool
+	opportunities []*ArbitrageOpportunity
+	scannedAt     time.Time
+	manager       *MultiChainManager
+	portfolio     *CrossChainPortfolio
+	quoter        *dex.Router
+	gas           *GasOptimizer
+	bridgeFees    BridgeFeeEstimator
+	executor      *ArbitrageExecutor
+}
+
+func NewCrossChainStrategy(
+	id uint64,
+	name string,
+	chainIDs []uint64,
+	pair *ArbitragePair,
+	manager *MultiChainManager,
+	portfolio *CrossChainPortfolio,
+	quoter *dex.Router,
+	gas *GasOptimizer,
+	bridgeFees BridgeFeeEstimator,
+	executor *ArbitrageExecutor,
+) *CrossChainStrategy {
+	return &CrossChainStrategy{
+		ID:         id,
+		Name:       name,
+		ChainIDs:   chainIDs,
+		Pair:       pair,
+		Active:     true,
+		manager:    manager,
+		portfolio:  portfolio,
+		quoter:     quoter,
+		gas:        gas,
+		bridgeFees: bridgeFees,
+		executor:   executor,
+	}
+}
+
+// ShouldExecute scans for opportunities once the cooldown has passed. Unfinished
+// runs make the strategy due whenever one of them can advance.
+func (s *CrossChainStrategy) ShouldExecute(ctx context.Context) (bool, error) {
+	if !s.Active {
+		return false, nil
+	}
+	if s.executor != nil && s.executor.Ready() {
+		return true, nil
+	}
+	if time.Since(s.LastExecution) < s.Cooldown {
+		return false, nil
+	}
+
+	opportunities, err := s.FindArbitrageOpportunities(ctx)
+	if err != nil {
+		return false, err
+	}
+	s.opportunities = opportunities
+	s.scannedAt = time.Now()
+	return len(opportunities) > 0, nil
+}
+
+func (s *CrossChainStrategy) GetType() string {
+	return "CrossChainArbitrage"
+}
+
+func (s *CrossChainStrategy) GetID() uint64 {
+	return s.ID
+}
+
+// SetTradeRecorder reports the swaps of every arbitrage leg
+func (s *CrossChainStrategy) SetTradeRecorder(recorder strategies.TradeRecorder) {
+	if s.executor != nil {
+		s.executor.SetTradeRecorder(recorder)
+	}
+}
+
+func (s *CrossChainStrategy) Execute(ctx context.Context) error {
+	log.Printf("🌐 Executing cross-chain strategy: %s", s.Name)
+
+	// Finish runs interrupted by a restart and follow inventory rebalances
+	if s.executor != nil {
+		s.executor.Resume(ctx)
+	}
+
+	if time.Since(s.LastExecution) < s.Cooldown {
+		return nil
+	}
+
+	// Reuse the opportunities found by ShouldExecute while their quotes are fresh
+	opportunities := s.opportunities
+	if time.Since(s.scannedAt) > opportunityTTL {
+		var err error
+		opportunities, err = s.FindArbitrageOpportunities(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to find opportunities: %v", err)
+		}
+	}
+	s.opportunities = nil
+
+	if len(opportunities) == 0 {
+		return nil
+	}
+
+	// Execute only the best opportunity; the others share its inventory
+	opportunity := opportunities[0]
+	log.Printf("💡 Found arbitrage: %s -> %s (net $%s, %d bps)",
+		s.manager.chains[opportunity.ChainA].Name,
+		s.manager.chains[opportunity.ChainB].Name,
+		FormatUSD(opportunity.NetProfitUSD),
+		opportunity.ProfitBps)
+
+	s.LastExecution = time.Now()
+	return s.ExecuteArbitrage(ctx, opportunity)
+}
+
+// ArbitrageOpportunity is a buy on ChainA, a bridge and a sell on ChainB, priced
+// from executable quotes for the configured trade size
+type ArbitrageOpportunity struct {
+	ChainA         uint64
+	ChainB         uint64
+	Token          common.Address // base token bought on ChainA
+	TokenB         common.Address // base token sold on ChainB
+	QuoteTokenA    common.Address
+	QuoteTokenB    common.Address
+	AmountIn       *big.Int // quote tokens spent on ChainA
+	BaseAmount     *big.Int // base tokens bought on ChainA and bridged
+	SellAmount     *big.Int // base tokens sold on ChainB, BaseAmount in ChainB decimals
+	AmountOut      *big.Int // quote tokens received on ChainB
+	NotionalUSD    *big.Int // USD value of AmountIn
+	PriceA         *big.Int // quote per base on ChainA, scaled by USDDecimals
+	PriceB         *big.Int // quote per base on ChainB, scaled by USDDecimals
+	GrossProfitUSD *big.Int
+	SwapFeeUSD     *big.Int
+	GasCostUSD     *big.Int
+	BridgeFeeUSD   *big.Int
+	NetProfitUSD   *big.Int
+	ProfitBps      int64 // net profit relative to the USD value of AmountIn
+}
+
+// pairQuote is the buy-leg view of the pair on one chain
+type pairQuote struct {
+	chain      *ChainConfig
+	base       *TokenInfo
+	quote      *TokenInfo
+	amountIn   *big.Int
+	baseOut    *big.Int
+	costUSD    *big.Int // USD value of amountIn
+	quotePrice *big.Int // USD price of the quote token
+	gasUSD     *big.Int // cost of one swap on this chain
+}
+
+// FindArbitrageOpportunities quotes the pair on every chain and returns the
+// ordered chain pairs whose net profit clears MinProfitBps and MinProfitUSD, best first
+func (s *CrossChainStrategy) FindArbitrageOpportunities(ctx context.Context) ([]*ArbitrageOpportunity, error) {
+	legs := make(map[uint64]*pairQuote)
+	for _, chainID := range s.ChainIDs {
+		leg, err := s.quoteBuyLeg(ctx, chainID)
+		if err != nil {
+			log.Printf("⚠️  Skipping chain %d for %s/%s: %v", chainID, s.Pair.BaseSymbol, s.Pair.QuoteSymbol, err)
+			continue
+		}
+		legs[chainID] = leg
+	}
+
+	opportunities := []*ArbitrageOpportunity{}
+	for _, chainA := range s.ChainIDs {
+		for _, chainB := range s.ChainIDs {
+			buy, sell := legs[chainA], legs[chainB]
+			if chainA == chainB || buy == nil || sell == nil {
+				continue
+			}
+
+			opportunity, err := s.evaluate(ctx, buy, sell)
+			if err != nil {
+				log.Printf("⚠️  Failed to evaluate %s -> %s: %v", buy.chain.Name, sell.chain.Name, err)
+				continue
+			}
+			if s.profitable(opportunity) {
+				opportunities = append(opportunities, opportunity)
+			}
+		}
+	}
+
+	sort.Slice(opportunities, func(i, j int) bool {
+		return opportunities[i].NetProfitUSD.Cmp(opportunities[j].NetProfitUSD) > 0
+	})
+	return opportunities, nil
+}
+
+func (s *CrossChainStrategy) profitable(opportunity *ArbitrageOpportunity) bool {
+	if opportunity.NetProfitUSD.Sign() <= 0 || opportunity.ProfitBps < s.Pair.MinProfitBps {
+		return false
+	}
+	return s.MinProfitUSD == nil || opportunity.NetProfitUSD.Cmp(s.MinProfitUSD) >= 0
+}
+
+// quoteBuyLeg quotes spending TradeSize quote tokens for the base token on a chain
+func (s *CrossChainStrategy) quoteBuyLeg(ctx context.Context, chainID uint64) (*pairQuote, error) {
+	chain, err := s.manager.GetChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	base, ok := resolveToken(chain, s.Pair.BaseSymbol)
+	if !ok {
+		return nil, fmt.Errorf("%s is not available on %s", s.Pair.BaseSymbol, chain.Name)
+	}
+	quoteToken, ok := resolveToken(chain, s.Pair.QuoteSymbol)
+	if !ok {
+		return nil, fmt.Errorf("%s is not available on %s", s.Pair.QuoteSymbol, chain.Name)
+	}
+
+	quotePrice, err := s.portfolio.prices.GetUSDPrice(ctx, chainID, quoteToken)
+	if err != nil {
+		return nil, err
+	}
+	gasCost, err := s.gas.EstimateTransactionCost(ctx, chainID, TxTypeSwap)
+	if err != nil {
+		return nil, err
+	}
+	if gasCost.CostUSD == nil {
+		return nil, fmt.Errorf("swap gas cost on %s could not be priced", chain.Name)
+	}
+
+	amountIn := new(big.Int).Mul(big.NewInt(s.Pair.TradeSize),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(quoteToken.Decimals)), nil))
+	quote, err := s.quoter.Quote(ctx, &dex.QuoteRequest{
+		ChainID:     chainID,
+		TokenIn:     quoteToken.Address,
+		TokenOut:    base.Address,
+		AmountIn:    amountIn,
+		DecimalsIn:  quoteToken.Decimals,
+		DecimalsOut: base.Decimals,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("buy quote failed: %v", err)
+	}
+	if quote.AmountOut.Sign() <= 0 {
+		return nil, fmt.Errorf("buy quote returned no output")
+	}
+
+	return &pairQuote{
+		chain:      chain,
+		base:       base,
+		quote:      quoteToken,
+		amountIn:   amountIn,
+		baseOut:    quote.AmountOut,
+		costUSD:    ValueInUSD(amountIn, quoteToken.Decimals, quotePrice),
+		quotePrice: quotePrice,
+		gasUSD:     gasCost.CostUSD,
+	}, nil
+}
+
+// evaluate quotes selling the base bought on buy.chain on sell.chain and nets out all costs
+func (s *CrossChainStrategy) evaluate(ctx context.Context, buy, sell *pairQuote) (*ArbitrageOpportunity, error) {
+	// The bridged amount keeps its value but may use different decimals on the destination
+	baseIn := rescale(buy.baseOut, buy.base.Decimals, sell.base.Decimals)
+	quote, err := s.quoter.Quote(ctx, &dex.QuoteRequest{
+		ChainID:     sell.chain.ChainID,
+		TokenIn:     sell.base.Address,
+		TokenOut:    sell.quote.Address,
+		AmountIn:    baseIn,
+		DecimalsIn:  sell.base.Decimals,
+		DecimalsOut: sell.quote.Decimals,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sell quote failed: %v", err)
+	}
+
+	proceedsUSD := ValueInUSD(quote.AmountOut, sell.quote.Decimals, sell.quotePrice)
+	gross := new(big.Int).Sub(proceedsUSD, buy.costUSD)
+
+	swapFees := new(big.Int).Add(buy.costUSD, proceedsUSD)
+	swapFees.Mul(swapFees, big.NewInt(s.Pair.SwapFeeBps))
+	swapFees.Div(swapFees, big.NewInt(10000))
+
+	gas := new(big.Int).Add(buy.gasUSD, sell.gasUSD)
+
+	// Both inventories are restored afterwards: the base bridged to the sell chain
+	// and the proceeds bridged back to the buy chain
+	bridgeFee := big.NewInt(0)
+	if s.bridgeFees != nil {
+		baseFee, err := s.bridgeFees.EstimateBridgeFee(ctx, buy.chain.ChainID, sell.chain.ChainID, buy.base, buy.baseOut, buy.costUSD)
+		if err != nil {
+			return nil, fmt.Errorf("bridge fee estimate failed: %v", err)
+		}
+		quoteFee, err := s.bridgeFees.EstimateBridgeFee(ctx, sell.chain.ChainID, buy.chain.ChainID, sell.quote, quote.AmountOut, proceedsUSD)
+		if err != nil {
+			return nil, fmt.Errorf("bridge fee estimate failed: %v", err)
+		}
+		bridgeFee.Add(baseFee, quoteFee)
+	}
+
+	net := new(big.Int).Sub(gross, swapFees)
+	net.Sub(net, gas)
+	net.Sub(net, bridgeFee)
+
+	profitBps := int64(0)
+	if buy.costUSD.Sign() > 0 {
+		profitBps = new(big.Int).Div(new(big.Int).Mul(net, big.NewInt(10000)), buy.costUSD).Int64()
+	}
+
+	return &ArbitrageOpportunity{
+		ChainA:         buy.chain.ChainID,
+		ChainB:         sell.chain.ChainID,
+		Token:          buy.base.Address,
+		TokenB:         sell.base.Address,
+		QuoteTokenA:    buy.quote.Address,
+		QuoteTokenB:    sell.quote.Address,
+		AmountIn:       buy.amountIn,
+		BaseAmount:     buy.baseOut,
+		SellAmount:     baseIn,
+		AmountOut:      quote.AmountOut,
+		NotionalUSD:    buy.costUSD,
+		PriceA:         unitPrice(buy.amountIn, buy.quote.Decimals, buy.baseOut, buy.base.Decimals),
+		PriceB:         unitPrice(quote.AmountOut, sell.quote.Decimals, baseIn, sell.base.Decimals),
+		GrossProfitUSD: gross,
+		SwapFeeUSD:     swapFees,
+		GasCostUSD:     gas,
+		BridgeFeeUSD:   bridgeFee,
+		NetProfitUSD:   net,
+		ProfitBps:      profitBps,
+	}, nil
+}
+
+// unitPrice returns quote tokens per whole base token, scaled by USDDecimals
+func unitPrice(quoteAmount *big.Int, quoteDecimals uint8, baseAmount *big.Int, baseDecimals uint8) *big.Int {
+	if baseAmount.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	price := rescale(quoteAmount, quoteDecimals, USDDecimals)
+	price.Mul(price, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(baseDecimals)), nil))
+	return price.Div(price, baseAmount)
+}
+
+// ExecuteArbitrage runs an opportunity in inventory mode: the sell on ChainB
+// uses tokens already held there instead of waiting for the bridge
+func (s *CrossChainStrategy) ExecuteArbitrage(ctx context.Context, opportunity *ArbitrageOpportunity) error {
+	log.Printf("⚡ Executing arbitrage between chains %d and %d: buy at %s, sell at %s",
+		opportunity.ChainA, opportunity.ChainB,
+		formatUnits(opportunity.PriceA, USDDecimals), formatUnits(opportunity.PriceB, USDDecimals))
+
+	if s.executor == nil {
+		return fmt.Errorf("no arbitrage executor configured")
+	}
+
+	run, err := s.executor.Start(ctx, s.ID, opportunity, s.Pair.SlippageBps)
+	if err != nil {
+		return err
+	}
+	if run.State == RunAborted || run.State == RunFailed || run.State == RunCompensated {
+		return fmt.Errorf("arbitrage run %s ended %s: %s", run.ID, run.State, run.Error)
+	}
+
+	log.Printf("✅ Arbitrage run %s is %s", run.ID, run.State)
+	return nil
+}
@@ -0,0 +1,48 @@
+package multichain
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"agent/bridge"
+	"agent/contracts"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ExecuteBatch simulates batch on the account and, if no call would revert it,
+// sends it as one executeCalls transaction signed by signer. It returns the
+// outcome of every call as recorded on-chain.
+func (m *MultiChainManager) ExecuteBatch(ctx context.Context, signer bridge.Sender, chainID uint64, account common.Address, batch *contracts.Batch) ([]contracts.CallResult, *types.Receipt, error) {
+	client, err := m.GetClient(chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := batch.Pack()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack batch: %v", err)
+	}
+
+	if _, err := batch.Simulate(ctx, client, signer.Address(), account); err != nil {
+		return nil, nil, err
+	}
+
+	receipt, err := signer.Send(ctx, chainID, account, data, nil)
+	if err != nil {
+		return nil, receipt, err
+	}
+	m.Gas.RecordReceipt(chainID, TxTypeBatch, receipt)
+	results, err := batch.Results(receipt, account)
+	if err != nil {
+		return nil, receipt, err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			log.Printf("⚠️  Batched call %d to %s failed in %s", result.Index, result.Target.Hex(), receipt.TxHash.Hex())
+		}
+	}
+	return results, receipt, nil
+}
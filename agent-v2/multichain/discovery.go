@@ -0,0 +1,117 @@
+package multichain
+
+import (
+	"context"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	// DefaultDiscoveryLookback is how many blocks behind the head discovery starts on chains without a checkpoint
+	DefaultDiscoveryLookback = 10000
+	// discoveryBatchBlocks keeps eth_getLogs ranges within common public RPC limits
+	discoveryBatchBlocks = 2000
+	// maxDiscoveryBatches bounds the log scanning done per portfolio update
+	maxDiscoveryBatches = 10
+)
+
+type tokenDiscovery struct {
+	lookback    uint64
+	checkpoints map[uint64]uint64 // chainID -> next block to scan
+}
+
+// EnableTokenDiscovery makes UpdateBalances scan ERC-20 Transfer logs into the
+// tracked accounts and start tracking every token received. Anyone can send a
+// token, so discovered tokens count toward the portfolio only once tracked
+// with TrackToken. Each chain resumes from its checkpoint block, or starts
+// lookback blocks behind the head.
+func (p *CrossChainPortfolio) EnableTokenDiscovery(checkpoints map[uint64]uint64, lookback uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	discovery := &tokenDiscovery{
+		lookback:    lookback,
+		checkpoints: make(map[uint64]uint64),
+	}
+	for chainID, block := range checkpoints {
+		discovery.checkpoints[chainID] = block
+	}
+	p.discovery = discovery
+}
+
+// DiscoveryCheckpoints returns the next block to scan on each chain so that
+// discovery can be resumed after a restart
+func (p *CrossChainPortfolio) DiscoveryCheckpoints() map[uint64]uint64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	checkpoints := make(map[uint64]uint64)
+	if p.discovery != nil {
+		for chainID, block := range p.discovery.checkpoints {
+			checkpoints[chainID] = block
+		}
+	}
+	return checkpoints
+}
+
+func (p *CrossChainPortfolio) discoverTokens(ctx context.Context, chainID uint64, client *ethclient.Client) {
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		log.Printf("⚠️  Token discovery skipped on chain %d: %v", chainID, err)
+		return
+	}
+
+	p.mu.RLock()
+	from, exists := p.discovery.checkpoints[chainID]
+	lookback := p.discovery.lookback
+	p.mu.RUnlock()
+	if !exists {
+		from = 0
+		if head > lookback {
+			from = head - lookback
+		}
+	}
+
+	accounts := p.trackedAccounts(chainID)
+	recipients := make([]common.Hash, 0, len(accounts))
+	for _, account := range accounts {
+		recipients = append(recipients, common.BytesToHash(account.Bytes()))
+	}
+
+	found := make(map[common.Address]bool)
+	for batch := 0; batch < maxDiscoveryBatches && from <= head; batch++ {
+		to := from + discoveryBatchBlocks - 1
+		if to > head {
+			to = head
+		}
+
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Topics:    [][]common.Hash{{TransferEventTopic}, nil, recipients},
+		})
+		if err != nil {
+			log.Printf("⚠️  Failed to scan transfers on chain %d (blocks %d-%d): %v", chainID, from, to, err)
+			break
+		}
+
+		for _, entry := range logs {
+			// ERC-721 transfers share the topic but index the token ID as well
+			if len(entry.Topics) == 3 {
+				found[entry.Address] = true
+			}
+		}
+		from = to + 1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.discovery.checkpoints[chainID] = from
+	for token := range found {
+		p.trackTokenLocked(chainID, token, true)
+	}
+}
@@ -0,0 +1,693 @@
+package multichain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"agent/bridge"
+	"agent/dex"
+	"agent/notify"
+	"agent/state"
+	"agent/strategies"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const arbitrageBucket = "arbitrage_runs"
+
+const (
+	// maxCompensationAttempts bounds retries of an unwind before a run needs manual attention
+	maxCompensationAttempts = 3
+
+	// reconcileToleranceBps is how far below its expected output an interrupted leg
+	// may land and still be counted as executed
+	reconcileToleranceBps = 500
+)
+
+// RunState is the stage of an arbitrage run. Runs move
+// planned -> bought -> sold -> rebalancing -> completed, or leave that path
+// through aborted (buy failed), compensating -> compensated (sell failed) or
+// failed (needs manual attention).
+type RunState string
+
+const (
+	RunPlanned      RunState = "planned"
+	RunBought       RunState = "bought"
+	RunSold         RunState = "sold"
+	RunRebalancing  RunState = "rebalancing"
+	RunCompleted    RunState = "completed"
+	RunCompensating RunState = "compensating"
+	RunCompensated  RunState = "compensated"
+	RunAborted      RunState = "aborted"
+	RunFailed       RunState = "failed"
+)
+
+// Terminal reports whether a run in this state needs no further work
+func (s RunState) Terminal() bool {
+	switch s {
+	case RunCompleted, RunCompensated, RunAborted, RunFailed:
+		return true
+	}
+	return false
+}
+
+type LegStatus string
+
+const (
+	LegPending   LegStatus = "pending"
+	LegSubmitted LegStatus = "submitted" // sent, outcome not yet recorded
+	LegDone      LegStatus = "done"
+	LegFailed    LegStatus = "failed"
+)
+
+// Leg is one swap of an arbitrage run
+type Leg struct {
+	ChainID       uint64         `json:"chainId"`
+	TokenIn       common.Address `json:"tokenIn"`
+	TokenOut      common.Address `json:"tokenOut"`
+	AmountIn      *big.Int       `json:"amountIn"`
+	ExpectedOut   *big.Int       `json:"expectedOut,omitempty"`
+	SlippageBps   uint32         `json:"slippageBps,omitempty"`   // tolerance passed to the aggregator; zero uses dex.DefaultSlippageBps
+	BalanceBefore *big.Int       `json:"balanceBefore,omitempty"` // TokenOut balance before submission, used to reconcile after a crash
+	AmountOut     *big.Int       `json:"amountOut,omitempty"`
+	Venue         string         `json:"venue,omitempty"`
+	TxHash        common.Hash    `json:"txHash"`
+	Status        LegStatus      `json:"status"`
+	Error         string         `json:"error,omitempty"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+// ArbitrageRun is the durable record of one inventory-mode arbitrage: buy on
+// ChainA, sell pre-positioned inventory on ChainB, then bridge the bought
+// tokens to ChainB and the sale proceeds to ChainA to restore both inventories
+type ArbitrageRun struct {
+	ID                     string                `json:"id"`
+	StrategyID             uint64                `json:"strategyId"`
+	State                  RunState              `json:"state"`
+	Opportunity            *ArbitrageOpportunity `json:"opportunity"`
+	Buy                    *Leg                  `json:"buy"`
+	Sell                   *Leg                  `json:"sell"`
+	Compensation           *Leg                  `json:"compensation,omitempty"`
+	Attempts               int                   `json:"attempts"`
+	RebalanceStarted       bool                  `json:"rebalanceStarted"`
+	RebalanceTransfer      string                `json:"rebalanceTransfer,omitempty"` // base tokens, ChainA -> ChainB
+	QuoteRebalanceStarted  bool                  `json:"quoteRebalanceStarted"`
+	QuoteRebalanceTransfer string                `json:"quoteRebalanceTransfer,omitempty"` // quote tokens, ChainB -> ChainA
+	Error                  string                `json:"error,omitempty"`
+	CreatedAt              time.Time             `json:"createdAt"`
+	UpdatedAt              time.Time             `json:"updatedAt"`
+}
+
+// ArbitrageExecutor drives arbitrage runs through their state machine,
+// persisting every transition so a run interrupted by a crash is resumed
+type ArbitrageExecutor struct {
+	Allowances *AllowanceManager // approves swap spenders from the sender
+	Notifier   notify.Notifier   // optional; receives slippage alerts
+	manager    *MultiChainManager
+	router     *dex.Router
+	bridges    *bridge.Tracker
+	sender     bridge.Sender
+	store      *state.Store
+	limits     map[uint64]*big.Int // chainID -> max USD notional of open runs touching the chain
+	recorder   strategies.TradeRecorder
+	runs       map[string]*ArbitrageRun
+	mu         sync.Mutex
+}
+
+func NewArbitrageExecutor(
+	manager *MultiChainManager,
+	router *dex.Router,
+	bridges *bridge.Tracker,
+	sender bridge.Sender,
+	store *state.Store,
+	limits map[uint64]*big.Int,
+) (*ArbitrageExecutor, error) {
+	e := &ArbitrageExecutor{
+		Allowances: NewAllowanceManager(manager, sender, store),
+		manager:    manager,
+		router:     router,
+		bridges:    bridges,
+		sender:     sender,
+		store:      store,
+		limits:     limits,
+		runs:       make(map[string]*ArbitrageRun),
+	}
+
+	keys, err := store.Keys(arbitrageBucket)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		var run ArbitrageRun
+		if _, err := store.Get(arbitrageBucket, key, &run); err != nil {
+			return nil, err
+		}
+		if !run.State.Terminal() {
+			e.runs[run.ID] = &run
+		}
+	}
+	if len(e.runs) > 0 {
+		log.Printf("⚡ Resuming %d unfinished arbitrage runs", len(e.runs))
+	}
+
+	return e, nil
+}
+
+// SetTradeRecorder reports every completed leg as a trade of the run's strategy
+func (e *ArbitrageExecutor) SetTradeRecorder(recorder strategies.TradeRecorder) {
+	e.recorder = recorder
+}
+
+// Exposure returns the USD notional of open runs per chain
+func (e *ArbitrageExecutor) Exposure() map[uint64]*big.Int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.exposureLocked()
+}
+
+func (e *ArbitrageExecutor) exposureLocked() map[uint64]*big.Int {
+	exposure := make(map[uint64]*big.Int)
+	for _, run := range e.runs {
+		for _, chainID := range []uint64{run.Opportunity.ChainA, run.Opportunity.ChainB} {
+			if exposure[chainID] == nil {
+				exposure[chainID] = big.NewInt(0)
+			}
+			exposure[chainID].Add(exposure[chainID], run.Opportunity.NotionalUSD)
+		}
+	}
+	return exposure
+}
+
+// Start checks limits and inventory, then executes a new run as far as it can go.
+// Both legs and any compensation swap are bounded by slippageBps.
+func (e *ArbitrageExecutor) Start(ctx context.Context, strategyID uint64, opportunity *ArbitrageOpportunity, slippageBps uint32) (*ArbitrageRun, error) {
+	if err := e.checkInventory(ctx, opportunity); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	run := &ArbitrageRun{
+		ID:          fmt.Sprintf("%s-%d-%d", timeKey(now), opportunity.ChainA, opportunity.ChainB),
+		StrategyID:  strategyID,
+		State:       RunPlanned,
+		Opportunity: opportunity,
+		Buy: &Leg{
+			ChainID:     opportunity.ChainA,
+			TokenIn:     opportunity.QuoteTokenA,
+			TokenOut:    opportunity.Token,
+			AmountIn:    opportunity.AmountIn,
+			ExpectedOut: opportunity.BaseAmount,
+			SlippageBps: slippageBps,
+			Status:      LegPending,
+		},
+		Sell: &Leg{
+			ChainID:     opportunity.ChainB,
+			TokenIn:     opportunity.TokenB,
+			TokenOut:    opportunity.QuoteTokenB,
+			AmountIn:    opportunity.SellAmount,
+			ExpectedOut: opportunity.AmountOut,
+			SlippageBps: slippageBps,
+			Status:      LegPending,
+		},
+		CreatedAt: now,
+	}
+
+	e.mu.Lock()
+	exposure := e.exposureLocked()
+	for _, chainID := range []uint64{opportunity.ChainA, opportunity.ChainB} {
+		limit, exists := e.limits[chainID]
+		if !exists {
+			continue
+		}
+		total := new(big.Int).Add(opportunity.NotionalUSD, zeroIfNil(exposure[chainID]))
+		if total.Cmp(limit) > 0 {
+			e.mu.Unlock()
+			return nil, fmt.Errorf("exposure on chain %d would reach $%s, limit is $%s",
+				chainID, FormatUSD(total), FormatUSD(limit))
+		}
+	}
+	e.runs[run.ID] = run
+	e.mu.Unlock()
+
+	if err := e.persist(run); err != nil {
+		e.mu.Lock()
+		delete(e.runs, run.ID)
+		e.mu.Unlock()
+		return nil, err
+	}
+
+	e.advance(ctx, run)
+	return run, nil
+}
+
+// Resume advances every unfinished run, e.g. after a restart or while a rebalance is in flight
+func (e *ArbitrageExecutor) Resume(ctx context.Context) {
+	for _, run := range e.OpenRuns() {
+		e.advance(ctx, run)
+	}
+}
+
+// Ready reports whether an open run can advance. A rebalancing run is ready
+// once the tracker records all its transfers as settled, or one as failed.
+func (e *ArbitrageExecutor) Ready() bool {
+	for _, run := range e.OpenRuns() {
+		if run.State != RunRebalancing {
+			return true
+		}
+		settled := true
+		for _, id := range run.rebalanceTransfers() {
+			transfer, found, err := e.bridges.Get(id)
+			if err != nil || !found || transfer.Status == bridge.StatusFailed {
+				return true
+			}
+			settled = settled && transfer.Status.Terminal()
+		}
+		if settled {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenRuns returns the runs that have not reached a terminal state
+func (e *ArbitrageExecutor) OpenRuns() []*ArbitrageRun {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	runs := make([]*ArbitrageRun, 0, len(e.runs))
+	for _, run := range e.runs {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// advance moves a run forward until it finishes or has to wait
+func (e *ArbitrageExecutor) advance(ctx context.Context, run *ArbitrageRun) {
+	for !run.State.Terminal() {
+		previous := run.State
+
+		switch run.State {
+		case RunPlanned:
+			e.runLeg(ctx, run, run.Buy)
+			switch run.Buy.Status {
+			case LegDone:
+				run.State = RunBought
+			case LegFailed:
+				// Nothing was bought, so there is nothing to unwind
+				run.State = RunAborted
+				run.Error = "buy failed: " + run.Buy.Error
+			}
+
+		case RunBought:
+			e.runLeg(ctx, run, run.Sell)
+			switch run.Sell.Status {
+			case LegDone:
+				run.State = RunSold
+			case LegFailed:
+				run.State = RunCompensating
+				run.Error = "sell failed: " + run.Sell.Error
+				run.Compensation = &Leg{
+					ChainID:     run.Buy.ChainID,
+					TokenIn:     run.Buy.TokenOut,
+					TokenOut:    run.Buy.TokenIn,
+					AmountIn:    run.Buy.AmountOut,
+					SlippageBps: run.Buy.SlippageBps,
+					Status:      LegPending,
+				}
+			}
+
+		case RunCompensating:
+			e.runLeg(ctx, run, run.Compensation)
+			switch run.Compensation.Status {
+			case LegDone:
+				run.State = RunCompensated
+			case LegFailed:
+				run.Attempts++
+				if run.Attempts >= maxCompensationAttempts {
+					run.State = RunFailed
+					run.Error = "unwind failed: " + run.Compensation.Error
+					log.Printf("🚨 Arbitrage run %s could not be unwound, %s of token %s remain on chain %d",
+						run.ID, run.Buy.AmountOut.String(), run.Buy.TokenOut.Hex(), run.Buy.ChainID)
+				} else {
+					run.Compensation.Status = LegPending
+					e.persist(run)
+					return // retry on the next resume
+				}
+			}
+
+		case RunSold:
+			base := &bridge.TransferRequest{
+				FromChainID: run.Buy.ChainID,
+				ToChainID:   run.Sell.ChainID,
+				Token:       run.Buy.TokenOut,
+				TokenOut:    run.Sell.TokenIn,
+				Amount:      run.Buy.AmountOut,
+			}
+			if !e.startRebalance(ctx, run, base, &run.RebalanceStarted, &run.RebalanceTransfer) {
+				break
+			}
+			quote := &bridge.TransferRequest{
+				FromChainID: run.Sell.ChainID,
+				ToChainID:   run.Buy.ChainID,
+				Token:       run.Sell.TokenOut,
+				TokenOut:    run.Buy.TokenIn,
+				Amount:      run.Sell.AmountOut,
+			}
+			if !e.startRebalance(ctx, run, quote, &run.QuoteRebalanceStarted, &run.QuoteRebalanceTransfer) {
+				break
+			}
+			run.State = RunRebalancing
+
+		case RunRebalancing:
+			completed := true
+			for _, id := range run.rebalanceTransfers() {
+				transfer, found, err := e.bridges.Get(id)
+				if err != nil || !found {
+					log.Printf("⚠️  Failed to look up rebalance transfer %s: %v", id, err)
+					return
+				}
+				if transfer.Status == bridge.StatusFailed {
+					run.State = RunFailed
+					run.Error = "rebalance transfer failed: " + transfer.Error
+					log.Printf("🚨 Arbitrage run %s: %s", run.ID, run.Error)
+					break
+				}
+				completed = completed && transfer.Status == bridge.StatusCompleted
+			}
+			if run.State == RunRebalancing && completed {
+				run.State = RunCompleted
+			}
+		}
+
+		if run.State == previous {
+			return
+		}
+		log.Printf("⚡ Arbitrage run %s: %s -> %s", run.ID, previous, run.State)
+		e.persist(run)
+	}
+
+	e.mu.Lock()
+	delete(e.runs, run.ID)
+	e.mu.Unlock()
+}
+
+// runLeg executes a pending leg, or reconciles one that was submitted before a crash
+func (e *ArbitrageExecutor) runLeg(ctx context.Context, run *ArbitrageRun, leg *Leg) {
+	if leg.Status == LegSubmitted {
+		e.reconcileLeg(ctx, leg)
+		e.reportLeg(ctx, run, leg)
+		return
+	}
+	if leg.Status != LegPending {
+		return
+	}
+
+	before, err := e.balanceOf(ctx, leg.ChainID, leg.TokenOut)
+	if err != nil {
+		return // transient; the leg stays pending
+	}
+	leg.BalanceBefore = before
+	leg.Status = LegSubmitted
+	leg.UpdatedAt = time.Now()
+	if err := e.persist(run); err != nil {
+		leg.Status = LegPending
+		return
+	}
+
+	if err := e.swap(ctx, leg); err != nil {
+		leg.Status = LegFailed
+		leg.Error = err.Error()
+		log.Printf("❌ Arbitrage leg on chain %d failed: %v", leg.ChainID, err)
+	} else {
+		leg.Status = LegDone
+	}
+	leg.UpdatedAt = time.Now()
+	e.reportLeg(ctx, run, leg)
+}
+
+func (e *ArbitrageExecutor) reportLeg(ctx context.Context, run *ArbitrageRun, leg *Leg) {
+	if e.recorder == nil || leg.Status != LegDone || leg.AmountOut == nil {
+		return
+	}
+
+	trade := &strategies.Trade{
+		StrategyID:   run.StrategyID,
+		StrategyType: "CrossChainArbitrage",
+		ChainID:      leg.ChainID,
+		TxHash:       leg.TxHash,
+		TokenIn:      leg.TokenIn,
+		TokenOut:     leg.TokenOut,
+		AmountIn:     leg.AmountIn,
+		AmountOut:    leg.AmountOut,
+		Timestamp:    leg.UpdatedAt,
+	}
+	if err := e.recorder.RecordTrade(ctx, trade); err != nil {
+		log.Printf("⚠️  Failed to record arbitrage leg of run %s: %v", run.ID, err)
+	}
+}
+
+// reconcileLeg decides the outcome of an interrupted leg from the balance it should have changed
+func (e *ArbitrageExecutor) reconcileLeg(ctx context.Context, leg *Leg) {
+	balance, err := e.balanceOf(ctx, leg.ChainID, leg.TokenOut)
+	if err != nil {
+		return
+	}
+	received := new(big.Int).Sub(balance, leg.BalanceBefore)
+
+	threshold := big.NewInt(1)
+	if leg.ExpectedOut != nil {
+		threshold = new(big.Int).Mul(leg.ExpectedOut, big.NewInt(10000-reconcileToleranceBps))
+		threshold.Div(threshold, big.NewInt(10000))
+	}
+
+	if received.Cmp(threshold) >= 0 {
+		leg.Status = LegDone
+		leg.AmountOut = received
+	} else {
+		leg.Status = LegFailed
+		leg.Error = "interrupted before completion"
+	}
+	leg.UpdatedAt = time.Now()
+}
+
+// swap executes a leg through the DEX router and records what it received
+func (e *ArbitrageExecutor) swap(ctx context.Context, leg *Leg) error {
+	req := &dex.QuoteRequest{
+		ChainID:     leg.ChainID,
+		TokenIn:     leg.TokenIn,
+		TokenOut:    leg.TokenOut,
+		AmountIn:    leg.AmountIn,
+		From:        e.sender.Address(),
+		SlippageBps: leg.SlippageBps,
+	}
+	quote, err := e.router.Swap(ctx, req)
+	if err != nil {
+		return err
+	}
+	leg.Venue = quote.Venue
+
+	// Refuse calldata whose minimum output is looser than our tolerance
+	if err := dex.CheckMinReceive(quote, req.Slippage()); err != nil {
+		return err
+	}
+
+	if err := e.Allowances.EnsureAllowance(ctx, leg.ChainID, leg.TokenIn, quote.Spender, leg.AmountIn); err != nil {
+		return err
+	}
+
+	// Aggregator calldata is what swaps look like on this chain, so estimates before enough receipts use it
+	e.manager.Gas.SetRepresentativeCall(leg.ChainID, TxTypeSwap, ethereum.CallMsg{
+		From:  e.sender.Address(),
+		To:    &quote.Tx.To,
+		Data:  quote.Tx.Data,
+		Value: quote.Tx.Value,
+	})
+	receipt, err := e.sender.Send(ctx, leg.ChainID, quote.Tx.To, quote.Tx.Data, quote.Tx.Value)
+	if receipt != nil {
+		leg.TxHash = receipt.TxHash
+	}
+	if err != nil {
+		return err
+	}
+	e.manager.Gas.RecordReceipt(leg.ChainID, TxTypeSwap, receipt)
+
+	leg.AmountOut = e.amountReceived(ctx, leg, receipt, quote)
+
+	realized := dex.RealizedSlippageBps(quote.AmountOut, leg.AmountOut)
+	if realized > int64(req.Slippage()) {
+		notify.Alertf(ctx, e.Notifier, "Realized slippage of %d bps on %s swap %s exceeds the %d bps limit: expected %s, received %s",
+			realized, quote.Venue, leg.TxHash.Hex(), req.Slippage(), quote.AmountOut, leg.AmountOut)
+	}
+	return nil
+}
+
+// amountReceived measures a swap's output from the receipt's Transfer logs,
+// falling back to the balance change for native output or tokens that do not log
+func (e *ArbitrageExecutor) amountReceived(ctx context.Context, leg *Leg, receipt *types.Receipt, quote *dex.Quote) *big.Int {
+	if leg.TokenOut != bridge.NativeToken {
+		if received := dex.AmountReceived(receipt, leg.TokenOut, e.sender.Address()); received.Sign() > 0 {
+			return received
+		}
+	}
+
+	balance, err := e.balanceOf(ctx, leg.ChainID, leg.TokenOut)
+	if err != nil {
+		return quote.AmountOut
+	}
+	received := new(big.Int).Sub(balance, leg.BalanceBefore)
+	if leg.TokenOut == bridge.NativeToken {
+		received.Add(received, e.gasPaid(ctx, leg.ChainID, receipt))
+	}
+	return received
+}
+
+// gasPaid returns the fee the account paid for a transaction, which lowers its
+// native balance alongside the swap output. It is zero when someone else sent it,
+// e.g. a session key or bundler sending through a smart account.
+func (e *ArbitrageExecutor) gasPaid(ctx context.Context, chainID uint64, receipt *types.Receipt) *big.Int {
+	if receipt.EffectiveGasPrice == nil {
+		return big.NewInt(0)
+	}
+	client, err := e.manager.GetClient(chainID)
+	if err != nil {
+		return big.NewInt(0)
+	}
+	tx, _, err := client.TransactionByHash(ctx, receipt.TxHash)
+	if err != nil {
+		log.Printf("⚠️  Failed to look up swap %s to measure its gas: %v", receipt.TxHash.Hex(), err)
+		return big.NewInt(0)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil || from != e.sender.Address() {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+}
+
+// rebalanceTransfers returns the IDs of the run's initiated rebalance transfers
+func (run *ArbitrageRun) rebalanceTransfers() []string {
+	var ids []string
+	for _, id := range []string{run.RebalanceTransfer, run.QuoteRebalanceTransfer} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// startRebalance initiates one rebalance transfer of a run unless it already has.
+// It reports false when the run has to wait for a retry or has failed.
+func (e *ArbitrageExecutor) startRebalance(ctx context.Context, run *ArbitrageRun, req *bridge.TransferRequest, started *bool, transferID *string) bool {
+	if *transferID != "" {
+		return true
+	}
+	if *started {
+		// A crash hit between sending the bridge transfer and recording it
+		run.State = RunFailed
+		run.Error = "rebalance transfer may have been sent but was not recorded"
+		log.Printf("🚨 Arbitrage run %s: %s", run.ID, run.Error)
+		return false
+	}
+	*started = true
+	e.persist(run)
+
+	transfer, err := e.rebalance(ctx, req)
+	if err != nil {
+		log.Printf("⚠️  Failed to rebalance inventory for run %s: %v", run.ID, err)
+		*started = false
+		e.persist(run)
+		return false // retry on the next resume
+	}
+	*transferID = transfer.ID
+	e.persist(run)
+	return true
+}
+
+// rebalance bridges tokens to the chain where a run used up inventory
+func (e *ArbitrageExecutor) rebalance(ctx context.Context, req *bridge.TransferRequest) (*bridge.Transfer, error) {
+	quote, err := e.bridges.BestQuote(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	transfer, err := e.bridges.Initiate(ctx, quote)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.manager.Gas.LearnFromTransaction(ctx, transfer.FromChainID, TxTypeBridge, transfer.SourceTxHash); err != nil {
+		log.Printf("⚠️  Failed to learn bridge gas usage: %v", err)
+	}
+	return transfer, nil
+}
+
+// checkInventory verifies the sender holds the quote tokens for the buy and the base tokens for the sell
+func (e *ArbitrageExecutor) checkInventory(ctx context.Context, opportunity *ArbitrageOpportunity) error {
+	required := []struct {
+		chainID uint64
+		token   common.Address
+		amount  *big.Int
+	}{
+		{opportunity.ChainA, opportunity.QuoteTokenA, opportunity.AmountIn},
+		{opportunity.ChainB, opportunity.TokenB, opportunity.SellAmount},
+	}
+
+	for _, r := range required {
+		balance, err := e.balanceOf(ctx, r.chainID, r.token)
+		if err != nil {
+			return err
+		}
+		if balance.Cmp(r.amount) < 0 {
+			return fmt.Errorf("insufficient inventory of %s on chain %d: have %s, need %s",
+				r.token.Hex(), r.chainID, balance.String(), r.amount.String())
+		}
+	}
+	return nil
+}
+
+func (e *ArbitrageExecutor) balanceOf(ctx context.Context, chainID uint64, token common.Address) (*big.Int, error) {
+	client, err := e.manager.GetClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+	account := e.sender.Address()
+
+	if token == bridge.NativeToken {
+		balance, err := client.BalanceAt(ctx, account, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance on chain %d: %v", chainID, err)
+		}
+		return balance, nil
+	}
+
+	call := balanceOfCall(token, account)
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &call.Target, Data: call.CallData}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance of %s on chain %d: %v", token.Hex(), chainID, err)
+	}
+	balance := decodeUint256(CallResult{Success: true, ReturnData: output})
+	if balance == nil {
+		return nil, fmt.Errorf("invalid balance of %s on chain %d", token.Hex(), chainID)
+	}
+	return balance, nil
+}
+
+func (e *ArbitrageExecutor) persist(run *ArbitrageRun) error {
+	run.UpdatedAt = time.Now()
+	if err := e.store.Put(arbitrageBucket, run.ID, run); err != nil {
+		log.Printf("⚠️  Failed to persist arbitrage run %s: %v", run.ID, err)
+		return err
+	}
+	return nil
+}
+
+func zeroIfNil(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return value
+}
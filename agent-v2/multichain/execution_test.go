@@ -0,0 +1,225 @@
+package multichain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"agent/bridge"
+	"agent/dex"
+	"agent/state"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	testQuoteA = common.HexToAddress("0x70c0000000000000000000000000000000000011")
+	testQuoteB = common.HexToAddress("0x70c0000000000000000000000000000000000012")
+	testBaseB  = common.HexToAddress("0x70c0000000000000000000000000000000000013")
+)
+
+// testRun is a run between chains 10 and 8453 whose legs both completed
+func testRun(runState RunState) *ArbitrageRun {
+	return &ArbitrageRun{
+		ID:         "run",
+		StrategyID: 1,
+		State:      runState,
+		Opportunity: &ArbitrageOpportunity{
+			ChainA: 10, ChainB: 8453, Token: testToken, TokenB: testBaseB,
+			QuoteTokenA: testQuoteA, QuoteTokenB: testQuoteB, NotionalUSD: big.NewInt(1000e8),
+		},
+		Buy: &Leg{
+			ChainID: 10, TokenIn: testQuoteA, TokenOut: testToken,
+			AmountIn: big.NewInt(1000), AmountOut: big.NewInt(500), Status: LegDone,
+		},
+		Sell: &Leg{
+			ChainID: 8453, TokenIn: testBaseB, TokenOut: testQuoteB,
+			AmountIn: big.NewInt(500), ExpectedOut: big.NewInt(1100), AmountOut: big.NewInt(1100), Status: LegDone,
+		},
+	}
+}
+
+func TestArbitrageResume(t *testing.T) {
+	cases := []struct {
+		name      string
+		run       func() *ArbitrageRun
+		transfers map[string]bridge.Status
+		balance   int64 // sell-chain balance of the sell leg's output token
+		ready     bool  // whether the run can advance before resuming
+		state     RunState
+		check     func(t *testing.T, run *ArbitrageRun)
+	}{
+		{
+			name: "both rebalances completed",
+			run: func() *ArbitrageRun {
+				run := testRun(RunRebalancing)
+				run.RebalanceTransfer, run.QuoteRebalanceTransfer = "base", "quote"
+				return run
+			},
+			transfers: map[string]bridge.Status{"base": bridge.StatusCompleted, "quote": bridge.StatusCompleted},
+			ready:     true,
+			state:     RunCompleted,
+		},
+		{
+			name: "waiting on a rebalance",
+			run: func() *ArbitrageRun {
+				run := testRun(RunRebalancing)
+				run.RebalanceTransfer, run.QuoteRebalanceTransfer = "base", "quote"
+				return run
+			},
+			transfers: map[string]bridge.Status{"base": bridge.StatusCompleted, "quote": bridge.StatusPending},
+			ready:     false,
+			state:     RunRebalancing,
+		},
+		{
+			name: "failed rebalance",
+			run: func() *ArbitrageRun {
+				run := testRun(RunRebalancing)
+				run.RebalanceTransfer, run.QuoteRebalanceTransfer = "base", "quote"
+				return run
+			},
+			transfers: map[string]bridge.Status{"base": bridge.StatusFailed, "quote": bridge.StatusPending},
+			ready:     true,
+			state:     RunFailed,
+		},
+		{
+			name: "run recorded before the quote leg was rebalanced",
+			run: func() *ArbitrageRun {
+				run := testRun(RunRebalancing)
+				run.RebalanceTransfer = "base"
+				return run
+			},
+			transfers: map[string]bridge.Status{"base": bridge.StatusCompleted},
+			ready:     true,
+			state:     RunCompleted,
+		},
+		{
+			name: "crash while sending the base transfer",
+			run: func() *ArbitrageRun {
+				run := testRun(RunSold)
+				run.RebalanceStarted = true
+				return run
+			},
+			ready: true,
+			state: RunFailed,
+		},
+		{
+			name: "crash while sending the quote transfer",
+			run: func() *ArbitrageRun {
+				run := testRun(RunSold)
+				run.RebalanceStarted, run.RebalanceTransfer = true, "base"
+				run.QuoteRebalanceStarted = true
+				return run
+			},
+			transfers: map[string]bridge.Status{"base": bridge.StatusPending},
+			ready:     true,
+			state:     RunFailed,
+		},
+		{
+			name: "interrupted sell that landed",
+			run: func() *ArbitrageRun {
+				run := testRun(RunBought)
+				run.Sell.Status, run.Sell.AmountOut, run.Sell.BalanceBefore = LegSubmitted, nil, big.NewInt(1000)
+				return run
+			},
+			balance: 2080, // 1080 received, within the tolerance of the expected 1100
+			ready:   true,
+			state:   RunSold, // no bridge quotes, so the rebalance is retried later
+			check: func(t *testing.T, run *ArbitrageRun) {
+				if run.Sell.Status != LegDone || run.Sell.AmountOut.Int64() != 1080 {
+					t.Errorf("sell %s with %v received, expected done with 1080", run.Sell.Status, run.Sell.AmountOut)
+				}
+				if run.RebalanceStarted {
+					t.Error("failed rebalance left marked as started")
+				}
+			},
+		},
+		{
+			name: "interrupted sell that did not land",
+			run: func() *ArbitrageRun {
+				run := testRun(RunBought)
+				run.Sell.Status, run.Sell.AmountOut, run.Sell.BalanceBefore = LegSubmitted, nil, big.NewInt(1000)
+				return run
+			},
+			balance: 1000,
+			ready:   true,
+			state:   RunCompensating, // no swap venue, so the unwind is retried later
+			check: func(t *testing.T, run *ArbitrageRun) {
+				if run.Sell.Status != LegFailed {
+					t.Errorf("sell %s, expected failed", run.Sell.Status)
+				}
+				unwind := run.Compensation
+				if unwind == nil || unwind.ChainID != 10 || unwind.TokenIn != testToken || unwind.AmountIn.Int64() != 500 {
+					t.Fatalf("unwind %+v, expected selling the 500 bought on chain 10", unwind)
+				}
+				if unwind.Status != LegPending || run.Attempts != 1 {
+					t.Errorf("unwind %s after %d attempts, expected pending after 1", unwind.Status, run.Attempts)
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			manager := NewMultiChainManager()
+			for _, chainID := range []uint64{10, 8453} {
+				rpc := addFakeChain(t, manager, &ChainConfig{ChainID: chainID, Name: "Test", NativeSymbol: "ETH", NativeDecimals: 18})
+				balance := func([]byte) ([]byte, error) {
+					return tokenABI.Methods["balanceOf"].Outputs.Pack(big.NewInt(c.balance))
+				}
+				for _, token := range []common.Address{testToken, testQuoteA, testQuoteB, testBaseB} {
+					rpc.handleCall(token, balance)
+				}
+			}
+			manager.Gas = NewGasOptimizer(manager, fixedPrice{big.NewInt(1e8)})
+
+			// Runs and transfers are loaded from the store, as after a restart
+			store, err := state.Open(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for id, status := range c.transfers {
+				transfer := &bridge.Transfer{ID: id, Status: status, InitiatedAt: time.Now()}
+				if err := store.Put("bridge_transfers", id, transfer); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := store.Put(arbitrageBucket, "run", c.run()); err != nil {
+				t.Fatal(err)
+			}
+
+			sender := &recordingSender{address: testAccount}
+			bridges, err := bridge.NewTracker(store, sender)
+			if err != nil {
+				t.Fatal(err)
+			}
+			executor, err := NewArbitrageExecutor(manager, dex.NewRouter(), bridges, sender, store, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ready := executor.Ready(); ready != c.ready {
+				t.Errorf("ready %v, expected %v", ready, c.ready)
+			}
+			executor.Resume(context.Background())
+
+			var run ArbitrageRun
+			if _, err := store.Get(arbitrageBucket, "run", &run); err != nil {
+				t.Fatal(err)
+			}
+			if run.State != c.state {
+				t.Errorf("run %s (%s), expected %s", run.State, run.Error, c.state)
+			}
+			if open := len(executor.OpenRuns()) > 0; open == c.state.Terminal() {
+				t.Errorf("run open %v in state %s", open, run.State)
+			}
+			if len(sender.sent) != 0 {
+				t.Errorf("sent %d transactions", len(sender.sent))
+			}
+			if c.check != nil {
+				c.check(t, &run)
+			}
+		})
+	}
+}
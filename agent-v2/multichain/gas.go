@@ -0,0 +1,369 @@
+package multichain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Transaction types with distinct gas profiles
+const (
+	TxTypeSwap    = "swap"
+	TxTypeApprove = "approve"
+	TxTypeBridge  = "bridge"
+	TxTypeExecute = "execute" // Smart Account execute overhead around an inner call
+	TxTypeBatch   = "batch"   // SmartAccountV2 executeCalls batch, including its calls
+)
+
+// defaultGasUsage is the gas assumed for a transaction type before anything is learned
+var defaultGasUsage = map[string]uint64{
+	TxTypeSwap:    180000,
+	TxTypeApprove: 50000,
+	TxTypeBridge:  150000,
+	TxTypeExecute: 60000,
+	TxTypeBatch:   250000,
+}
+
+const (
+	// minGasSamples is the number of receipts needed before learned usage replaces estimates
+	minGasSamples = 3
+	// maxGasSamples bounds the receipt history kept per chain and transaction type
+	maxGasSamples = 50
+	// gasEstimateTTL is how long an eth_estimateGas result is reused
+	gasEstimateTTL = 10 * time.Minute
+)
+
+// GasProfile tracks how much gas one transaction type uses on one chain
+type GasProfile struct {
+	TxType         string
+	samples        []uint64
+	call           *ethereum.CallMsg // representative call used for eth_estimateGas
+	estimate       uint64
+	estimatedAt    time.Time
+	estimateFailed bool
+}
+
+// GasOptimizer helps choose the best chain for transactions based on gas costs
+type GasOptimizer struct {
+	manager  *MultiChainManager
+	prices   PriceSource
+	profiles map[uint64]map[string]*GasProfile // chainID -> txType -> profile
+	mu       sync.Mutex
+}
+
+// ChainGasCost is the estimated cost of one transaction type on one chain
+type ChainGasCost struct {
+	ChainID        uint64
+	Name           string
+	TxType         string
+	GasUsage       uint64
+	GasPrice       *big.Int // wei per gas
+	ExecutionFee   *big.Int // wei, GasPrice times GasUsage less any L1 gas it includes
+	L1DataFee      *big.Int // wei, zero on L1s
+	TotalFee       *big.Int // wei in the chain's native token
+	NativeSymbol   string
+	NativePriceUSD *big.Int // scaled by USDDecimals; nil if unpriced
+	CostUSD        *big.Int // scaled by USDDecimals; nil if unpriced
+}
+
+func NewGasOptimizer(manager *MultiChainManager, prices PriceSource) *GasOptimizer {
+	return &GasOptimizer{
+		manager:  manager,
+		prices:   prices,
+		profiles: make(map[uint64]map[string]*GasProfile),
+	}
+}
+
+func (g *GasOptimizer) profile(chainID uint64, txType string) *GasProfile {
+	if g.profiles[chainID] == nil {
+		g.profiles[chainID] = make(map[string]*GasProfile)
+	}
+	profile, exists := g.profiles[chainID][txType]
+	if !exists {
+		profile = &GasProfile{TxType: txType}
+		g.profiles[chainID][txType] = profile
+	}
+	return profile
+}
+
+// RecordReceipt learns the gas used by a mined transaction of the given type.
+// Like LearnFromTransaction and SetRepresentativeCall it does nothing on a nil optimizer.
+func (g *GasOptimizer) RecordReceipt(chainID uint64, txType string, receipt *types.Receipt) {
+	if g == nil || receipt == nil || receipt.Status != types.ReceiptStatusSuccessful {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	profile := g.profile(chainID, txType)
+	profile.samples = append(profile.samples, receipt.GasUsed)
+	if len(profile.samples) > maxGasSamples {
+		profile.samples = profile.samples[len(profile.samples)-maxGasSamples:]
+	}
+}
+
+// LearnFromTransaction fetches the receipt of a transaction and records its gas usage
+func (g *GasOptimizer) LearnFromTransaction(ctx context.Context, chainID uint64, txType string, txHash common.Hash) error {
+	if g == nil {
+		return nil
+	}
+	client, err := g.manager.GetClient(chainID)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get receipt for %s: %v", txHash.Hex(), err)
+	}
+
+	g.RecordReceipt(chainID, txType, receipt)
+	return nil
+}
+
+// SetRepresentativeCall registers calldata typical of a transaction type,
+// e.g. the last swap quote, used to refine the gas estimate via eth_estimateGas
+func (g *GasOptimizer) SetRepresentativeCall(chainID uint64, txType string, call ethereum.CallMsg) {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	profile := g.profile(chainID, txType)
+	profile.call = &call
+	profile.estimatedAt = time.Time{}
+	profile.estimateFailed = false
+}
+
+// EstimateGas returns the expected gas usage of a transaction type on a chain.
+// Learned receipt usage is preferred, then a fresh eth_estimateGas on the
+// representative call, then the built-in default for the type.
+func (g *GasOptimizer) EstimateGas(ctx context.Context, chainID uint64, txType string) uint64 {
+	usage, _ := g.estimateGas(ctx, chainID, txType)
+	return usage
+}
+
+// estimateGas is EstimateGas, also reporting whether the usage was measured on
+// the chain rather than taken from the defaults
+func (g *GasOptimizer) estimateGas(ctx context.Context, chainID uint64, txType string) (uint64, bool) {
+	g.mu.Lock()
+	profile := g.profile(chainID, txType)
+	if len(profile.samples) >= minGasSamples {
+		usage := median(profile.samples)
+		g.mu.Unlock()
+		return usage, true
+	}
+
+	call := profile.call
+	if call == nil {
+		call = g.defaultRepresentativeCall(chainID, txType)
+	}
+	if profile.estimate > 0 && time.Since(profile.estimatedAt) < gasEstimateTTL {
+		estimate := profile.estimate
+		g.mu.Unlock()
+		return estimate, true
+	}
+	failed := profile.estimateFailed && time.Since(profile.estimatedAt) < gasEstimateTTL
+	g.mu.Unlock()
+
+	if call != nil && !failed {
+		estimate, err := g.estimateCall(ctx, chainID, *call)
+		if err != nil {
+			log.Printf("⚠️  Gas estimate for %s on chain %d failed: %v", txType, chainID, err)
+		}
+
+		g.mu.Lock()
+		profile.estimate = estimate
+		profile.estimatedAt = time.Now()
+		profile.estimateFailed = err != nil
+		g.mu.Unlock()
+
+		if err == nil {
+			return estimate, true
+		}
+	}
+
+	if usage, exists := defaultGasUsage[txType]; exists {
+		return usage, false
+	}
+	return defaultGasUsage[TxTypeExecute], false
+}
+
+// SuggestGasLimit returns a gas limit with headroom over the highest observed usage
+func (g *GasOptimizer) SuggestGasLimit(ctx context.Context, chainID uint64, txType string) uint64 {
+	g.mu.Lock()
+	highest := uint64(0)
+	for _, sample := range g.profile(chainID, txType).samples {
+		if sample > highest {
+			highest = sample
+		}
+	}
+	g.mu.Unlock()
+
+	if highest == 0 {
+		highest = g.EstimateGas(ctx, chainID, txType)
+	}
+	return highest * 12 / 10
+}
+
+func (g *GasOptimizer) estimateCall(ctx context.Context, chainID uint64, call ethereum.CallMsg) (uint64, error) {
+	client, err := g.manager.GetClient(chainID)
+	if err != nil {
+		return 0, err
+	}
+	return client.EstimateGas(ctx, call)
+}
+
+// defaultRepresentativeCall builds calldata for types that can be estimated
+// without a prior quote: an approve of the chain's first built-in token
+func (g *GasOptimizer) defaultRepresentativeCall(chainID uint64, txType string) *ethereum.CallMsg {
+	if txType != TxTypeApprove {
+		return nil
+	}
+
+	chain, exists := g.manager.chains[chainID]
+	if !exists || len(chain.Tokens) == 0 {
+		return nil
+	}
+
+	data, err := tokenABI.Pack("approve", Multicall3Address, big.NewInt(1))
+	if err != nil {
+		return nil
+	}
+	token := chain.Tokens[0].Address
+	return &ethereum.CallMsg{
+		From: common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
+		To:   &token,
+		Data: data,
+	}
+}
+
+// RankChainsForTransaction estimates the USD cost of a transaction type on
+// every connected chain, including L1 data fees on rollups, cheapest first.
+// Chains whose native token cannot be priced are ranked last.
+func (g *GasOptimizer) RankChainsForTransaction(ctx context.Context, txType string) ([]*ChainGasCost, error) {
+	costs := make([]*ChainGasCost, 0, len(g.manager.clients))
+
+	for chainID := range g.manager.clients {
+		cost, err := g.EstimateTransactionCost(ctx, chainID, txType)
+		if err != nil {
+			log.Printf("⚠️  Failed to estimate %s cost on chain %d: %v", txType, chainID, err)
+			continue
+		}
+
+		usd := "unpriced"
+		if cost.CostUSD != nil {
+			usd = "$" + formatUnits(cost.CostUSD, USDDecimals)
+		}
+		log.Printf("⛽ %s: %s gas = %d, execution = %s wei, L1 data = %s wei (%s)",
+			cost.Name, txType, cost.GasUsage, cost.ExecutionFee.String(), cost.L1DataFee.String(), usd)
+
+		costs = append(costs, cost)
+	}
+
+	if len(costs) == 0 {
+		return nil, fmt.Errorf("no available chains")
+	}
+
+	sort.SliceStable(costs, func(i, j int) bool {
+		a, b := costs[i].CostUSD, costs[j].CostUSD
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Cmp(b) < 0
+	})
+
+	return costs, nil
+}
+
+// EstimateTransactionCost breaks down the cost of a transaction type on a chain
+func (g *GasOptimizer) EstimateTransactionCost(ctx context.Context, chainID uint64, txType string) (*ChainGasCost, error) {
+	chain, err := g.manager.GetChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	client, err := g.manager.GetClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %v", err)
+	}
+
+	gasUsage, measured := g.estimateGas(ctx, chainID, txType)
+	cost := &ChainGasCost{
+		ChainID:      chainID,
+		Name:         chain.Name,
+		TxType:       txType,
+		GasUsage:     gasUsage,
+		GasPrice:     gasPrice,
+		ExecutionFee: new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasUsage)),
+		L1DataFee:    big.NewInt(0),
+		NativeSymbol: chain.NativeSymbol,
+	}
+
+	if chain.L1FeeModel != L1FeeNone {
+		to, data := g.representativeCalldata(chainID, txType)
+		l1Fee, l1Gas, err := g.manager.EstimateL1Fee(ctx, chainID, to, data, gasUsage, gasPrice)
+		if err != nil {
+			log.Printf("⚠️  Failed to estimate L1 data fee on %s: %v", chain.Name, err)
+		} else {
+			cost.L1DataFee = l1Fee
+			// Measured Arbitrum gas already pays for L1 data; the defaults do not
+			if measured && l1Gas > 0 {
+				executionGas := gasUsage - min(l1Gas, gasUsage)
+				cost.ExecutionFee = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(executionGas))
+			}
+		}
+	}
+	cost.TotalFee = new(big.Int).Add(cost.ExecutionFee, cost.L1DataFee)
+
+	price, err := g.prices.GetUSDPrice(ctx, chainID, chain.NativeTokenInfo())
+	if err != nil {
+		log.Printf("⚠️  Failed to price %s on %s: %v", chain.NativeSymbol, chain.Name, err)
+	} else {
+		cost.NativePriceUSD = price
+		cost.CostUSD = ValueInUSD(cost.TotalFee, chain.NativeDecimals, price)
+	}
+
+	return cost, nil
+}
+
+// representativeCalldata returns the registered call for a type, or synthetic
+// calldata of typical length when none is known
+func (g *GasOptimizer) representativeCalldata(chainID uint64, txType string) (common.Address, []byte) {
+	g.mu.Lock()
+	call := g.profile(chainID, txType).call
+	if call == nil {
+		call = g.defaultRepresentativeCall(chainID, txType)
+	}
+	g.mu.Unlock()
+
+	if call != nil && call.To != nil {
+		return *call.To, call.Data
+	}
+
+	size, exists := typicalCalldataSize[txType]
+	if !exists {
+		size = typicalCalldataSize[TxTypeExecute]
+	}
+	return Multicall3Address, syntheticCalldata(size)
+}
+
+func median(values []uint64) uint64 {
+	sorted := append([]uint64{}, values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2]
+}
@@ -0,0 +1,102 @@
+package multichain
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// fixedPrice values every token at the same USD price
+type fixedPrice struct {
+	price *big.Int
+}
+
+func (f fixedPrice) GetUSDPrice(ctx context.Context, chainID uint64, token *TokenInfo) (*big.Int, error) {
+	return f.price, nil
+}
+
+func TestEstimateTransactionCost(t *testing.T) {
+	gwei := big.NewInt(1e9)
+	cases := []struct {
+		name     string
+		model    string
+		txType   string
+		call     bool   // register a representative call, so gas is measured by eth_estimateGas
+		estimate uint64 // eth_estimateGas result
+		l1Fee    *big.Int
+		l1Gas    uint64
+		l2Base   *big.Int
+		gas      uint64
+		exec     *big.Int
+		l1       *big.Int
+	}{
+		{
+			name: "L1", model: L1FeeNone, txType: TxTypeSwap, call: true, estimate: 200000,
+			gas: 200000, exec: new(big.Int).Mul(big.NewInt(200000), gwei), l1: big.NewInt(0),
+		},
+		{
+			name: "OP Stack adds the oracle fee", model: L1FeeOPStack, txType: TxTypeSwap, call: true, estimate: 200000,
+			l1Fee: big.NewInt(5e12),
+			gas:   200000, exec: new(big.Int).Mul(big.NewInt(200000), gwei), l1: big.NewInt(5e12),
+		},
+		{
+			name: "Arbitrum estimate already includes L1 gas", model: L1FeeArbitrum, txType: TxTypeSwap, call: true, estimate: 200000,
+			l1Gas: 50000, l2Base: gwei,
+			gas: 200000, exec: new(big.Int).Mul(big.NewInt(150000), gwei), l1: new(big.Int).Mul(big.NewInt(50000), gwei),
+		},
+		{
+			name: "Arbitrum default excludes L1 gas", model: L1FeeArbitrum, txType: TxTypeBridge,
+			l1Gas: 50000, l2Base: gwei,
+			gas:  defaultGasUsage[TxTypeBridge],
+			exec: new(big.Int).Mul(new(big.Int).SetUint64(defaultGasUsage[TxTypeBridge]), gwei),
+			l1:   new(big.Int).Mul(big.NewInt(50000), gwei),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			manager := NewMultiChainManager()
+			rpc := addFakeChain(t, manager, &ChainConfig{ChainID: 10, Name: "Test", NativeSymbol: "ETH", NativeDecimals: 18, L1FeeModel: c.model})
+			rpc.handle("eth_gasPrice", func([]json.RawMessage) (interface{}, error) {
+				return (*hexutil.Big)(gwei), nil
+			})
+			rpc.handle("eth_estimateGas", func([]json.RawMessage) (interface{}, error) {
+				return hexutil.Uint64(c.estimate), nil
+			})
+			rpc.handleCall(opGasPriceOracleAddress, func([]byte) ([]byte, error) {
+				return opGasPriceOracleABI.Methods["getL1Fee"].Outputs.Pack(c.l1Fee)
+			})
+			rpc.handleCall(arbNodeInterfaceAddress, func([]byte) ([]byte, error) {
+				return arbNodeInterfaceABI.Methods["gasEstimateComponents"].Outputs.Pack(c.estimate, c.l1Gas, c.l2Base, big.NewInt(0))
+			})
+
+			gas := NewGasOptimizer(manager, fixedPrice{big.NewInt(2000e8)})
+			if c.call {
+				to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+				gas.SetRepresentativeCall(10, c.txType, ethereum.CallMsg{To: &to, Data: []byte{1, 2, 3, 4}})
+			}
+
+			cost, err := gas.EstimateTransactionCost(context.Background(), 10, c.txType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cost.GasUsage != c.gas {
+				t.Errorf("gas %d, expected %d", cost.GasUsage, c.gas)
+			}
+			if cost.ExecutionFee.Cmp(c.exec) != 0 {
+				t.Errorf("execution fee %s, expected %s", cost.ExecutionFee, c.exec)
+			}
+			if cost.L1DataFee.Cmp(c.l1) != 0 {
+				t.Errorf("L1 data fee %s, expected %s", cost.L1DataFee, c.l1)
+			}
+			if total := new(big.Int).Add(c.exec, c.l1); cost.TotalFee.Cmp(total) != 0 {
+				t.Errorf("total fee %s, expected %s", cost.TotalFee, total)
+			}
+		})
+	}
+}
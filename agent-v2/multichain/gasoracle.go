@@ -0,0 +1,190 @@
+package multichain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"agent/state"
+)
+
+// minOracleSamples is the history needed before percentiles are considered meaningful
+const minOracleSamples = 10
+
+const gasSampleBucket = "gas_samples"
+
+// GasSample is one observation of a chain's fee market
+type GasSample struct {
+	Timestamp time.Time `json:"timestamp"`
+	BaseFee   *big.Int  `json:"baseFee"` // zero on chains without EIP-1559
+	TipCap    *big.Int  `json:"tipCap"`
+}
+
+// EffectivePrice is the price per gas a transaction would pay at this sample
+func (s *GasSample) EffectivePrice() *big.Int {
+	return new(big.Int).Add(s.BaseFee, s.TipCap)
+}
+
+// GasOracle records fee history per chain and computes percentiles over a sliding
+// window. Samples are persisted, so a restart keeps the baseline.
+type GasOracle struct {
+	manager *MultiChainManager
+	store   *state.Store
+	window  time.Duration
+	samples map[uint64][]*GasSample
+	mu      sync.RWMutex
+}
+
+func NewGasOracle(manager *MultiChainManager, store *state.Store, window time.Duration) (*GasOracle, error) {
+	o := &GasOracle{
+		manager: manager,
+		store:   store,
+		window:  window,
+		samples: make(map[uint64][]*GasSample),
+	}
+
+	keys, err := store.Keys(gasSampleBucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gas samples: %v", err)
+	}
+	cutoff := time.Now().Add(-window)
+	for _, key := range keys {
+		chainID, err := strconv.ParseUint(strings.SplitN(key, "-", 2)[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		sample := &GasSample{}
+		if _, err := store.Get(gasSampleBucket, key, sample); err != nil {
+			return nil, err
+		}
+		if sample.Timestamp.Before(cutoff) {
+			store.Delete(gasSampleBucket, key)
+			continue
+		}
+		// Keys sort by chain, then time, so each history stays oldest first
+		o.samples[chainID] = append(o.samples[chainID], sample)
+	}
+
+	return o, nil
+}
+
+// Sample records the current base fee and tip on every connected chain
+func (o *GasOracle) Sample(ctx context.Context) {
+	for chainID := range o.manager.clients {
+		if _, err := o.SampleChain(ctx, chainID); err != nil {
+			log.Printf("⚠️  Failed to sample gas on chain %d: %v", chainID, err)
+		}
+	}
+}
+
+// SampleChain records the current base fee and tip on one chain
+func (o *GasOracle) SampleChain(ctx context.Context, chainID uint64) (*GasSample, error) {
+	client, err := o.manager.GetClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %v", err)
+	}
+
+	sample := &GasSample{Timestamp: time.Now()}
+	if header.BaseFee != nil {
+		sample.BaseFee = header.BaseFee
+		sample.TipCap, err = client.SuggestGasTipCap(ctx)
+		if err != nil {
+			sample.TipCap = big.NewInt(0)
+		}
+	} else {
+		// Legacy fee market: the whole gas price is recorded as the base fee
+		sample.BaseFee, err = client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price: %v", err)
+		}
+		sample.TipCap = big.NewInt(0)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err := o.store.Put(gasSampleBucket, gasSampleKey(chainID, sample.Timestamp), sample); err != nil {
+		log.Printf("⚠️  Failed to persist gas sample for chain %d: %v", chainID, err)
+	}
+
+	cutoff := sample.Timestamp.Add(-o.window)
+	history := o.samples[chainID]
+	kept := 0
+	for kept < len(history) && history[kept].Timestamp.Before(cutoff) {
+		if err := o.store.Delete(gasSampleBucket, gasSampleKey(chainID, history[kept].Timestamp)); err != nil {
+			log.Printf("⚠️  Failed to delete expired gas sample for chain %d: %v", chainID, err)
+		}
+		kept++
+	}
+	o.samples[chainID] = append(history[kept:], sample)
+
+	return sample, nil
+}
+
+func gasSampleKey(chainID uint64, ts time.Time) string {
+	return fmt.Sprintf("%d-%s", chainID, timeKey(ts))
+}
+
+// History returns the samples of a chain within the window, oldest first
+func (o *GasOracle) History(chainID uint64) []*GasSample {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return append([]*GasSample{}, o.samples[chainID]...)
+}
+
+// Percentile returns the effective gas price at percentile p (0-100) of the window
+func (o *GasOracle) Percentile(chainID uint64, p float64) (*big.Int, error) {
+	prices, err := o.sortedPrices(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := int(p / 100 * float64(len(prices)-1))
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(prices) {
+		index = len(prices) - 1
+	}
+	return prices[index], nil
+}
+
+// CurrentGasPercentile returns where the latest sample of a chain ranks within
+// the window, from 0 (cheapest seen) to 100 (most expensive seen)
+func (o *GasOracle) CurrentGasPercentile(ctx context.Context, chainID uint64) (float64, error) {
+	prices, err := o.sortedPrices(chainID)
+	if err != nil {
+		return 0, err
+	}
+
+	history := o.History(chainID)
+	current := history[len(history)-1].EffectivePrice()
+
+	below := sort.Search(len(prices), func(i int) bool { return prices[i].Cmp(current) > 0 })
+	return float64(below-1) / float64(len(prices)-1) * 100, nil
+}
+
+func (o *GasOracle) sortedPrices(chainID uint64) ([]*big.Int, error) {
+	history := o.History(chainID)
+	if len(history) < minOracleSamples {
+		return nil, fmt.Errorf("only %d gas samples for chain %d, need %d", len(history), chainID, minOracleSamples)
+	}
+
+	prices := make([]*big.Int, len(history))
+	for i, sample := range history {
+		prices[i] = sample.EffectivePrice()
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Cmp(prices[j]) < 0 })
+	return prices, nil
+}
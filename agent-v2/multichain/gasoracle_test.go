@@ -0,0 +1,109 @@
+package multichain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"agent/state"
+)
+
+func TestGasOraclePercentiles(t *testing.T) {
+	cases := []struct {
+		name      string
+		prices    []int64 // effective gas price of each sample, oldest first
+		expired   int     // samples at the start that fall outside the window
+		median    int64
+		current   float64 // percentile of the last sample
+		notEnough bool
+	}{
+		{name: "too few samples", prices: []int64{1, 2, 3}, notEnough: true},
+		{
+			name:    "latest is the cheapest",
+			prices:  []int64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+			median:  5,
+			current: 0,
+		},
+		{
+			name:    "latest is the most expensive",
+			prices:  []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+			median:  6,
+			current: 100,
+		},
+		{
+			name:    "ties rank at their highest position",
+			prices:  []int64{5, 5, 5, 5, 5, 1, 1, 1, 1, 1, 5},
+			median:  5,
+			current: 100,
+		},
+		{
+			name:    "expired samples are dropped",
+			prices:  []int64{100, 100, 100, 100, 100, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+			expired: 5,
+			median:  6,
+			current: 100,
+		},
+		{
+			name:      "expired samples do not count toward the minimum",
+			prices:    []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			expired:   1,
+			notEnough: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// Samples are loaded from the store, as after a restart
+			store, err := state.Open(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			window := time.Hour
+			now := time.Now()
+			for i, price := range c.prices {
+				ts := now.Add(-window / 2).Add(time.Duration(i) * time.Second)
+				if i < c.expired {
+					ts = now.Add(-2 * window).Add(time.Duration(i) * time.Second)
+				}
+				sample := &GasSample{Timestamp: ts, BaseFee: big.NewInt(price - 1), TipCap: big.NewInt(1)}
+				if err := store.Put(gasSampleBucket, gasSampleKey(10, ts), sample); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			oracle, err := NewGasOracle(NewMultiChainManager(), store, window)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if history := oracle.History(10); len(history) != len(c.prices)-c.expired {
+				t.Errorf("%d samples loaded, expected %d", len(history), len(c.prices)-c.expired)
+			}
+			if keys, _ := store.Keys(gasSampleBucket); len(keys) != len(c.prices)-c.expired {
+				t.Errorf("%d samples stored, expected expired ones to be deleted", len(keys))
+			}
+
+			median, err := oracle.Percentile(10, 50)
+			if c.notEnough {
+				if err == nil {
+					t.Error("expected an error without enough samples")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if median.Int64() != c.median {
+				t.Errorf("median %s, expected %d", median, c.median)
+			}
+
+			current, err := oracle.CurrentGasPercentile(context.Background(), 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if current != c.current {
+				t.Errorf("current at p%.1f, expected p%.1f", current, c.current)
+			}
+		})
+	}
+}
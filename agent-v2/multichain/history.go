@@ -0,0 +1,523 @@
+package multichain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"agent/state"
+	"agent/strategies"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	snapshotBucket = "portfolio_snapshots"
+	tradeBucket    = "trades"
+	positionBucket = "positions"
+	cashFlowBucket = "cash_flows"
+)
+
+// PortfolioSnapshot is the valued state of the portfolio at a point in time
+type PortfolioSnapshot struct {
+	Timestamp   time.Time           `json:"timestamp"`
+	TotalValue  *big.Int            `json:"totalValue"`
+	NetFlow     *big.Int            `json:"netFlow"` // external deposits minus withdrawals since the previous snapshot
+	ChainValues map[uint64]*big.Int `json:"chainValues"`
+	Holdings    []*SnapshotHolding  `json:"holdings"`
+}
+
+type SnapshotHolding struct {
+	ChainID  uint64         `json:"chainId"`
+	Token    common.Address `json:"token"`
+	Symbol   string         `json:"symbol"`
+	Decimals uint8          `json:"decimals"`
+	Balance  *big.Int       `json:"balance"`
+	PriceUSD *big.Int       `json:"priceUsd,omitempty"`
+	ValueUSD *big.Int       `json:"valueUsd"`
+}
+
+// Position is the average-cost basis of a token acquired through trades
+type Position struct {
+	ChainID     uint64         `json:"chainId"`
+	Token       common.Address `json:"token"`
+	Quantity    *big.Int       `json:"quantity"`
+	CostUSD     *big.Int       `json:"costUsd"`
+	RealizedPnL *big.Int       `json:"realizedPnl"`
+}
+
+// TokenPnL is the profit and loss of a single position
+type TokenPnL struct {
+	ChainID       uint64         `json:"chainId"`
+	Token         common.Address `json:"token"`
+	Symbol        string         `json:"symbol"`
+	Quantity      *big.Int       `json:"quantity"`
+	CostUSD       *big.Int       `json:"costUsd"`
+	ValueUSD      *big.Int       `json:"valueUsd"`
+	RealizedPnL   *big.Int       `json:"realizedPnl"`
+	UnrealizedPnL *big.Int       `json:"unrealizedPnl"`
+}
+
+// PerformanceReport summarizes portfolio performance over a time range.
+// All USD amounts are scaled by USDDecimals; returns are fractions (0.05 = 5%).
+type PerformanceReport struct {
+	From               time.Time   `json:"from"`
+	To                 time.Time   `json:"to"`
+	StartValue         *big.Int    `json:"startValue"`
+	EndValue           *big.Int    `json:"endValue"`
+	Change             *big.Int    `json:"change"`
+	ChangePercent      float64     `json:"changePercent"`
+	TimeWeightedReturn float64     `json:"timeWeightedReturn"`
+	RealizedPnL        *big.Int    `json:"realizedPnl"`
+	UnrealizedPnL      *big.Int    `json:"unrealizedPnl"`
+	Tokens             []*TokenPnL `json:"tokens"`
+}
+
+// TotalChange formats the time-weighted return the way the dashboard displays it, e.g. "+12.5%"
+func (r *PerformanceReport) TotalChange() string {
+	return fmt.Sprintf("%+.1f%%", r.TimeWeightedReturn*100)
+}
+
+// recordedTrade is a trade as persisted, with its USD value at execution time
+type recordedTrade struct {
+	*strategies.Trade
+	ValueUSD *big.Int `json:"valueUsd"`
+}
+
+// PortfolioHistory records periodic portfolio snapshots and the cost basis of
+// executed trades in the state store
+type PortfolioHistory struct {
+	portfolio    *CrossChainPortfolio
+	store        *state.Store
+	interval     time.Duration
+	lastSnapshot time.Time
+	pendingFlow  *big.Int
+	transfers    []*AccountEvent // indexed transfers not yet classified as cash flows
+	tradeTxs     map[common.Hash]bool
+	positions    map[string]*Position
+	mu           sync.Mutex
+}
+
+func NewPortfolioHistory(portfolio *CrossChainPortfolio, store *state.Store, interval time.Duration) (*PortfolioHistory, error) {
+	h := &PortfolioHistory{
+		portfolio:   portfolio,
+		store:       store,
+		interval:    interval,
+		pendingFlow: big.NewInt(0),
+		tradeTxs:    make(map[common.Hash]bool),
+		positions:   make(map[string]*Position),
+	}
+
+	trades, err := store.Keys(tradeBucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trades: %v", err)
+	}
+	for _, key := range trades {
+		if parts := strings.SplitN(key, "-", 2); len(parts) == 2 {
+			h.tradeTxs[common.HexToHash(parts[1])] = true
+		}
+	}
+
+	keys, err := store.Keys(positionBucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load positions: %v", err)
+	}
+	for _, key := range keys {
+		position := &Position{}
+		if _, err := store.Get(positionBucket, key, position); err != nil {
+			return nil, err
+		}
+		h.positions[key] = position
+	}
+
+	snapshots, err := store.Keys(snapshotBucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snapshots: %v", err)
+	}
+	if len(snapshots) > 0 {
+		if ts, err := parseTimeKey(snapshots[len(snapshots)-1]); err == nil {
+			h.lastSnapshot = ts
+		}
+	}
+
+	return h, nil
+}
+
+// MaybeSnapshot takes a snapshot if the snapshot interval has elapsed
+func (h *PortfolioHistory) MaybeSnapshot(ctx context.Context) error {
+	h.mu.Lock()
+	due := time.Since(h.lastSnapshot) >= h.interval
+	h.mu.Unlock()
+
+	if !due {
+		return nil
+	}
+	_, err := h.Snapshot(ctx)
+	return err
+}
+
+// Snapshot records the portfolio's current valuation
+func (h *PortfolioHistory) Snapshot(ctx context.Context) (*PortfolioSnapshot, error) {
+	breakdown := h.portfolio.GetBreakdown()
+	if len(breakdown) == 0 {
+		return nil, fmt.Errorf("portfolio has not been updated yet")
+	}
+
+	flow := h.settleTransfers(ctx)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.pendingFlow.Add(h.pendingFlow, flow)
+	snapshot := &PortfolioSnapshot{
+		Timestamp:   time.Now(),
+		TotalValue:  h.portfolio.GetTotalValue(),
+		NetFlow:     new(big.Int).Set(h.pendingFlow),
+		ChainValues: make(map[uint64]*big.Int),
+	}
+	for _, chain := range breakdown {
+		snapshot.ChainValues[chain.ChainID] = chain.ValueUSD
+		for _, holding := range chain.Holdings {
+			snapshot.Holdings = append(snapshot.Holdings, &SnapshotHolding{
+				ChainID:  chain.ChainID,
+				Token:    holding.Token.Address,
+				Symbol:   holding.Token.Symbol,
+				Decimals: holding.Token.Decimals,
+				Balance:  holding.Balance,
+				PriceUSD: holding.PriceUSD,
+				ValueUSD: holding.ValueUSD,
+			})
+		}
+	}
+
+	if err := h.store.Put(snapshotBucket, timeKey(snapshot.Timestamp), snapshot); err != nil {
+		return nil, fmt.Errorf("failed to store snapshot: %v", err)
+	}
+
+	h.lastSnapshot = snapshot.Timestamp
+	h.pendingFlow = big.NewInt(0)
+	log.Printf("📸 Portfolio snapshot: $%s", FormatUSD(snapshot.TotalValue))
+	return snapshot, nil
+}
+
+// RecordCashFlow registers an external deposit (positive) or withdrawal
+// (negative) in USD so that it is excluded from the time-weighted return
+func (h *PortfolioHistory) RecordCashFlow(valueUSD *big.Int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pendingFlow.Add(h.pendingFlow, valueUSD)
+}
+
+// RecordTransfers queues indexed transfers in and out of the smart accounts.
+// They are classified when the next snapshot is taken, once the trades of the
+// same transactions have been recorded. It implements TransferRecorder.
+func (h *PortfolioHistory) RecordTransfers(events []*AccountEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, event := range events {
+		if event.Type == EventTransferIn || event.Type == EventTransferOut {
+			h.transfers = append(h.transfers, event)
+		}
+	}
+}
+
+// settleTransfers values the queued transfers that are deposits or
+// withdrawals: those between an account and an address outside the portfolio,
+// in transactions that move tokens in only one direction and were not
+// recorded as trades. Each transfer is counted once, even if it is indexed
+// again after a reorg.
+func (h *PortfolioHistory) settleTransfers(ctx context.Context) *big.Int {
+	h.mu.Lock()
+	transfers := h.transfers
+	h.transfers = nil
+	trades := make(map[common.Hash]bool, len(h.tradeTxs))
+	for hash := range h.tradeTxs {
+		trades[hash] = true
+	}
+	h.mu.Unlock()
+
+	directions := make(map[common.Hash]map[string]bool)
+	for _, transfer := range transfers {
+		if directions[transfer.TxHash] == nil {
+			directions[transfer.TxHash] = make(map[string]bool)
+		}
+		directions[transfer.TxHash][transfer.Type] = true
+	}
+
+	flow := big.NewInt(0)
+	for _, transfer := range transfers {
+		if len(directions[transfer.TxHash]) > 1 || trades[transfer.TxHash] ||
+			h.portfolio.tracksAccount(transfer.ChainID, transfer.Counterparty) {
+			continue
+		}
+		token, known := h.portfolio.tokenInfo(transfer.ChainID, transfer.Token)
+		if !known || token.Discovered {
+			continue
+		}
+
+		key := fmt.Sprintf("%d-%s-%d", transfer.ChainID, transfer.TxHash.Hex(), transfer.LogIndex)
+		var counted *big.Int
+		if found, err := h.store.Get(cashFlowBucket, key, &counted); err != nil || found {
+			continue
+		}
+		price, err := h.portfolio.prices.GetUSDPrice(ctx, transfer.ChainID, token)
+		if err != nil {
+			log.Printf("⚠️  Failed to price %s transfer %s: %v", token.Symbol, transfer.TxHash.Hex(), err)
+			continue
+		}
+
+		value := ValueInUSD(transfer.Amount, token.Decimals, price)
+		if transfer.Type == EventTransferOut {
+			value.Neg(value)
+		}
+		if err := h.store.Put(cashFlowBucket, key, value); err != nil {
+			log.Printf("⚠️  Failed to store cash flow: %v", err)
+			continue
+		}
+		flow.Add(flow, value)
+		log.Printf("💵 Cash flow of $%s on chain %d in %s", FormatUSD(value), transfer.ChainID, transfer.TxHash.Hex())
+	}
+	return flow
+}
+
+// RecordTrade updates average-cost positions for both legs of a trade and
+// persists the trade. It implements strategies.TradeRecorder.
+func (h *PortfolioHistory) RecordTrade(ctx context.Context, trade *strategies.Trade) error {
+	valueUSD, err := h.tradeValue(ctx, trade)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Disposal of TokenIn realizes PnL against its average cost. Amounts beyond
+	// the tracked quantity were acquired outside of trades and have no basis.
+	sold := h.position(trade.ChainID, trade.TokenIn)
+	if sold.Quantity.Sign() > 0 {
+		amount := minBig(trade.AmountIn, sold.Quantity)
+		cost := mulDiv(sold.CostUSD, amount, sold.Quantity)
+		proceeds := mulDiv(valueUSD, amount, trade.AmountIn)
+
+		sold.RealizedPnL.Add(sold.RealizedPnL, new(big.Int).Sub(proceeds, cost))
+		sold.Quantity.Sub(sold.Quantity, amount)
+		sold.CostUSD.Sub(sold.CostUSD, cost)
+	}
+
+	bought := h.position(trade.ChainID, trade.TokenOut)
+	bought.Quantity.Add(bought.Quantity, trade.AmountOut)
+	bought.CostUSD.Add(bought.CostUSD, valueUSD)
+
+	for _, position := range []*Position{sold, bought} {
+		if err := h.store.Put(positionBucket, positionKey(position.ChainID, position.Token), position); err != nil {
+			return fmt.Errorf("failed to store position: %v", err)
+		}
+	}
+
+	key := timeKey(trade.Timestamp) + "-" + trade.TxHash.Hex()
+	if err := h.store.Put(tradeBucket, key, &recordedTrade{Trade: trade, ValueUSD: valueUSD}); err != nil {
+		return fmt.Errorf("failed to store trade: %v", err)
+	}
+	h.tradeTxs[trade.TxHash] = true
+	return nil
+}
+
+// tradeValue prices a trade by its input leg, falling back to its output leg
+func (h *PortfolioHistory) tradeValue(ctx context.Context, trade *strategies.Trade) (*big.Int, error) {
+	legs := []struct {
+		token  common.Address
+		amount *big.Int
+	}{
+		{trade.TokenIn, trade.AmountIn},
+		{trade.TokenOut, trade.AmountOut},
+	}
+
+	for _, leg := range legs {
+		token, known := h.portfolio.tokenInfo(trade.ChainID, leg.token)
+		if !known {
+			continue
+		}
+		price, err := h.portfolio.prices.GetUSDPrice(ctx, trade.ChainID, token)
+		if err != nil {
+			continue
+		}
+		return ValueInUSD(leg.amount, token.Decimals, price), nil
+	}
+	return nil, fmt.Errorf("cannot price trade %s on chain %d", trade.TxHash.Hex(), trade.ChainID)
+}
+
+// position returns the tracked position for a token, creating an empty one if needed
+func (h *PortfolioHistory) position(chainID uint64, token common.Address) *Position {
+	key := positionKey(chainID, token)
+	position, exists := h.positions[key]
+	if !exists {
+		position = &Position{
+			ChainID:     chainID,
+			Token:       token,
+			Quantity:    big.NewInt(0),
+			CostUSD:     big.NewInt(0),
+			RealizedPnL: big.NewInt(0),
+		}
+		h.positions[key] = position
+	}
+	return position
+}
+
+// GetSnapshots returns the snapshots taken within [from, to] in chronological order
+func (h *PortfolioHistory) GetSnapshots(from, to time.Time) ([]*PortfolioSnapshot, error) {
+	keys, err := h.store.Keys(snapshotBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []*PortfolioSnapshot{}
+	for _, key := range keys {
+		ts, err := parseTimeKey(key)
+		if err != nil || ts.Before(from) || ts.After(to) {
+			continue
+		}
+
+		snapshot := &PortfolioSnapshot{}
+		if _, err := h.store.Get(snapshotBucket, key, snapshot); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// GetPerformance computes value change, time-weighted return and per-token PnL over [from, to]
+func (h *PortfolioHistory) GetPerformance(ctx context.Context, from, to time.Time) (*PerformanceReport, error) {
+	snapshots, err := h.GetSnapshots(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("no snapshots between %s and %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	first, last := snapshots[0], snapshots[len(snapshots)-1]
+	report := &PerformanceReport{
+		From:          first.Timestamp,
+		To:            last.Timestamp,
+		StartValue:    first.TotalValue,
+		EndValue:      last.TotalValue,
+		Change:        new(big.Int).Sub(last.TotalValue, first.TotalValue),
+		RealizedPnL:   big.NewInt(0),
+		UnrealizedPnL: big.NewInt(0),
+	}
+	if first.TotalValue.Sign() > 0 {
+		report.ChangePercent, _ = new(big.Rat).SetFrac(report.Change, first.TotalValue).Float64()
+	}
+
+	// Chain sub-period returns, treating each period's net flow as arriving at its end
+	growth := new(big.Rat).SetInt64(1)
+	for i := 1; i < len(snapshots); i++ {
+		previous := snapshots[i-1].TotalValue
+		if previous.Sign() <= 0 {
+			continue
+		}
+		current := snapshots[i].TotalValue
+		if snapshots[i].NetFlow != nil {
+			current = new(big.Int).Sub(current, snapshots[i].NetFlow)
+		}
+		growth.Mul(growth, new(big.Rat).SetFrac(current, previous))
+	}
+	twr, _ := growth.Float64()
+	report.TimeWeightedReturn = twr - 1
+
+	report.Tokens = h.tokenPnL(ctx)
+	for _, token := range report.Tokens {
+		report.RealizedPnL.Add(report.RealizedPnL, token.RealizedPnL)
+		report.UnrealizedPnL.Add(report.UnrealizedPnL, token.UnrealizedPnL)
+	}
+
+	return report, nil
+}
+
+// tokenPnL values every position at current prices
+func (h *PortfolioHistory) tokenPnL(ctx context.Context) []*TokenPnL {
+	h.mu.Lock()
+	positions := make([]*Position, 0, len(h.positions))
+	for _, position := range h.positions {
+		positions = append(positions, &Position{
+			ChainID:     position.ChainID,
+			Token:       position.Token,
+			Quantity:    new(big.Int).Set(position.Quantity),
+			CostUSD:     new(big.Int).Set(position.CostUSD),
+			RealizedPnL: new(big.Int).Set(position.RealizedPnL),
+		})
+	}
+	h.mu.Unlock()
+
+	result := make([]*TokenPnL, 0, len(positions))
+	for _, position := range positions {
+		pnl := &TokenPnL{
+			ChainID:       position.ChainID,
+			Token:         position.Token,
+			Quantity:      position.Quantity,
+			CostUSD:       position.CostUSD,
+			ValueUSD:      big.NewInt(0),
+			RealizedPnL:   position.RealizedPnL,
+			UnrealizedPnL: big.NewInt(0),
+		}
+
+		if token, known := h.portfolio.tokenInfo(position.ChainID, position.Token); known {
+			pnl.Symbol = token.Symbol
+			if position.Quantity.Sign() > 0 {
+				price, err := h.portfolio.prices.GetUSDPrice(ctx, position.ChainID, token)
+				if err != nil {
+					log.Printf("⚠️  Failed to price %s position: %v", token.Symbol, err)
+				} else {
+					pnl.ValueUSD = ValueInUSD(position.Quantity, token.Decimals, price)
+					pnl.UnrealizedPnL = new(big.Int).Sub(pnl.ValueUSD, position.CostUSD)
+				}
+			}
+		}
+		result = append(result, pnl)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ChainID != result[j].ChainID {
+			return result[i].ChainID < result[j].ChainID
+		}
+		return result[i].Token.Hex() < result[j].Token.Hex()
+	})
+	return result
+}
+
+func positionKey(chainID uint64, token common.Address) string {
+	return fmt.Sprintf("%d-%s", chainID, token.Hex())
+}
+
+// timeKey encodes a timestamp so that lexical key order is chronological
+func timeKey(ts time.Time) string {
+	return fmt.Sprintf("%020d", ts.UnixNano())
+}
+
+func parseTimeKey(key string) (time.Time, error) {
+	nanos, err := strconv.ParseInt(strings.SplitN(key, "-", 2)[0], 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, nanos), nil
+}
+
+func minBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return a
+	}
+	return b
+}
+
+// mulDiv returns value * numerator / denominator
+func mulDiv(value, numerator, denominator *big.Int) *big.Int {
+	result := new(big.Int).Mul(value, numerator)
+	return result.Div(result, denominator)
+}
@@ -0,0 +1,448 @@
+package multichain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"agent/contracts"
+	"agent/state"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	accountEventBucket = "account_events"
+	indexerBucket      = "indexer"
+
+	// reorgWindow is how many blocks behind the head the indexer keeps hashes for; older blocks are treated as final
+	reorgWindow = 128
+)
+
+// Event types recorded by the AccountIndexer
+const (
+	EventStrategyCreated  = "StrategyCreated"
+	EventStrategyExecuted = "StrategyExecuted"
+	EventStrategyPaused   = "StrategyPaused"
+	EventChainAdded       = "ChainAdded"
+	EventTransferIn       = "TransferIn"
+	EventTransferOut      = "TransferOut"
+)
+
+var (
+	accountABI = mustParseABI(contracts.SmartAccountV2MetaData.ABI)
+
+	accountEventTopics = []common.Hash{
+		accountABI.Events[EventStrategyCreated].ID,
+		accountABI.Events[EventStrategyExecuted].ID,
+		accountABI.Events[EventStrategyPaused].ID,
+		accountABI.Events[EventChainAdded].ID,
+	}
+)
+
+// AccountEvent is a decoded log emitted by, or transferring tokens to or from, a smart account
+type AccountEvent struct {
+	ChainID     uint64         `json:"chainId"`
+	Account     common.Address `json:"account"`
+	Type        string         `json:"type"`
+	BlockNumber uint64         `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	Timestamp   time.Time      `json:"timestamp"`
+	TxHash      common.Hash    `json:"txHash"`
+	LogIndex    uint           `json:"logIndex"`
+
+	// Strategy events
+	StrategyID   uint64         `json:"strategyId,omitempty"`
+	StrategyType string         `json:"strategyType,omitempty"` // StrategyCreated only
+	Executor     common.Address `json:"executor,omitempty"`     // StrategyExecuted only
+
+	// ChainAdded
+	AddedChainID  uint64         `json:"addedChainId,omitempty"`
+	AddedContract common.Address `json:"addedContract,omitempty"`
+
+	// Transfers
+	Token        common.Address `json:"token,omitempty"`
+	Counterparty common.Address `json:"counterparty,omitempty"`
+	Amount       *big.Int       `json:"amount,omitempty"`
+}
+
+func (e *AccountEvent) key() string {
+	return eventKey(e.ChainID, e.BlockNumber, e.LogIndex)
+}
+
+// eventKey orders events by chain, block and log index when keys are sorted lexically
+func eventKey(chainID, blockNumber uint64, logIndex uint) string {
+	return fmt.Sprintf("%d-%012d-%06d", chainID, blockNumber, logIndex)
+}
+
+func parseEventKey(key string) (chainID, blockNumber uint64, ok bool) {
+	parts := strings.Split(key, "-")
+	if len(parts) != 3 {
+		return 0, 0, false
+	}
+	chainID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	blockNumber, err = strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return chainID, blockNumber, true
+}
+
+// blockRef is a scanned block whose hash is re-checked to detect reorgs
+type blockRef struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// indexerCheckpoint is the indexing progress on one chain
+type indexerCheckpoint struct {
+	Next   uint64     `json:"next"`   // next block to scan
+	Blocks []blockRef `json:"blocks"` // scanned blocks within the reorg window, oldest first
+}
+
+// TransferRecorder receives the transfers in and out of the smart accounts as
+// they are indexed, e.g. to exclude deposits from returns
+type TransferRecorder interface {
+	RecordTransfers(events []*AccountEvent)
+}
+
+// AccountIndexer follows the strategy and chain events of the smart accounts
+// and the ERC-20 transfers in and out of them, and stores them decoded in the
+// state store. Progress is checkpointed per chain; when a scanned block is
+// reorged out, its events are dropped and the chain is rescanned from the fork.
+type AccountIndexer struct {
+	Lookback  uint64           // blocks behind the head a chain without a checkpoint starts at
+	Transfers TransferRecorder // optional
+	manager   *MultiChainManager
+	store     *state.Store
+	accounts  map[uint64]*contracts.SmartAccountClient
+	mu        sync.Mutex
+}
+
+func NewAccountIndexer(manager *MultiChainManager, store *state.Store, accounts map[uint64]*contracts.SmartAccountClient) *AccountIndexer {
+	return &AccountIndexer{
+		Lookback: DefaultDiscoveryLookback,
+		manager:  manager,
+		store:    store,
+		accounts: accounts,
+	}
+}
+
+// Poll indexes new blocks on every chain with a smart account
+func (x *AccountIndexer) Poll(ctx context.Context) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for chainID, account := range x.accounts {
+		client, err := x.manager.GetClient(chainID)
+		if err != nil {
+			continue
+		}
+		if err := x.index(ctx, chainID, client, account); err != nil {
+			log.Printf("⚠️  Indexer on chain %d: %v", chainID, err)
+		}
+	}
+}
+
+func (x *AccountIndexer) index(ctx context.Context, chainID uint64, client *ethclient.Client, account *contracts.SmartAccountClient) error {
+	var checkpoint indexerCheckpoint
+	found, err := x.store.Get(indexerBucket, strconv.FormatUint(chainID, 10), &checkpoint)
+	if err != nil {
+		return err
+	}
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %v", err)
+	}
+	if !found && head > x.Lookback {
+		checkpoint.Next = head - x.Lookback
+	}
+
+	if err := x.handleReorg(ctx, chainID, client, &checkpoint); err != nil {
+		return err
+	}
+
+	for batch := 0; batch < maxDiscoveryBatches && checkpoint.Next <= head; batch++ {
+		to := checkpoint.Next + discoveryBatchBlocks - 1
+		if to > head {
+			to = head
+		}
+
+		events, err := x.scan(ctx, chainID, client, account, checkpoint.Next, to)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := x.store.Put(accountEventBucket, event.key(), event); err != nil {
+				return err
+			}
+			checkpoint.track(blockRef{Number: event.BlockNumber, Hash: event.BlockHash})
+		}
+		if x.Transfers != nil && len(events) > 0 {
+			x.Transfers.RecordTransfers(events)
+		}
+
+		header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(to))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %v", to, err)
+		}
+		checkpoint.track(blockRef{Number: to, Hash: header.Hash()})
+		checkpoint.Next = to + 1
+		checkpoint.prune(head)
+
+		if err := x.store.Put(indexerBucket, strconv.FormatUint(chainID, 10), &checkpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *indexerCheckpoint) track(block blockRef) {
+	if len(c.Blocks) > 0 && c.Blocks[len(c.Blocks)-1].Number >= block.Number {
+		return
+	}
+	c.Blocks = append(c.Blocks, block)
+}
+
+// prune forgets blocks deep enough to be final, always keeping the latest
+func (c *indexerCheckpoint) prune(head uint64) {
+	keep := 0
+	for keep < len(c.Blocks)-1 && c.Blocks[keep].Number+reorgWindow < head {
+		keep++
+	}
+	c.Blocks = c.Blocks[keep:]
+}
+
+// handleReorg compares the latest scanned block with the canonical chain. On a
+// mismatch it walks back to the newest block that still matches, deletes the
+// events indexed after it and rewinds the checkpoint there.
+func (x *AccountIndexer) handleReorg(ctx context.Context, chainID uint64, client *ethclient.Client, checkpoint *indexerCheckpoint) error {
+	if len(checkpoint.Blocks) == 0 {
+		return nil
+	}
+
+	matched := -1
+	for i := len(checkpoint.Blocks) - 1; i >= 0; i-- {
+		block := checkpoint.Blocks[i]
+		header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(block.Number))
+		if errors.Is(err, ethereum.NotFound) {
+			continue // the new chain is shorter
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %v", block.Number, err)
+		}
+		if header.Hash() == block.Hash {
+			matched = i
+			break
+		}
+	}
+	if matched == len(checkpoint.Blocks)-1 {
+		return nil
+	}
+
+	// With no tracked block left on the canonical chain, rescan from the oldest one
+	fork := checkpoint.Blocks[0].Number
+	if matched >= 0 {
+		fork = checkpoint.Blocks[matched].Number + 1
+	} else {
+		log.Printf("🚨 Reorg on chain %d is deeper than the %d tracked blocks", chainID, len(checkpoint.Blocks))
+	}
+
+	if err := x.deleteFrom(chainID, fork); err != nil {
+		return err
+	}
+	checkpoint.Blocks = checkpoint.Blocks[:matched+1]
+	checkpoint.Next = fork
+	log.Printf("⚠️  Reorg on chain %d: rewound indexer to block %d", chainID, fork)
+	return x.store.Put(indexerBucket, strconv.FormatUint(chainID, 10), checkpoint)
+}
+
+// deleteFrom removes the events of a chain from a block onwards
+func (x *AccountIndexer) deleteFrom(chainID, fromBlock uint64) error {
+	keys, err := x.store.Keys(accountEventBucket)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if keyChain, block, ok := parseEventKey(key); ok && keyChain == chainID && block >= fromBlock {
+			if err := x.store.Delete(accountEventBucket, key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// scan fetches and decodes the account's events in a block range, in chain order
+func (x *AccountIndexer) scan(ctx context.Context, chainID uint64, client *ethclient.Client, account *contracts.SmartAccountClient, from, to uint64) ([]*AccountEvent, error) {
+	accountTopic := common.BytesToHash(account.Address.Bytes())
+	queries := []ethereum.FilterQuery{
+		{Topics: [][]common.Hash{{TransferEventTopic}, nil, {accountTopic}}},
+		{Topics: [][]common.Hash{{TransferEventTopic}, {accountTopic}}},
+	}
+	if account.Version == contracts.V2 {
+		queries = append(queries, ethereum.FilterQuery{
+			Addresses: []common.Address{account.Address},
+			Topics:    [][]common.Hash{accountEventTopics},
+		})
+	}
+
+	var logs []types.Log
+	for _, query := range queries {
+		query.FromBlock = new(big.Int).SetUint64(from)
+		query.ToBlock = new(big.Int).SetUint64(to)
+		found, err := client.FilterLogs(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan blocks %d-%d: %v", from, to, err)
+		}
+		logs = append(logs, found...)
+	}
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+
+	timestamps := make(map[uint64]time.Time)
+	events := make([]*AccountEvent, 0, len(logs))
+	for i, entry := range logs {
+		// A self-transfer matches both transfer queries
+		if i > 0 && logs[i-1].BlockNumber == entry.BlockNumber && logs[i-1].Index == entry.Index {
+			continue
+		}
+		event, ok := decodeAccountEvent(account.Address, entry)
+		if !ok {
+			continue
+		}
+		event.ChainID = chainID
+
+		timestamp, exists := timestamps[entry.BlockNumber]
+		if !exists {
+			header, err := client.HeaderByHash(ctx, entry.BlockHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get block %d: %v", entry.BlockNumber, err)
+			}
+			timestamp = time.Unix(int64(header.Time), 0)
+			timestamps[entry.BlockNumber] = timestamp
+		}
+		event.Timestamp = timestamp
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func decodeAccountEvent(account common.Address, entry types.Log) (*AccountEvent, bool) {
+	if len(entry.Topics) == 0 {
+		return nil, false
+	}
+	event := &AccountEvent{
+		Account:     account,
+		BlockNumber: entry.BlockNumber,
+		BlockHash:   entry.BlockHash,
+		TxHash:      entry.TxHash,
+		LogIndex:    entry.Index,
+	}
+
+	if entry.Topics[0] == TransferEventTopic {
+		// ERC-721 transfers share the topic but index the token ID as well
+		if len(entry.Topics) != 3 || len(entry.Data) != 32 {
+			return nil, false
+		}
+		from := common.BytesToAddress(entry.Topics[1].Bytes())
+		to := common.BytesToAddress(entry.Topics[2].Bytes())
+		event.Token = entry.Address
+		event.Amount = new(big.Int).SetBytes(entry.Data)
+		if to == account {
+			event.Type, event.Counterparty = EventTransferIn, from
+		} else {
+			event.Type, event.Counterparty = EventTransferOut, to
+		}
+		return event, true
+	}
+
+	if entry.Address != account || len(entry.Topics) < 2 {
+		return nil, false
+	}
+	abiEvent, err := accountABI.EventByID(entry.Topics[0])
+	if err != nil {
+		return nil, false
+	}
+	event.Type = abiEvent.Name
+	values, err := abiEvent.Inputs.NonIndexed().Unpack(entry.Data)
+	if err != nil {
+		return nil, false
+	}
+	indexed := entry.Topics[1].Big().Uint64()
+
+	switch event.Type {
+	case EventStrategyCreated:
+		event.StrategyID = indexed
+		event.StrategyType, _ = values[0].(string)
+	case EventStrategyExecuted:
+		if len(entry.Topics) < 3 {
+			return nil, false
+		}
+		event.StrategyID = indexed
+		event.Executor = common.BytesToAddress(entry.Topics[2].Bytes())
+	case EventStrategyPaused:
+		event.StrategyID = indexed
+	case EventChainAdded:
+		event.AddedChainID = indexed
+		event.AddedContract, _ = values[0].(common.Address)
+	}
+	return event, true
+}
+
+// Events returns the indexed events of a chain in a block range, inclusive, in chain order
+func (x *AccountIndexer) Events(chainID, fromBlock, toBlock uint64) ([]*AccountEvent, error) {
+	keys, err := x.store.Keys(accountEventBucket)
+	if err != nil {
+		return nil, err
+	}
+	var events []*AccountEvent
+	for _, key := range keys {
+		keyChain, block, ok := parseEventKey(key)
+		if !ok || keyChain != chainID || block < fromBlock || block > toBlock {
+			continue
+		}
+		event := &AccountEvent{}
+		if _, err := x.store.Get(accountEventBucket, key, event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// StrategyEvents returns the lifecycle events of one on-chain strategy in chain order
+func (x *AccountIndexer) StrategyEvents(chainID, strategyID uint64) ([]*AccountEvent, error) {
+	events, err := x.Events(chainID, 0, ^uint64(0))
+	if err != nil {
+		return nil, err
+	}
+	var matching []*AccountEvent
+	for _, event := range events {
+		switch event.Type {
+		case EventStrategyCreated, EventStrategyExecuted, EventStrategyPaused:
+			if event.StrategyID == strategyID {
+				matching = append(matching, event)
+			}
+		}
+	}
+	return matching, nil
+}
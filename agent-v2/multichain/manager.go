@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"sort"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -12,17 +14,33 @@ import (
 
 // ChainConfig represents configuration for a supported blockchain
 type ChainConfig struct {
-	ChainID       uint64
-	Name          string
-	RPC           string
-	DEXAggregator string
-	NativeToken   common.Address
-	IsTestnet     bool
-	BlockTime     uint64 // Average block time in seconds
+	ChainID         uint64
+	Name            string
+	RPC             string
+	DEXAggregator   string
+	NativeToken     common.Address
+	NativeSymbol    string
+	NativeDecimals  uint8
+	NativePriceFeed common.Address // Chainlink native/USD aggregator, zero if none
+	Tokens          []*TokenInfo   // ERC-20 tokens always tracked on this chain
+	L1FeeModel      string         // How an L2 charges for L1 data, see L1FeeOPStack
+	IsTestnet       bool
+	BlockTime       uint64 // Average block time in seconds
+}
+
+// NativeTokenInfo returns the token metadata of the chain's native currency
+func (c *ChainConfig) NativeTokenInfo() *TokenInfo {
+	return &TokenInfo{
+		Address:   c.NativeToken,
+		Symbol:    c.NativeSymbol,
+		Decimals:  c.NativeDecimals,
+		PriceFeed: c.NativePriceFeed,
+	}
 }
 
 // MultiChainManager handles operations across multiple blockchains
 type MultiChainManager struct {
+	Gas     *GasOptimizer // optional; learns gas usage from the transactions executors and batches send
 	chains  map[uint64]*ChainConfig
 	clients map[uint64]*ethclient.Client
 }
@@ -38,67 +56,95 @@ func (m *MultiChainManager) Initialize() error {
 	// Configure supported chains
 	chains := []*ChainConfig{
 		{
-			ChainID:       1,
-			Name:          "Ethereum Mainnet",
-			RPC:           "https://eth.llamarpc.com",
-			DEXAggregator: "https://api.1inch.io/v5.0/1",
-			NativeToken:   common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
-			IsTestnet:     false,
-			BlockTime:     12,
+			ChainID:         1,
+			Name:            "Ethereum Mainnet",
+			RPC:             "https://eth.llamarpc.com",
+			DEXAggregator:   "https://api.1inch.io/v5.0/1",
+			NativeToken:     common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
+			NativeSymbol:    "ETH",
+			NativeDecimals:  18,
+			NativePriceFeed: common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"),
+			Tokens:          BuiltinTokens(1),
+			IsTestnet:       false,
+			BlockTime:       12,
 		},
 		{
-			ChainID:       137,
-			Name:          "Polygon",
-			RPC:           "https://polygon-rpc.com",
-			DEXAggregator: "https://api.1inch.io/v5.0/137",
-			NativeToken:   common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
-			IsTestnet:     false,
-			BlockTime:     2,
+			ChainID:         137,
+			Name:            "Polygon",
+			RPC:             "https://polygon-rpc.com",
+			DEXAggregator:   "https://api.1inch.io/v5.0/137",
+			NativeToken:     common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
+			NativeSymbol:    "MATIC",
+			NativeDecimals:  18,
+			NativePriceFeed: common.HexToAddress("0xAB594600376Ec9fD91F8e885dADF0CE036862dE0"),
+			Tokens:          BuiltinTokens(137),
+			IsTestnet:       false,
+			BlockTime:       2,
 		},
 		{
-			ChainID:       42161,
-			Name:          "Arbitrum One",
-			RPC:           "https://arb1.arbitrum.io/rpc",
-			DEXAggregator: "https://api.1inch.io/v5.0/42161",
-			NativeToken:   common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
-			IsTestnet:     false,
-			BlockTime:     1,
+			ChainID:         42161,
+			Name:            "Arbitrum One",
+			RPC:             "https://arb1.arbitrum.io/rpc",
+			DEXAggregator:   "https://api.1inch.io/v5.0/42161",
+			NativeToken:     common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
+			NativeSymbol:    "ETH",
+			NativeDecimals:  18,
+			NativePriceFeed: common.HexToAddress("0x639Fe6ab55C921f74e7fac1ee960C0B6293ba612"),
+			Tokens:          BuiltinTokens(42161),
+			L1FeeModel:      L1FeeArbitrum,
+			IsTestnet:       false,
+			BlockTime:       1,
 		},
 		{
-			ChainID:       10,
-			Name:          "Optimism",
-			RPC:           "https://mainnet.optimism.io",
-			DEXAggregator: "https://api.1inch.io/v5.0/10",
-			NativeToken:   common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
-			IsTestnet:     false,
-			BlockTime:     2,
+			ChainID:         10,
+			Name:            "Optimism",
+			RPC:             "https://mainnet.optimism.io",
+			DEXAggregator:   "https://api.1inch.io/v5.0/10",
+			NativeToken:     common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
+			NativeSymbol:    "ETH",
+			NativeDecimals:  18,
+			NativePriceFeed: common.HexToAddress("0x13e3Ee699D1909E989722E753853AE30b17e08c5"),
+			Tokens:          BuiltinTokens(10),
+			L1FeeModel:      L1FeeOPStack,
+			IsTestnet:       false,
+			BlockTime:       2,
 		},
 		{
-			ChainID:       8453,
-			Name:          "Base",
-			RPC:           "https://mainnet.base.org",
-			DEXAggregator: "https://api.1inch.io/v5.0/8453",
-			NativeToken:   common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
-			IsTestnet:     false,
-			BlockTime:     2,
+			ChainID:         8453,
+			Name:            "Base",
+			RPC:             "https://mainnet.base.org",
+			DEXAggregator:   "https://api.1inch.io/v5.0/8453",
+			NativeToken:     common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
+			NativeSymbol:    "ETH",
+			NativeDecimals:  18,
+			NativePriceFeed: common.HexToAddress("0x71041dddad3595F9CEd3DcCFBe3D1F4b0a16Bb70"),
+			Tokens:          BuiltinTokens(8453),
+			L1FeeModel:      L1FeeOPStack,
+			IsTestnet:       false,
+			BlockTime:       2,
 		},
 		{
-			ChainID:       195,
-			Name:          "X Layer Testnet",
-			RPC:           "https://testrpc.xlayer.tech",
-			DEXAggregator: "https://www.okx.com/api/v5/dex/aggregator",
-			NativeToken:   common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
-			IsTestnet:     true,
-			BlockTime:     3,
+			ChainID:        195,
+			Name:           "X Layer Testnet",
+			RPC:            "https://testrpc.xlayer.tech",
+			DEXAggregator:  "https://www.okx.com/api/v5/dex/aggregator",
+			NativeToken:    common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
+			NativeSymbol:   "OKB",
+			NativeDecimals: 18,
+			IsTestnet:      true,
+			BlockTime:      3,
 		},
 		{
-			ChainID:       196,
-			Name:          "X Layer Mainnet",
-			RPC:           "https://rpc.xlayer.tech",
-			DEXAggregator: "https://www.okx.com/api/v5/dex/aggregator",
-			NativeToken:   common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
-			IsTestnet:     false,
-			BlockTime:     3,
+			ChainID:        196,
+			Name:           "X Layer Mainnet",
+			RPC:            "https://rpc.xlayer.tech",
+			DEXAggregator:  "https://www.okx.com/api/v5/dex/aggregator",
+			NativeToken:    common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
+			NativeSymbol:   "OKB",
+			NativeDecimals: 18,
+			Tokens:         BuiltinTokens(196),
+			IsTestnet:      false,
+			BlockTime:      3,
 		},
 	}
 
@@ -132,7 +178,7 @@ func (m *MultiChainManager) AddChain(config *ChainConfig) error {
 	}
 
 	if chainID.Uint64() != config.ChainID {
-		return fmt.Errorf("chain ID mismatch for %s: expected %d, got %d", 
+		return fmt.Errorf("chain ID mismatch for %s: expected %d, got %d",
 			config.Name, config.ChainID, chainID.Uint64())
 	}
 
@@ -167,247 +213,430 @@ func (m *MultiChainManager) GetSupportedChains() []*ChainConfig {
 // CrossChainPortfolio represents a user's portfolio across multiple chains
 type CrossChainPortfolio struct {
 	UserAddress common.Address
-	Balances    map[uint64]map[common.Address]*big.Int // chainID -> token -> balance
-	TotalValue  *big.Int
+	Balances    map[uint64]map[common.Address]*big.Int      // chainID -> token -> balance
+	Holdings    map[uint64]map[common.Address]*TokenHolding // chainID -> token -> valued holding
+	ChainValues map[uint64]*big.Int                         // chainID -> USD value
+	TotalValue  *big.Int                                    // USD, scaled by USDDecimals
 	manager     *MultiChainManager
+	prices      PriceSource
+	accounts    map[uint64][]common.Address              // chainID -> accounts tracked besides UserAddress
+	tokens      map[uint64]map[common.Address]*TokenInfo // chainID -> configured and discovered tokens
+	pending     map[uint64]map[common.Address]bool       // chainID -> tokens awaiting metadata, true if discovered
+	discovery   *tokenDiscovery
+	mu          sync.RWMutex
+}
+
+// TokenHolding is a token balance together with its USD valuation
+type TokenHolding struct {
+	Token           *TokenInfo
+	Balance         *big.Int                    // summed over all tracked accounts
+	AccountBalances map[common.Address]*big.Int // per tracked account
+	PriceUSD        *big.Int                    // USD per whole token, scaled by USDDecimals; nil if unpriced
+	ValueUSD        *big.Int                    // scaled by USDDecimals; zero if unpriced
+}
+
+// ChainBreakdown summarizes the portfolio value held on a single chain
+type ChainBreakdown struct {
+	ChainID  uint64
+	Name     string
+	ValueUSD *big.Int
+	Holdings []*TokenHolding
 }
 
-func NewCrossChainPortfolio(userAddress common.Address, manager *MultiChainManager) *CrossChainPortfolio {
+func NewCrossChainPortfolio(userAddress common.Address, manager *MultiChainManager, prices PriceSource) *CrossChainPortfolio {
 	return &CrossChainPortfolio{
 		UserAddress: userAddress,
 		Balances:    make(map[uint64]map[common.Address]*big.Int),
+		Holdings:    make(map[uint64]map[common.Address]*TokenHolding),
+		ChainValues: make(map[uint64]*big.Int),
 		TotalValue:  big.NewInt(0),
 		manager:     manager,
+		prices:      prices,
+		accounts:    make(map[uint64][]common.Address),
+		tokens:      make(map[uint64]map[common.Address]*TokenInfo),
+		pending:     make(map[uint64]map[common.Address]bool),
 	}
 }
 
-func (p *CrossChainPortfolio) UpdateBalances(ctx context.Context) error {
-	log.Printf("🔍 Updating cross-chain portfolio for %s", p.UserAddress.Hex())
-
-	totalValue := big.NewInt(0)
-
-	for chainID, client := range p.manager.clients {
-		chainBalances := make(map[common.Address]*big.Int)
+// TrackAccount adds an account, such as the smart account, whose balances on
+// the chain are tracked alongside UserAddress
+func (p *CrossChainPortfolio) TrackAccount(chainID uint64, account common.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-		// Get native token balance
-		nativeBalance, err := client.BalanceAt(ctx, p.UserAddress, nil)
-		if err != nil {
-			log.Printf("⚠️  Failed to get native balance on chain %d: %v", chainID, err)
-			continue
+	if account == p.UserAddress {
+		return
+	}
+	for _, existing := range p.accounts[chainID] {
+		if existing == account {
+			return
 		}
-
-		chain := p.manager.chains[chainID]
-		chainBalances[chain.NativeToken] = nativeBalance
-
-		// Convert to USD value (simplified - would use price oracles in practice)
-		valueInUSD := new(big.Int).Mul(nativeBalance, big.NewInt(2000)) // Mock $2000 per ETH
-		totalValue.Add(totalValue, valueInUSD)
-
-		p.Balances[chainID] = chainBalances
-
-		log.Printf("📊 Chain %s: %s ETH", 
-			chain.Name, 
-			nativeBalance.String())
 	}
+	p.accounts[chainID] = append(p.accounts[chainID], account)
+}
 
-	p.TotalValue = totalValue
-	log.Printf("💰 Total portfolio value: $%s", totalValue.String())
-
-	return nil
+// TrackToken adds an ERC-20 token to the chain's tracked list. Its symbol and
+// decimals are read on-chain during the next update. Tracked tokens are the
+// allowlist: a discovered token is only valued once it is tracked.
+func (p *CrossChainPortfolio) TrackToken(chainID uint64, token common.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if known, exists := p.tokens[chainID][token]; exists {
+		known.Discovered = false
+		return
+	}
+	p.trackTokenLocked(chainID, token, false)
 }
 
-func (p *CrossChainPortfolio) GetBalanceOnChain(chainID uint64, token common.Address) *big.Int {
-	if chainBalances, exists := p.Balances[chainID]; exists {
-		if balance, exists := chainBalances[token]; exists {
-			return balance
+// trackTokenLocked queues a token for metadata resolution. Discovered tokens
+// can be sent by anyone, so they are shown but not priced by symbol.
+func (p *CrossChainPortfolio) trackTokenLocked(chainID uint64, token common.Address, discovered bool) {
+	if _, known := p.tokens[chainID][token]; known {
+		return
+	}
+	if chain, exists := p.manager.chains[chainID]; exists {
+		if token == chain.NativeToken {
+			return
+		}
+		for _, builtin := range chain.Tokens {
+			if builtin.Address == token {
+				return
+			}
 		}
 	}
-	return big.NewInt(0)
+	if p.pending[chainID] == nil {
+		p.pending[chainID] = make(map[common.Address]bool)
+	}
+	if fromLogs, queued := p.pending[chainID][token]; queued && !fromLogs {
+		return
+	}
+	p.pending[chainID][token] = discovered
 }
 
-func (p *CrossChainPortfolio) GetTotalBalance(token common.Address) *big.Int {
-	total := big.NewInt(0)
-	for _, chainBalances := range p.Balances {
-		if balance, exists := chainBalances[token]; exists {
-			total.Add(total, balance)
+// trackedAccounts returns UserAddress followed by the chain's extra accounts
+func (p *CrossChainPortfolio) trackedAccounts(chainID uint64) []common.Address {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]common.Address{p.UserAddress}, p.accounts[chainID]...)
+}
+
+// tracksAccount reports whether address is one of the chain's tracked accounts
+func (p *CrossChainPortfolio) tracksAccount(chainID uint64, address common.Address) bool {
+	for _, account := range p.trackedAccounts(chainID) {
+		if account == address {
+			return true
 		}
 	}
-	return total
+	return false
 }
 
-// CrossChainStrategy represents a trading strategy that operates across multiple chains
-type CrossChainStrategy struct {
-	ID          uint64
-	Name        string
-	ChainIDs    []uint64
-	Active      bool
-	manager     *MultiChainManager
-	portfolio   *CrossChainPortfolio
+// trackedTokens returns the chain's built-in, configured and discovered ERC-20 tokens
+func (p *CrossChainPortfolio) trackedTokens(chain *ChainConfig) []*TokenInfo {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	tokens := append([]*TokenInfo{}, chain.Tokens...)
+	for _, token := range p.tokens[chain.ChainID] {
+		tokens = append(tokens, token)
+	}
+	return tokens
 }
 
-func NewCrossChainStrategy(
-	id uint64,
-	name string,
-	chainIDs []uint64,
-	manager *MultiChainManager,
-	portfolio *CrossChainPortfolio,
-) *CrossChainStrategy {
-	return &CrossChainStrategy{
-		ID:        id,
-		Name:      name,
-		ChainIDs:  chainIDs,
-		Active:    true,
-		manager:   manager,
-		portfolio: portfolio,
+// tokenInfo returns metadata for a native, built-in or tracked token on a chain
+func (p *CrossChainPortfolio) tokenInfo(chainID uint64, address common.Address) (*TokenInfo, bool) {
+	chain, exists := p.manager.chains[chainID]
+	if !exists {
+		return nil, false
+	}
+	if address == chain.NativeToken {
+		return chain.NativeTokenInfo(), true
+	}
+	for _, token := range chain.Tokens {
+		if token.Address == address {
+			return token, true
+		}
 	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	token, exists := p.tokens[chainID][address]
+	return token, exists
 }
 
-func (s *CrossChainStrategy) Execute(ctx context.Context) error {
-	log.Printf("🌐 Executing cross-chain strategy: %s", s.Name)
+// resolvePendingTokens fetches metadata for tokens added since the last update
+func (p *CrossChainPortfolio) resolvePendingTokens(ctx context.Context, chainID uint64) {
+	p.mu.RLock()
+	addresses := make([]common.Address, 0, len(p.pending[chainID]))
+	discovered := make(map[common.Address]bool, len(p.pending[chainID]))
+	for address, fromLogs := range p.pending[chainID] {
+		addresses = append(addresses, address)
+		discovered[address] = fromLogs
+	}
+	p.mu.RUnlock()
 
-	// Update portfolio balances across all chains
-	err := s.portfolio.UpdateBalances(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to update portfolio: %v", err)
+	if len(addresses) == 0 {
+		return
 	}
 
-	// Find arbitrage opportunities
-	opportunities, err := s.FindArbitrageOpportunities(ctx)
+	resolved, err := p.manager.ResolveTokens(ctx, chainID, addresses)
 	if err != nil {
-		return fmt.Errorf("failed to find opportunities: %v", err)
+		log.Printf("⚠️  Failed to resolve token metadata on chain %d: %v", chainID, err)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.tokens[chainID] == nil {
+		p.tokens[chainID] = make(map[common.Address]*TokenInfo)
+	}
+	for _, token := range resolved {
+		// A token tracked while its metadata was resolving is no longer pending as discovered
+		token.Discovered = discovered[token.Address] && p.pending[chainID][token.Address]
+		p.tokens[chainID][token.Address] = token
+		if token.Discovered {
+			log.Printf("🪙 Discovered %s (%s) on chain %d; add it to TRACKED_TOKENS to value it", token.Symbol, token.Address.Hex(), chainID)
+			continue
+		}
+		log.Printf("🪙 Tracking %s (%s) on chain %d", token.Symbol, token.Address.Hex(), chainID)
 	}
+	// Tokens that failed to resolve are not ERC-20s and are dropped
+	delete(p.pending, chainID)
+}
 
-	// Execute profitable trades
-	for _, opportunity := range opportunities {
-		log.Printf("💡 Found arbitrage: %s -> %s (profit: %s%%)", 
-			s.manager.chains[opportunity.ChainA].Name,
-			s.manager.chains[opportunity.ChainB].Name,
-			opportunity.ProfitPercentage.String())
+func (p *CrossChainPortfolio) UpdateBalances(ctx context.Context) error {
+	log.Printf("🔍 Updating cross-chain portfolio for %s", p.UserAddress.Hex())
 
-		// Execute the arbitrage (simplified)
-		err := s.ExecuteArbitrage(ctx, opportunity)
+	balances := make(map[uint64]map[common.Address]*big.Int)
+	holdings := make(map[uint64]map[common.Address]*TokenHolding)
+	chainValues := make(map[uint64]*big.Int)
+	totalValue := big.NewInt(0)
+
+	for chainID, client := range p.manager.clients {
+		chain := p.manager.chains[chainID]
+
+		if p.discovery != nil {
+			p.discoverTokens(ctx, chainID, client)
+		}
+		p.resolvePendingTokens(ctx, chainID)
+
+		chainHoldings, err := p.fetchHoldings(ctx, chain)
 		if err != nil {
-			log.Printf("❌ Failed to execute arbitrage: %v", err)
+			log.Printf("⚠️  Failed to get balances on chain %d: %v", chainID, err)
+			continue
+		}
+
+		chainBalances := make(map[common.Address]*big.Int)
+		chainValue := big.NewInt(0)
+		for address, holding := range chainHoldings {
+			p.valueHolding(ctx, chainID, holding)
+			chainBalances[address] = holding.Balance
+			chainValue.Add(chainValue, holding.ValueUSD)
 		}
+
+		balances[chainID] = chainBalances
+		holdings[chainID] = chainHoldings
+		chainValues[chainID] = chainValue
+		totalValue.Add(totalValue, chainValue)
+
+		log.Printf("📊 Chain %s: %s %s, %d tokens ($%s)",
+			chain.Name,
+			formatUnits(chainBalances[chain.NativeToken], chain.NativeDecimals),
+			chain.NativeSymbol,
+			len(chainHoldings)-1,
+			FormatUSD(chainValue))
 	}
 
+	p.mu.Lock()
+	p.Balances = balances
+	p.Holdings = holdings
+	p.ChainValues = chainValues
+	p.TotalValue = totalValue
+	p.mu.Unlock()
+
+	log.Printf("💰 Total portfolio value: $%s", FormatUSD(totalValue))
+
 	return nil
 }
 
-type ArbitrageOpportunity struct {
-	ChainA          uint64
-	ChainB          uint64
-	Token           common.Address
-	PriceA          *big.Int
-	PriceB          *big.Int
-	ProfitPercentage *big.Int
-}
+// fetchHoldings reads native and ERC-20 balances of every tracked account in a
+// single Multicall3 request. Non-zero ERC-20 balances and the native balance
+// are returned. Chains without Multicall3 fall back to native balances only.
+func (p *CrossChainPortfolio) fetchHoldings(ctx context.Context, chain *ChainConfig) (map[common.Address]*TokenHolding, error) {
+	accounts := p.trackedAccounts(chain.ChainID)
+	tokens := p.trackedTokens(chain)
+
+	native := &TokenHolding{
+		Token:           chain.NativeTokenInfo(),
+		Balance:         big.NewInt(0),
+		AccountBalances: make(map[common.Address]*big.Int),
+	}
+	holdings := map[common.Address]*TokenHolding{chain.NativeToken: native}
 
-func (s *CrossChainStrategy) FindArbitrageOpportunities(ctx context.Context) ([]*ArbitrageOpportunity, error) {
-	opportunities := []*ArbitrageOpportunity{}
+	calls := make([]Call, 0, len(accounts)*(len(tokens)+1))
+	for _, account := range accounts {
+		calls = append(calls, nativeBalanceCall(account))
+		for _, token := range tokens {
+			calls = append(calls, balanceOfCall(token.Address, account))
+		}
+	}
+
+	results, err := p.manager.Multicall(ctx, chain.ChainID, calls)
+	if err != nil {
+		log.Printf("⚠️  Multicall unavailable on chain %d, reading native balances only: %v", chain.ChainID, err)
+		return p.fetchNativeHoldings(ctx, chain, accounts, native)
+	}
+
+	i := 0
+	for _, account := range accounts {
+		if balance := decodeUint256(results[i]); balance != nil {
+			native.AccountBalances[account] = balance
+			native.Balance.Add(native.Balance, balance)
+		}
+		i++
 
-	// Compare prices across different chains
-	for i, chainA := range s.ChainIDs {
-		for j, chainB := range s.ChainIDs {
-			if i >= j {
-				continue // Avoid duplicates and self-comparison
+		for _, token := range tokens {
+			balance := decodeUint256(results[i])
+			i++
+			if balance == nil || balance.Sign() == 0 {
+				continue
 			}
 
-			// Mock price comparison - in practice, you'd query DEX APIs
-			priceA := big.NewInt(2000) // $2000 on chain A
-			priceB := big.NewInt(2050) // $2050 on chain B
-
-			// Calculate profit percentage
-			priceDiff := new(big.Int).Sub(priceB, priceA)
-			profitPercentage := new(big.Int).Mul(priceDiff, big.NewInt(100))
-			profitPercentage.Div(profitPercentage, priceA)
-
-			// If profit > 1%, it's an opportunity
-			if profitPercentage.Cmp(big.NewInt(1)) > 0 {
-				opportunity := &ArbitrageOpportunity{
-					ChainA:           chainA,
-					ChainB:           chainB,
-					Token:            s.manager.chains[chainA].NativeToken,
-					PriceA:           priceA,
-					PriceB:           priceB,
-					ProfitPercentage: profitPercentage,
+			holding, exists := holdings[token.Address]
+			if !exists {
+				holding = &TokenHolding{
+					Token:           token,
+					Balance:         big.NewInt(0),
+					AccountBalances: make(map[common.Address]*big.Int),
 				}
-				opportunities = append(opportunities, opportunity)
+				holdings[token.Address] = holding
 			}
+			holding.AccountBalances[account] = balance
+			holding.Balance.Add(holding.Balance, balance)
 		}
 	}
 
-	return opportunities, nil
+	return holdings, nil
 }
 
-func (s *CrossChainStrategy) ExecuteArbitrage(ctx context.Context, opportunity *ArbitrageOpportunity) error {
-	log.Printf("⚡ Executing arbitrage between chains %d and %d", 
-		opportunity.ChainA, opportunity.ChainB)
-
-	// 1. Buy on cheaper chain
-	clientA, err := s.manager.GetClient(opportunity.ChainA)
+func (p *CrossChainPortfolio) fetchNativeHoldings(ctx context.Context, chain *ChainConfig, accounts []common.Address, native *TokenHolding) (map[common.Address]*TokenHolding, error) {
+	client, err := p.manager.GetClient(chain.ChainID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// 2. Bridge to more expensive chain (simplified)
-	log.Printf("🌉 Bridging assets from chain %d to chain %d", 
-		opportunity.ChainA, opportunity.ChainB)
+	for _, account := range accounts {
+		balance, err := client.BalanceAt(ctx, account, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get native balance of %s: %v", account.Hex(), err)
+		}
+		native.AccountBalances[account] = balance
+		native.Balance.Add(native.Balance, balance)
+	}
 
-	// 3. Sell on more expensive chain
-	clientB, err := s.manager.GetClient(opportunity.ChainB)
-	if err != nil {
-		return err
+	return map[common.Address]*TokenHolding{chain.NativeToken: native}, nil
+}
+
+// valueHolding prices a holding, leaving it at zero value if no price is
+// available or the token was discovered rather than allowlisted
+func (p *CrossChainPortfolio) valueHolding(ctx context.Context, chainID uint64, holding *TokenHolding) {
+	holding.ValueUSD = big.NewInt(0)
+	if holding.Balance.Sign() == 0 || holding.Token.Discovered {
+		return
 	}
 
-	// Mock execution
-	_ = clientA
-	_ = clientB
+	token := holding.Token
+	price, err := p.prices.GetUSDPrice(ctx, chainID, token)
+	if err != nil {
+		log.Printf("⚠️  Failed to price %s on chain %d: %v", token.Symbol, chainID, err)
+		return
+	}
 
-	log.Printf("✅ Arbitrage executed successfully")
-	return nil
+	holding.PriceUSD = price
+	holding.ValueUSD = ValueInUSD(holding.Balance, token.Decimals, price)
 }
 
-// GasOptimizer helps choose the best chain for transactions based on gas costs
-type GasOptimizer struct {
-	manager *MultiChainManager
-}
+func (p *CrossChainPortfolio) GetBalanceOnChain(chainID uint64, token common.Address) *big.Int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 
-func NewGasOptimizer(manager *MultiChainManager) *GasOptimizer {
-	return &GasOptimizer{manager: manager}
+	if chainBalances, exists := p.Balances[chainID]; exists {
+		if balance, exists := chainBalances[token]; exists {
+			return balance
+		}
+	}
+	return big.NewInt(0)
 }
 
-func (g *GasOptimizer) GetBestChainForTransaction(ctx context.Context, txType string) (uint64, error) {
-	bestChainID := uint64(0)
-	lowestCost := big.NewInt(0)
+func (p *CrossChainPortfolio) GetTotalBalance(token common.Address) *big.Int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 
-	for chainID, client := range g.manager.clients {
-		gasPrice, err := client.SuggestGasPrice(ctx)
-		if err != nil {
-			log.Printf("⚠️  Failed to get gas price for chain %d: %v", chainID, err)
-			continue
+	total := big.NewInt(0)
+	for _, chainBalances := range p.Balances {
+		if balance, exists := chainBalances[token]; exists {
+			total.Add(total, balance)
 		}
+	}
+	return total
+}
 
-		// Estimate gas cost (simplified)
-		gasLimit := big.NewInt(100000) // Base gas limit
-		gasCost := new(big.Int).Mul(gasPrice, gasLimit)
+// GetTotalValue returns the USD value of the whole portfolio, scaled by USDDecimals
+func (p *CrossChainPortfolio) GetTotalValue() *big.Int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return new(big.Int).Set(p.TotalValue)
+}
 
-		chain := g.manager.chains[chainID]
-		log.Printf("⛽ %s: Gas cost = %s wei", chain.Name, gasCost.String())
+// GetChainValue returns the USD value held on a chain, scaled by USDDecimals
+func (p *CrossChainPortfolio) GetChainValue(chainID uint64) *big.Int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 
-		if bestChainID == 0 || gasCost.Cmp(lowestCost) < 0 {
-			bestChainID = chainID
-			lowestCost = gasCost
-		}
+	if value, exists := p.ChainValues[chainID]; exists {
+		return new(big.Int).Set(value)
 	}
+	return big.NewInt(0)
+}
+
+// GetTokenValue returns the USD value of a token on a chain, scaled by USDDecimals
+func (p *CrossChainPortfolio) GetTokenValue(chainID uint64, token common.Address) *big.Int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 
-	if bestChainID == 0 {
-		return 0, fmt.Errorf("no available chains")
+	if chainHoldings, exists := p.Holdings[chainID]; exists {
+		if holding, exists := chainHoldings[token]; exists {
+			return new(big.Int).Set(holding.ValueUSD)
+		}
 	}
+	return big.NewInt(0)
+}
 
-	chain := g.manager.chains[bestChainID]
-	log.Printf("🏆 Best chain for %s: %s (cost: %s wei)", 
-		txType, chain.Name, lowestCost.String())
+// GetBreakdown returns per-chain and per-token valuations ordered by chain ID and value
+func (p *CrossChainPortfolio) GetBreakdown() []*ChainBreakdown {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	breakdown := make([]*ChainBreakdown, 0, len(p.Holdings))
+	for chainID, chainHoldings := range p.Holdings {
+		entry := &ChainBreakdown{
+			ChainID:  chainID,
+			ValueUSD: new(big.Int).Set(p.ChainValues[chainID]),
+			Holdings: make([]*TokenHolding, 0, len(chainHoldings)),
+		}
+		if chain, exists := p.manager.chains[chainID]; exists {
+			entry.Name = chain.Name
+		}
+		for _, holding := range chainHoldings {
+			entry.Holdings = append(entry.Holdings, holding)
+		}
+		sort.Slice(entry.Holdings, func(i, j int) bool {
+			return entry.Holdings[i].ValueUSD.Cmp(entry.Holdings[j].ValueUSD) > 0
+		})
+		breakdown = append(breakdown, entry)
+	}
 
-	return bestChainID, nil
+	sort.Slice(breakdown, func(i, j int) bool {
+		return breakdown[i].ChainID < breakdown[j].ChainID
+	})
+	return breakdown
 }
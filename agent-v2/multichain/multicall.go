@@ -0,0 +1,180 @@
+package multichain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Multicall3Address is the canonical Multicall3 deployment, identical on every supported chain
+var Multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// maxMulticallBatch bounds the number of calls packed into one aggregate3 request
+const maxMulticallBatch = 200
+
+const multicall3ABI = `[
+	{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"address","name":"addr","type":"address"}],"name":"getEthBalance","outputs":[{"internalType":"uint256","name":"balance","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+const erc20ABI = `[
+	{"inputs":[{"internalType":"address","name":"owner","type":"address"},{"internalType":"address","name":"spender","type":"address"}],"name":"allowance","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"address","name":"spender","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"}],"name":"approve","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"address","name":"account","type":"address"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"symbol","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"from","type":"address"},{"indexed":true,"internalType":"address","name":"to","type":"address"},{"indexed":false,"internalType":"uint256","name":"value","type":"uint256"}],"name":"Transfer","type":"event"}
+]`
+
+var (
+	multicallABI = mustParseABI(multicall3ABI)
+	tokenABI     = mustParseABI(erc20ABI)
+
+	// TransferEventTopic is the topic0 of the ERC-20 Transfer event
+	TransferEventTopic = tokenABI.Events["Transfer"].ID
+)
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("invalid ABI: %v", err))
+	}
+	return parsed
+}
+
+// Call is a single read-only call batched through Multicall3
+type Call struct {
+	Target   common.Address
+	CallData []byte
+}
+
+// CallResult is the outcome of a batched call; failed calls do not abort the batch
+type CallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Multicall executes calls on a chain through Multicall3, splitting large batches
+func (m *MultiChainManager) Multicall(ctx context.Context, chainID uint64, calls []Call) ([]CallResult, error) {
+	client, err := m.GetClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CallResult, 0, len(calls))
+	for start := 0; start < len(calls); start += maxMulticallBatch {
+		end := start + maxMulticallBatch
+		if end > len(calls) {
+			end = len(calls)
+		}
+
+		type call3 struct {
+			Target       common.Address
+			AllowFailure bool
+			CallData     []byte
+		}
+		batch := make([]call3, 0, end-start)
+		for _, call := range calls[start:end] {
+			batch = append(batch, call3{Target: call.Target, AllowFailure: true, CallData: call.CallData})
+		}
+
+		data, err := multicallABI.Pack("aggregate3", batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack multicall: %v", err)
+		}
+
+		output, err := client.CallContract(ctx, ethereum.CallMsg{To: &Multicall3Address, Data: data}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("multicall failed on chain %d: %v", chainID, err)
+		}
+
+		var decoded []CallResult
+		if err := multicallABI.UnpackIntoInterface(&decoded, "aggregate3", output); err != nil {
+			return nil, fmt.Errorf("failed to decode multicall result on chain %d: %v", chainID, err)
+		}
+		if len(decoded) != end-start {
+			return nil, fmt.Errorf("multicall on chain %d returned %d results for %d calls", chainID, len(decoded), end-start)
+		}
+		results = append(results, decoded...)
+	}
+
+	return results, nil
+}
+
+func nativeBalanceCall(account common.Address) Call {
+	data, _ := multicallABI.Pack("getEthBalance", account)
+	return Call{Target: Multicall3Address, CallData: data}
+}
+
+func balanceOfCall(token, account common.Address) Call {
+	data, _ := tokenABI.Pack("balanceOf", account)
+	return Call{Target: token, CallData: data}
+}
+
+// decodeUint256 returns the balance in a call result, or nil if the call failed
+func decodeUint256(result CallResult) *big.Int {
+	if !result.Success || len(result.ReturnData) < 32 {
+		return nil
+	}
+	return new(big.Int).SetBytes(result.ReturnData[:32])
+}
+
+// ResolveTokens reads symbol and decimals for ERC-20 tokens on a chain.
+// Tokens that do not answer both calls are omitted from the result.
+func (m *MultiChainManager) ResolveTokens(ctx context.Context, chainID uint64, addresses []common.Address) ([]*TokenInfo, error) {
+	symbolData, _ := tokenABI.Pack("symbol")
+	decimalsData, _ := tokenABI.Pack("decimals")
+
+	calls := make([]Call, 0, 2*len(addresses))
+	for _, address := range addresses {
+		calls = append(calls,
+			Call{Target: address, CallData: symbolData},
+			Call{Target: address, CallData: decimalsData},
+		)
+	}
+
+	results, err := m.Multicall(ctx, chainID, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*TokenInfo, 0, len(addresses))
+	for i, address := range addresses {
+		symbol, ok := decodeSymbol(results[2*i])
+		if !ok {
+			continue
+		}
+		decimals := decodeUint256(results[2*i+1])
+		if decimals == nil || decimals.Cmp(big.NewInt(255)) > 0 {
+			continue
+		}
+		tokens = append(tokens, &TokenInfo{
+			Address:  address,
+			Symbol:   symbol,
+			Decimals: uint8(decimals.Uint64()),
+		})
+	}
+	return tokens, nil
+}
+
+// decodeSymbol handles both string and legacy bytes32 symbol() return values
+func decodeSymbol(result CallResult) (string, bool) {
+	if !result.Success {
+		return "", false
+	}
+	if out, err := tokenABI.Unpack("symbol", result.ReturnData); err == nil {
+		if symbol, ok := out[0].(string); ok && symbol != "" {
+			return symbol, true
+		}
+	}
+	if len(result.ReturnData) == 32 {
+		symbol := strings.TrimRight(string(result.ReturnData), "\x00")
+		return symbol, symbol != ""
+	}
+	return "", false
+}
@@ -0,0 +1,279 @@
+package multichain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// USDDecimals is the fixed-point precision of every USD amount in this package.
+// It matches the precision of Chainlink's USD feeds.
+const USDDecimals = 8
+
+// PriceSource returns the USD price of one whole token, scaled by USDDecimals
+type PriceSource interface {
+	GetUSDPrice(ctx context.Context, chainID uint64, token *TokenInfo) (*big.Int, error)
+}
+
+// ValueInUSD converts a raw token balance into a USD amount scaled by USDDecimals
+func ValueInUSD(balance *big.Int, decimals uint8, price *big.Int) *big.Int {
+	value := new(big.Int).Mul(balance, price)
+	return value.Div(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+}
+
+// FormatUSD renders a USD amount scaled by USDDecimals with two decimal places
+func FormatUSD(value *big.Int) string {
+	cents := new(big.Int).Div(value, big.NewInt(1000000)) // 10^(USDDecimals-2)
+	sign := ""
+	if cents.Sign() < 0 {
+		sign = "-"
+		cents.Abs(cents)
+	}
+	whole, frac := new(big.Int).QuoRem(cents, big.NewInt(100), new(big.Int))
+	return fmt.Sprintf("%s%s.%02d", sign, whole.String(), frac.Int64())
+}
+
+// rescale converts a fixed-point value from one precision to another
+func rescale(value *big.Int, from, to uint8) *big.Int {
+	result := new(big.Int).Set(value)
+	if from > to {
+		return result.Div(result, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(from-to)), nil))
+	}
+	return result.Mul(result, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(to-from)), nil))
+}
+
+const chainlinkAggregatorABI = `[
+	{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"latestRoundData","outputs":[{"internalType":"uint80","name":"roundId","type":"uint80"},{"internalType":"int256","name":"answer","type":"int256"},{"internalType":"uint256","name":"startedAt","type":"uint256"},{"internalType":"uint256","name":"updatedAt","type":"uint256"},{"internalType":"uint80","name":"answeredInRound","type":"uint80"}],"stateMutability":"view","type":"function"}
+]`
+
+// ChainlinkPriceSource reads prices from Chainlink aggregators on the token's chain
+type ChainlinkPriceSource struct {
+	manager  *MultiChainManager
+	abi      abi.ABI
+	maxAge   time.Duration
+	decimals map[common.Address]uint8
+	mu       sync.Mutex
+}
+
+func NewChainlinkPriceSource(manager *MultiChainManager, maxAge time.Duration) *ChainlinkPriceSource {
+	parsedABI, err := abi.JSON(strings.NewReader(chainlinkAggregatorABI))
+	if err != nil {
+		panic(fmt.Sprintf("invalid chainlink ABI: %v", err))
+	}
+	return &ChainlinkPriceSource{
+		manager:  manager,
+		abi:      parsedABI,
+		maxAge:   maxAge,
+		decimals: make(map[common.Address]uint8),
+	}
+}
+
+func (c *ChainlinkPriceSource) GetUSDPrice(ctx context.Context, chainID uint64, token *TokenInfo) (*big.Int, error) {
+	if token.PriceFeed == (common.Address{}) {
+		return nil, fmt.Errorf("no price feed for %s on chain %d", token.Symbol, chainID)
+	}
+
+	feedDecimals, err := c.feedDecimals(ctx, chainID, token.PriceFeed)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.call(ctx, chainID, token.PriceFeed, "latestRoundData")
+	if err != nil {
+		return nil, err
+	}
+
+	answer := out[1].(*big.Int)
+	updatedAt := out[3].(*big.Int)
+	if answer.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid price from feed %s: %s", token.PriceFeed.Hex(), answer.String())
+	}
+	if c.maxAge > 0 && time.Since(time.Unix(updatedAt.Int64(), 0)) > c.maxAge {
+		return nil, fmt.Errorf("stale price from feed %s: updated at %s", token.PriceFeed.Hex(), updatedAt.String())
+	}
+
+	return rescale(answer, feedDecimals, USDDecimals), nil
+}
+
+func (c *ChainlinkPriceSource) feedDecimals(ctx context.Context, chainID uint64, feed common.Address) (uint8, error) {
+	c.mu.Lock()
+	decimals, cached := c.decimals[feed]
+	c.mu.Unlock()
+	if cached {
+		return decimals, nil
+	}
+
+	out, err := c.call(ctx, chainID, feed, "decimals")
+	if err != nil {
+		return 0, err
+	}
+
+	decimals = out[0].(uint8)
+	c.mu.Lock()
+	c.decimals[feed] = decimals
+	c.mu.Unlock()
+	return decimals, nil
+}
+
+func (c *ChainlinkPriceSource) call(ctx context.Context, chainID uint64, feed common.Address, method string) ([]interface{}, error) {
+	client, err := c.manager.GetClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.abi.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &feed, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s on feed %s: %v", method, feed.Hex(), err)
+	}
+
+	return c.abi.Unpack(method, result)
+}
+
+// OKXPriceSource prices tokens by symbol using the OKX market ticker API.
+// It covers tokens without an on-chain feed, such as OKB on X Layer.
+type OKXPriceSource struct {
+	baseURL  string
+	client   *http.Client
+	cacheTTL time.Duration
+	cache    map[string]cachedPrice
+	mu       sync.Mutex
+}
+
+type cachedPrice struct {
+	price     *big.Int
+	fetchedAt time.Time
+}
+
+func NewOKXPriceSource(cacheTTL time.Duration) *OKXPriceSource {
+	return &OKXPriceSource{
+		baseURL:  "https://www.okx.com/api/v5/market/ticker",
+		client:   &http.Client{Timeout: 10 * time.Second},
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]cachedPrice),
+	}
+}
+
+func (o *OKXPriceSource) GetUSDPrice(ctx context.Context, chainID uint64, token *TokenInfo) (*big.Int, error) {
+	symbol := strings.ToUpper(token.Symbol)
+	if token.PriceSymbol != "" {
+		symbol = strings.ToUpper(token.PriceSymbol)
+	}
+	if symbol == "" {
+		return nil, fmt.Errorf("token %s on chain %d has no symbol", token.Address.Hex(), chainID)
+	}
+	if symbol == "USDT" {
+		return rescale(big.NewInt(1), 0, USDDecimals), nil
+	}
+
+	o.mu.Lock()
+	cached, exists := o.cache[symbol]
+	o.mu.Unlock()
+	if exists && time.Since(cached.fetchedAt) < o.cacheTTL {
+		return new(big.Int).Set(cached.price), nil
+	}
+
+	url := fmt.Sprintf("%s?instId=%s-USDT", o.baseURL, symbol)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var ticker struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+		Data []struct {
+			Last string `json:"last"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&ticker); err != nil {
+		return nil, err
+	}
+	if ticker.Code != "0" || len(ticker.Data) == 0 {
+		return nil, fmt.Errorf("OKX ticker error for %s: %s", symbol, ticker.Msg)
+	}
+
+	price, err := parseDecimal(ticker.Data[0].Last, USDDecimals)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OKX price for %s: %v", symbol, err)
+	}
+
+	o.mu.Lock()
+	o.cache[symbol] = cachedPrice{price: price, fetchedAt: time.Now()}
+	o.mu.Unlock()
+
+	return new(big.Int).Set(price), nil
+}
+
+// parseDecimal parses a decimal string such as "2345.67" into a fixed-point integer
+func parseDecimal(s string, decimals uint8) (*big.Int, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(s), ".")
+	if len(frac) > int(decimals) {
+		frac = frac[:decimals]
+	}
+	frac += strings.Repeat("0", int(decimals)-len(frac))
+
+	value, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse %q", s)
+	}
+	return value, nil
+}
+
+// FallbackPriceSource tries each source in order until one returns a price
+type FallbackPriceSource struct {
+	sources []PriceSource
+}
+
+func NewFallbackPriceSource(sources ...PriceSource) *FallbackPriceSource {
+	return &FallbackPriceSource{sources: sources}
+}
+
+func (f *FallbackPriceSource) GetUSDPrice(ctx context.Context, chainID uint64, token *TokenInfo) (*big.Int, error) {
+	var lastErr error
+	for _, source := range f.sources {
+		price, err := source.GetUSDPrice(ctx, chainID, token)
+		if err == nil {
+			return price, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no price sources configured")
+	}
+	return nil, fmt.Errorf("no price for %s on chain %d: %v", token.Symbol, chainID, lastErr)
+}
+
+// NewDefaultPriceSource prefers on-chain Chainlink feeds and falls back to OKX tickers
+func NewDefaultPriceSource(manager *MultiChainManager) PriceSource {
+	return NewFallbackPriceSource(
+		NewChainlinkPriceSource(manager, 2*time.Hour),
+		NewOKXPriceSource(time.Minute),
+	)
+}
+
+// formatUnits renders a raw token amount as a decimal string for logging
+func formatUnits(amount *big.Int, decimals uint8) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return new(big.Rat).SetFrac(amount, unit).FloatString(6)
+}
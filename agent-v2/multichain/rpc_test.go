@@ -0,0 +1,107 @@
+package multichain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// fakeRPC is a JSON-RPC endpoint answering the methods in handlers. Every
+// chain gets eth_chainId, so it can be added to a MultiChainManager.
+type fakeRPC struct {
+	*httptest.Server
+	chainID  uint64
+	handlers map[string]func(params []json.RawMessage) (interface{}, error)
+	calls    map[common.Address]func(data []byte) ([]byte, error) // eth_call by recipient
+	mu       sync.Mutex
+}
+
+func newFakeRPC(t *testing.T, chainID uint64) *fakeRPC {
+	f := &fakeRPC{
+		chainID:  chainID,
+		handlers: make(map[string]func(params []json.RawMessage) (interface{}, error)),
+		calls:    make(map[common.Address]func(data []byte) ([]byte, error)),
+	}
+	f.handle("eth_chainId", func([]json.RawMessage) (interface{}, error) {
+		return hexutil.Uint64(f.chainID), nil
+	})
+	f.handle("eth_call", func(params []json.RawMessage) (interface{}, error) {
+		var call struct {
+			To    common.Address `json:"to"`
+			Input hexutil.Bytes  `json:"input"`
+			Data  hexutil.Bytes  `json:"data"`
+		}
+		if err := json.Unmarshal(params[0], &call); err != nil {
+			return nil, err
+		}
+		f.mu.Lock()
+		handler, exists := f.calls[call.To]
+		f.mu.Unlock()
+		if !exists {
+			return nil, fmt.Errorf("unexpected eth_call to %s", call.To.Hex())
+		}
+		data := call.Input
+		if len(data) == 0 {
+			data = call.Data
+		}
+		output, err := handler(data)
+		return hexutil.Bytes(output), err
+	})
+
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid JSON-RPC request: %v", err)
+			return
+		}
+
+		f.mu.Lock()
+		handler, exists := f.handlers[req.Method]
+		f.mu.Unlock()
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if !exists {
+			t.Errorf("unexpected JSON-RPC method %s", req.Method)
+			response["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		} else if result, err := handler(req.Params); err != nil {
+			response["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+		} else {
+			response["result"] = result
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	return f
+}
+
+func (f *fakeRPC) handle(method string, handler func(params []json.RawMessage) (interface{}, error)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[method] = handler
+}
+
+func (f *fakeRPC) handleCall(to common.Address, handler func(data []byte) ([]byte, error)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[to] = handler
+}
+
+// addFakeChain connects manager to a new fake endpoint for chain
+func addFakeChain(t *testing.T, manager *MultiChainManager, chain *ChainConfig) *fakeRPC {
+	rpc := newFakeRPC(t, chain.ChainID)
+	t.Cleanup(rpc.Close)
+	chain.RPC = rpc.URL
+	if err := manager.AddChain(chain); err != nil {
+		t.Fatalf("failed to add chain %d: %v", chain.ChainID, err)
+	}
+	return rpc
+}
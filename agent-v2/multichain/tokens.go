@@ -0,0 +1,71 @@
+package multichain
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TokenInfo describes a token tracked on a specific chain
+type TokenInfo struct {
+	Address     common.Address
+	Symbol      string
+	Decimals    uint8
+	PriceFeed   common.Address // Chainlink <symbol>/USD aggregator, zero if none
+	PriceSymbol string         // Market symbol when it differs from Symbol, e.g. ETH for WETH
+	Discovered  bool           // found in Transfer logs and not allowlisted; never priced
+}
+
+// builtinTokens lists the major stablecoins and wrapped natives tracked on every run
+var builtinTokens = map[uint64][]*TokenInfo{
+	1: {
+		{Address: common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), Symbol: "USDC", Decimals: 6},
+		{Address: common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7"), Symbol: "USDT", Decimals: 6},
+		{Address: common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"), Symbol: "DAI", Decimals: 18},
+		{Address: common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"), Symbol: "WETH", Decimals: 18,
+			PriceFeed: common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"), PriceSymbol: "ETH"},
+		{Address: common.HexToAddress("0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599"), Symbol: "WBTC", Decimals: 8, PriceSymbol: "BTC"},
+	},
+	137: {
+		{Address: common.HexToAddress("0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359"), Symbol: "USDC", Decimals: 6},
+		{Address: common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"), Symbol: "USDC.e", Decimals: 6, PriceSymbol: "USDC"},
+		{Address: common.HexToAddress("0xc2132D05D31c914a87C6611C10748AEb04B58e8F"), Symbol: "USDT", Decimals: 6},
+		{Address: common.HexToAddress("0x8f3Cf7ad23Cd3CaDbD9735AFf958023239c6A063"), Symbol: "DAI", Decimals: 18},
+		{Address: common.HexToAddress("0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270"), Symbol: "WMATIC", Decimals: 18,
+			PriceFeed: common.HexToAddress("0xAB594600376Ec9fD91F8e885dADF0CE036862dE0"), PriceSymbol: "MATIC"},
+		{Address: common.HexToAddress("0x7ceB23fD6bC0adD59E62ac25578270cFf1b9f619"), Symbol: "WETH", Decimals: 18, PriceSymbol: "ETH"},
+	},
+	42161: {
+		{Address: common.HexToAddress("0xaf88d065e77c8cC2239327C5EDb3A432268e5831"), Symbol: "USDC", Decimals: 6},
+		{Address: common.HexToAddress("0xFd086bC7CD5C481DCC9C85ebE478A1C0b69FCbb9"), Symbol: "USDT", Decimals: 6},
+		{Address: common.HexToAddress("0xDA10009cBd5D07dd0CeCc66161FC93D7c9000da1"), Symbol: "DAI", Decimals: 18},
+		{Address: common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1"), Symbol: "WETH", Decimals: 18,
+			PriceFeed: common.HexToAddress("0x639Fe6ab55C921f74e7fac1ee960C0B6293ba612"), PriceSymbol: "ETH"},
+	},
+	10: {
+		{Address: common.HexToAddress("0x0b2C639c533813f4Aa9D7837CAf62653d097Ff85"), Symbol: "USDC", Decimals: 6},
+		{Address: common.HexToAddress("0x94b008aA00579c1307B0EF2c499aD98a8ce58e58"), Symbol: "USDT", Decimals: 6},
+		{Address: common.HexToAddress("0xDA10009cBd5D07dd0CeCc66161FC93D7c9000da1"), Symbol: "DAI", Decimals: 18},
+		{Address: common.HexToAddress("0x4200000000000000000000000000000000000006"), Symbol: "WETH", Decimals: 18,
+			PriceFeed: common.HexToAddress("0x13e3Ee699D1909E989722E753853AE30b17e08c5"), PriceSymbol: "ETH"},
+	},
+	8453: {
+		{Address: common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"), Symbol: "USDC", Decimals: 6},
+		{Address: common.HexToAddress("0x50c5725949A6F0c72E6C4a641F24049A917DB0Cb"), Symbol: "DAI", Decimals: 18},
+		{Address: common.HexToAddress("0x4200000000000000000000000000000000000006"), Symbol: "WETH", Decimals: 18,
+			PriceFeed: common.HexToAddress("0x71041dddad3595F9CEd3DcCFBe3D1F4b0a16Bb70"), PriceSymbol: "ETH"},
+	},
+	196: {
+		{Address: common.HexToAddress("0x74b7F16337b8972027F6196A17a631aC6dE26d22"), Symbol: "USDC", Decimals: 6},
+		{Address: common.HexToAddress("0x1E4a5963aBFD975d8c9021ce480b42188849D41d"), Symbol: "USDT", Decimals: 6},
+		{Address: common.HexToAddress("0xe538905cf8410324e03A5A23C1c177a474D59b2b"), Symbol: "WOKB", Decimals: 18, PriceSymbol: "OKB"},
+	},
+}
+
+// BuiltinTokens returns the default token list for a chain
+func BuiltinTokens(chainID uint64) []*TokenInfo {
+	tokens := make([]*TokenInfo, 0, len(builtinTokens[chainID]))
+	for _, token := range builtinTokens[chainID] {
+		copied := *token
+		tokens = append(tokens, &copied)
+	}
+	return tokens
+}
@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Notifier delivers alerts that need an operator's attention, such as a swap
+// that filled beyond its slippage limit
+type Notifier interface {
+	Alert(ctx context.Context, message string)
+}
+
+// Alertf formats an alert and sends it through n, or only logs it when n is nil
+func Alertf(ctx context.Context, n Notifier, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if n == nil {
+		log.Printf("🚨 %s", message)
+		return
+	}
+	n.Alert(ctx, message)
+}
+
+// LogNotifier writes alerts to the log
+type LogNotifier struct{}
+
+func (LogNotifier) Alert(ctx context.Context, message string) {
+	log.Printf("🚨 %s", message)
+}
+
+// WebhookNotifier logs alerts and posts them to a Slack-compatible webhook as {"text": message}
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *WebhookNotifier) Alert(ctx context.Context, message string) {
+	log.Printf("🚨 %s", message)
+
+	body, err := json.Marshal(map[string]string{"text": message})
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("⚠️  Failed to send alert: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		log.Printf("⚠️  Failed to send alert: %v", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("⚠️  Alert webhook returned HTTP %d", resp.StatusCode)
+	}
+}
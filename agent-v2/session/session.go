@@ -0,0 +1,323 @@
+// Package session signs routine smart account executions with an ephemeral
+// session key, so the owner key is only needed to authorize and rotate it.
+package session
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"agent/bridge"
+	"agent/contracts"
+	"agent/state"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	bucket     = "session"
+	currentKey = "current"
+
+	// sweepGas covers the transfer that returns a retired key's leftover gas money
+	sweepGas = 21000 * 12 / 10
+)
+
+// Key is an ephemeral signer the owner has authorized on its smart accounts
+type Key struct {
+	PrivateKey string         `json:"privateKey"` // hex, stored only in the state directory
+	Address    common.Address `json:"address"`
+	ExpiresAt  time.Time      `json:"expiresAt"`
+	Chains     []uint64       `json:"chains"` // chains whose smart account authorized the key
+}
+
+func (k *Key) authorizedOn(chainID uint64) bool {
+	for _, authorized := range k.Chains {
+		if authorized == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+// Manager holds the current session key, signs with it and rotates it before it expires.
+// It implements bridge.Sender, so it can sign for a bridge.SmartAccountSender.
+type Manager struct {
+	Duration     time.Duration
+	RotateBefore time.Duration     // rotate once less than this remains
+	GasFunding   *big.Int          // native currency the owner sends a new key on each chain; nil disables
+	Journal      *bridge.TxJournal // optional; records executions until they are mined
+	store        *state.Store
+	clients      bridge.ClientSource
+	accounts     map[uint64]*contracts.SmartAccountClient
+	key          *Key
+	signer       *ecdsa.PrivateKey
+	opts         map[uint64]*bind.TransactOpts
+	mu           sync.Mutex
+}
+
+func NewManager(store *state.Store, clients bridge.ClientSource, accounts map[uint64]*contracts.SmartAccountClient, duration time.Duration) (*Manager, error) {
+	m := &Manager{
+		Duration:     duration,
+		RotateBefore: duration / 4,
+		store:        store,
+		clients:      clients,
+		accounts:     accounts,
+		opts:         make(map[uint64]*bind.TransactOpts),
+	}
+
+	var key Key
+	found, err := store.Get(bucket, currentKey, &key)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		signer, err := crypto.HexToECDSA(key.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stored session key: %v", err)
+		}
+		m.key, m.signer = &key, signer
+	}
+	return m, nil
+}
+
+// Current returns the session key in use, or nil before the first authorization
+func (m *Manager) Current() *Key {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.key
+}
+
+// NeedsRotation reports whether the key is missing, close to expiry or not authorized on every account
+func (m *Manager) NeedsRotation() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.key == nil || time.Until(m.key.ExpiresAt) < m.RotateBefore {
+		return true
+	}
+	for chainID := range m.accounts {
+		if !m.key.authorizedOn(chainID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Address is the session key's address
+func (m *Manager) Address() common.Address {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.key == nil {
+		return common.Address{}
+	}
+	return m.key.Address
+}
+
+// Send signs and sends a transaction with the current session key
+func (m *Manager) Send(ctx context.Context, chainID uint64, to common.Address, data []byte, value *big.Int) (*types.Receipt, error) {
+	signer, err := m.currentSigner()
+	if err != nil {
+		return nil, err
+	}
+	sender := bridge.NewKeyedSender(signer, m.clients)
+	sender.Journal = m.Journal
+	return sender.Send(ctx, chainID, to, data, value)
+}
+
+// SignHash signs a userOpHash with the current session key as an EIP-191
+// personal message, so the manager can sign for a userop.Sender
+func (m *Manager) SignHash(hash common.Hash) ([]byte, error) {
+	signer, err := m.currentSigner()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.Sign(accounts.TextHash(hash.Bytes()), signer)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+func (m *Manager) currentSigner() (*ecdsa.PrivateKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.key == nil {
+		return nil, fmt.Errorf("no session key authorized; run `go run . session-key` with the owner key")
+	}
+	if time.Now().After(m.key.ExpiresAt) {
+		return nil, fmt.Errorf("session key %s expired at %s", m.key.Address.Hex(), m.key.ExpiresAt.Format(time.RFC3339))
+	}
+	return m.signer, nil
+}
+
+// TransactOpts returns options that sign with the session key on a chain.
+// The same options are updated in place when the key rotates.
+func (m *Manager) TransactOpts(chainID uint64) (*bind.TransactOpts, error) {
+	signer, err := m.currentSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if opts, exists := m.opts[chainID]; exists {
+		return opts, nil
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(signer, new(big.Int).SetUint64(chainID))
+	if err != nil {
+		return nil, err
+	}
+	m.opts[chainID] = opts
+	return opts, nil
+}
+
+// MaybeRotate replaces the session key when it needs rotation. Without the
+// owner key it can only warn, so an operator has to run the CLI step in time.
+func (m *Manager) MaybeRotate(ctx context.Context, owner *ecdsa.PrivateKey) {
+	if !m.NeedsRotation() {
+		return
+	}
+	if owner == nil {
+		if key := m.Current(); key != nil {
+			log.Printf("🚨 Session key %s expires at %s; run `go run . session-key` with the owner key to rotate it",
+				key.Address.Hex(), key.ExpiresAt.Format(time.RFC3339))
+		}
+		return
+	}
+	if _, err := m.Authorize(ctx, owner); err != nil {
+		log.Printf("🚨 Failed to rotate session key: %v", err)
+	}
+}
+
+// Authorize generates a new session key, has the owner authorize it with
+// setSessionKey on every smart account and fund it for gas, then retires the
+// previous key
+func (m *Manager) Authorize(ctx context.Context, owner *ecdsa.PrivateKey) (*Key, error) {
+	signer, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session key: %v", err)
+	}
+	key := &Key{
+		PrivateKey: hex.EncodeToString(crypto.FromECDSA(signer)),
+		Address:    crypto.PubkeyToAddress(signer.PublicKey),
+		ExpiresAt:  time.Now().Add(m.Duration).Truncate(time.Second),
+	}
+
+	ownerSender := bridge.NewKeyedSender(owner, m.clients)
+	for _, chainID := range m.chainIDs() {
+		if err := m.setSessionKey(ctx, owner, chainID, key.Address, key.ExpiresAt); err != nil {
+			return nil, err
+		}
+		key.Chains = append(key.Chains, chainID)
+
+		if m.GasFunding != nil && m.GasFunding.Sign() > 0 {
+			if _, err := ownerSender.Send(ctx, chainID, key.Address, nil, m.GasFunding); err != nil {
+				return nil, fmt.Errorf("failed to fund session key on chain %d: %v", chainID, err)
+			}
+		}
+	}
+
+	if err := m.store.Put(bucket, currentKey, key); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	previous, previousSigner := m.key, m.signer
+	m.key, m.signer = key, signer
+	for chainID, opts := range m.opts {
+		rotated, err := bind.NewKeyedTransactorWithChainID(signer, new(big.Int).SetUint64(chainID))
+		if err == nil {
+			opts.From, opts.Signer = rotated.From, rotated.Signer
+		}
+	}
+	m.mu.Unlock()
+
+	log.Printf("🔑 Session key %s authorized on chains %v until %s", key.Address.Hex(), key.Chains, key.ExpiresAt.Format(time.RFC3339))
+
+	if previous != nil {
+		m.retire(ctx, owner, previous, previousSigner)
+	}
+	return key, nil
+}
+
+func (m *Manager) setSessionKey(ctx context.Context, owner *ecdsa.PrivateKey, chainID uint64, key common.Address, expiresAt time.Time) error {
+	client, err := m.clients(chainID)
+	if err != nil {
+		return err
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(owner, new(big.Int).SetUint64(chainID))
+	if err != nil {
+		return err
+	}
+	auth.Context = ctx
+
+	tx, err := m.accounts[chainID].SetSessionKey(auth, key, expiresAt)
+	if err != nil {
+		return fmt.Errorf("setSessionKey failed on chain %d: %v", chainID, err)
+	}
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return fmt.Errorf("failed waiting for setSessionKey on chain %d: %v", chainID, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("setSessionKey reverted on chain %d; is the key the account owner?", chainID)
+	}
+	return nil
+}
+
+// retire revokes a replaced key and returns its leftover gas money to the owner.
+// Failures are only logged: the key expires on its own.
+func (m *Manager) retire(ctx context.Context, owner *ecdsa.PrivateKey, key *Key, signer *ecdsa.PrivateKey) {
+	ownerAddress := crypto.PubkeyToAddress(owner.PublicKey)
+	for _, chainID := range key.Chains {
+		if _, exists := m.accounts[chainID]; !exists {
+			continue
+		}
+		if err := m.setSessionKey(ctx, owner, chainID, key.Address, time.Unix(0, 0)); err != nil {
+			log.Printf("⚠️  Failed to revoke session key %s: %v", key.Address.Hex(), err)
+		}
+
+		client, err := m.clients(chainID)
+		if err != nil {
+			continue
+		}
+		balance, err := client.BalanceAt(ctx, key.Address, nil)
+		if err != nil {
+			continue
+		}
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			continue
+		}
+		// Leave twice the transfer cost in case the gas price rises before sending
+		leftover := new(big.Int).Sub(balance, new(big.Int).Mul(gasPrice, big.NewInt(2*sweepGas)))
+		if leftover.Sign() <= 0 {
+			continue
+		}
+		if _, err := bridge.NewKeyedSender(signer, m.clients).Send(ctx, chainID, ownerAddress, nil, leftover); err != nil {
+			log.Printf("⚠️  Failed to sweep session key %s on chain %d: %v", key.Address.Hex(), chainID, err)
+		}
+	}
+	log.Printf("🔒 Retired session key %s", key.Address.Hex())
+}
+
+func (m *Manager) chainIDs() []uint64 {
+	chainIDs := make([]uint64, 0, len(m.accounts))
+	for chainID := range m.accounts {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+	return chainIDs
+}
@@ -0,0 +1,157 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Store persists agent state as JSON documents grouped into buckets.
+// Each bucket is a directory and each key a file, so writes stay cheap as
+// history grows and a crash never leaves a half-written document behind.
+type Store struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create state directory %s: %v", dir, err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Put stores value under bucket/key, replacing any previous value atomically
+func (s *Store) Put(bucket, key string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s/%s: %v", bucket, key, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucketDir := filepath.Join(s.dir, bucket)
+	if err := os.MkdirAll(bucketDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create bucket %s: %v", bucket, err)
+	}
+
+	tmp, err := os.CreateTemp(bucketDir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(bucket, key))
+}
+
+// Get loads bucket/key into value and reports whether the key exists
+func (s *Store) Get(bucket, key string, value interface{}) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, err := os.ReadFile(s.path(bucket, key))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, value); err != nil {
+		return false, fmt.Errorf("failed to decode %s/%s: %v", bucket, key, err)
+	}
+	return true, nil
+}
+
+func (s *Store) Delete(bucket, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path(bucket, key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Keys returns the keys of a bucket in lexical order
+func (s *Store) Keys(bucket string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := os.ReadDir(filepath.Join(s.dir, bucket))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		key, err := url.PathUnescape(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Flush syncs bucket directories so that completed renames survive a power loss
+func (s *Store) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	dirs := []string{s.dir}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, filepath.Join(s.dir, entry.Name()))
+		}
+	}
+
+	for _, dir := range dirs {
+		f, err := os.Open(dir)
+		if err != nil {
+			return err
+		}
+		err = f.Sync()
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to sync %s: %v", dir, err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) path(bucket, key string) string {
+	return filepath.Join(s.dir, bucket, url.PathEscape(key)+".json")
+}
@@ -0,0 +1,143 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"agent/state"
+)
+
+const gasDeferralBucket = "gas_deferrals"
+
+// GasPolicy lets a latency-insensitive strategy wait for cheap gas once it is due
+type GasPolicy struct {
+	MaxPercentile float64       // execute when current gas ranks at or below this percentile (0-100) of recent history
+	MaxDelay      time.Duration // execute regardless once the strategy has been due this long
+}
+
+// GasAware is implemented by strategies that declare a GasPolicy
+type GasAware interface {
+	GetGasPolicy() *GasPolicy
+}
+
+// GasPercentileSource ranks a chain's current gas price within its recent history
+type GasPercentileSource interface {
+	CurrentGasPercentile(ctx context.Context, chainID uint64) (float64, error)
+}
+
+// GasGate defers due strategies until gas is cheap enough or their deadline passes.
+// Deferral start times are persisted, so a restart does not reset the deadline.
+type GasGate struct {
+	source   GasPercentileSource
+	store    *state.Store
+	dueSince map[strategyKey]time.Time // first time each strategy was due and deferred
+	mu       sync.Mutex
+}
+
+// strategyKey identifies a strategy; on-chain strategy IDs are only unique per account
+type strategyKey struct {
+	chainID uint64
+	id      uint64
+}
+
+func (k strategyKey) String() string {
+	return fmt.Sprintf("%d-%d", k.chainID, k.id)
+}
+
+func NewGasGate(source GasPercentileSource, store *state.Store) (*GasGate, error) {
+	g := &GasGate{
+		source:   source,
+		store:    store,
+		dueSince: make(map[strategyKey]time.Time),
+	}
+
+	keys, err := store.Keys(gasDeferralBucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gas deferrals: %v", err)
+	}
+	for _, key := range keys {
+		parts := strings.SplitN(key, "-", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		chainID, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		id, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		var dueSince time.Time
+		if _, err := store.Get(gasDeferralBucket, key, &dueSince); err != nil {
+			return nil, err
+		}
+		g.dueSince[strategyKey{chainID: chainID, id: id}] = dueSince
+	}
+
+	return g, nil
+}
+
+// Allow reports whether a due strategy may execute now on the given chain.
+// Strategies without a policy are always allowed.
+func (g *GasGate) Allow(ctx context.Context, strategy TradingStrategy, chainID uint64) bool {
+	aware, ok := strategy.(GasAware)
+	if !ok || aware.GetGasPolicy() == nil {
+		return true
+	}
+	policy := aware.GetGasPolicy()
+
+	key := strategyKey{chainID: chainID, id: strategy.GetID()}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	dueSince, deferred := g.dueSince[key]
+	if !deferred {
+		dueSince = time.Now()
+	}
+
+	if policy.MaxDelay > 0 && time.Since(dueSince) >= policy.MaxDelay {
+		log.Printf("⏰ %s strategy #%d reached its gas deadline, executing", strategy.GetType(), strategy.GetID())
+		g.clear(key)
+		return true
+	}
+
+	percentile, err := g.source.CurrentGasPercentile(ctx, chainID)
+	if err != nil {
+		// Without enough history there is nothing to compare against
+		log.Printf("⚠️  Gas percentile unavailable for chain %d, not deferring: %v", chainID, err)
+		g.clear(key)
+		return true
+	}
+
+	if percentile <= policy.MaxPercentile {
+		g.clear(key)
+		return true
+	}
+
+	if !deferred {
+		if err := g.store.Put(gasDeferralBucket, key.String(), dueSince); err != nil {
+			log.Printf("⚠️  Failed to persist gas deferral of %s strategy #%d: %v", strategy.GetType(), strategy.GetID(), err)
+		}
+	}
+	g.dueSince[key] = dueSince
+	log.Printf("⛽ Deferring %s strategy #%d: gas at p%.0f exceeds p%.0f",
+		strategy.GetType(), strategy.GetID(), percentile, policy.MaxPercentile)
+	return false
+}
+
+// clear forgets a strategy's deferral once it is allowed to execute
+func (g *GasGate) clear(key strategyKey) {
+	if _, deferred := g.dueSince[key]; !deferred {
+		return
+	}
+	delete(g.dueSince, key)
+	if err := g.store.Delete(gasDeferralBucket, key.String()); err != nil {
+		log.Printf("⚠️  Failed to delete gas deferral %s: %v", key, err)
+	}
+}
@@ -0,0 +1,85 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"agent/state"
+)
+
+// fixedPercentile ranks current gas at percentile, or fails when err is set
+type fixedPercentile struct {
+	percentile float64
+	err        error
+}
+
+func (f fixedPercentile) CurrentGasPercentile(ctx context.Context, chainID uint64) (float64, error) {
+	return f.percentile, f.err
+}
+
+type gasStrategy struct {
+	fakeStrategy
+	policy *GasPolicy
+}
+
+func (g *gasStrategy) GetGasPolicy() *GasPolicy {
+	return g.policy
+}
+
+func TestGasGateAllow(t *testing.T) {
+	policy := &GasPolicy{MaxPercentile: 30, MaxDelay: time.Hour}
+	cases := []struct {
+		name     string
+		policy   *GasPolicy
+		source   fixedPercentile
+		dueSince time.Duration // persisted deferral start before now; zero for none
+		allowed  bool
+		deferred bool // a deferral is persisted afterwards
+	}{
+		{name: "no policy", source: fixedPercentile{percentile: 90}, allowed: true},
+		{name: "cheap gas", policy: policy, source: fixedPercentile{percentile: 30}, allowed: true},
+		{name: "expensive gas", policy: policy, source: fixedPercentile{percentile: 31}, deferred: true},
+		{name: "expensive gas before the deadline", policy: policy, source: fixedPercentile{percentile: 90}, dueSince: 59 * time.Minute, deferred: true},
+		{name: "deadline survives a restart", policy: policy, source: fixedPercentile{percentile: 90}, dueSince: time.Hour, allowed: true},
+		{name: "cheap gas clears the deferral", policy: policy, source: fixedPercentile{percentile: 10}, dueSince: time.Minute, allowed: true},
+		{name: "no history", policy: policy, source: fixedPercentile{err: fmt.Errorf("only 3 gas samples")}, allowed: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			store, err := state.Open(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			key := strategyKey{chainID: 10, id: 7}
+			if c.dueSince > 0 {
+				if err := store.Put(gasDeferralBucket, key.String(), time.Now().Add(-c.dueSince)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gate, err := NewGasGate(c.source, store)
+			if err != nil {
+				t.Fatal(err)
+			}
+			strategy := &gasStrategy{fakeStrategy: fakeStrategy{id: 7, chainID: 10}, policy: c.policy}
+			if allowed := gate.Allow(context.Background(), strategy, 10); allowed != c.allowed {
+				t.Errorf("allowed %v, expected %v", allowed, c.allowed)
+			}
+
+			var dueSince time.Time
+			found, err := store.Get(gasDeferralBucket, key.String(), &dueSince)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found != c.deferred {
+				t.Errorf("deferral persisted %v, expected %v", found, c.deferred)
+			}
+			if found && c.dueSince > 0 && time.Since(dueSince) < c.dueSince {
+				t.Errorf("deferral restarted at %s", dueSince)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"agent/bridge"
+	"agent/contracts"
+	"agent/notify"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	// DefaultOnChainLookback is how many blocks behind the head discovery starts without a from block
+	DefaultOnChainLookback = 10000
+	// onChainBatchBlocks keeps eth_getLogs ranges within common public RPC limits
+	onChainBatchBlocks = 2000
+	// maxOnChainBatches bounds the log scanning done per Discover call
+	maxOnChainBatches = 10
+)
+
+// StrategySync discovers the strategies stored in a SmartAccountV2 from its
+// StrategyCreated events. The strategies it builds schedule from the contract's
+// state and record each execution on it. Rebalance strategies are skipped
+// until rebalancing makes trades.
+type StrategySync struct {
+	SlippageBps uint32
+	Swapper     Swapper
+	Signer      bridge.Sender
+	Approver    Approver
+	Notifier    notify.Notifier
+	GasPolicy   *GasPolicy // applied to discovered DCA strategies
+	chainID     uint64
+	account     *contracts.SmartAccountClient
+	client      *ethclient.Client
+	auth        *bind.TransactOpts
+	startBlock  uint64
+	nextBlock   uint64 // zero until the first scan picks a start block
+	known       map[uint64]bool
+}
+
+// NewStrategySync scans from fromBlock, normally the account's deployment
+// block; zero starts DefaultOnChainLookback blocks behind the head
+func NewStrategySync(chainID uint64, account *contracts.SmartAccountClient, client *ethclient.Client, auth *bind.TransactOpts, fromBlock uint64) (*StrategySync, error) {
+	if _, err := account.V2(); err != nil {
+		return nil, err
+	}
+	return &StrategySync{
+		chainID:   chainID,
+		account:   account,
+		client:    client,
+		auth:      auth,
+		nextBlock: fromBlock,
+		known:     make(map[uint64]bool),
+	}, nil
+}
+
+// StartBlock is the first block scanned. Discovered strategies live only in
+// memory, so a restart has to rescan from here rather than resume.
+func (s *StrategySync) StartBlock() uint64 {
+	return s.startBlock
+}
+
+// Discover scans new blocks for StrategyCreated events and returns engine
+// strategies for the ones not seen before
+func (s *StrategySync) Discover(ctx context.Context) ([]TradingStrategy, error) {
+	account, err := s.account.V2()
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block number: %v", err)
+	}
+	if s.nextBlock == 0 && head > DefaultOnChainLookback {
+		s.nextBlock = head - DefaultOnChainLookback
+	}
+	if s.startBlock == 0 {
+		s.startBlock = s.nextBlock
+	}
+
+	var discovered []TradingStrategy
+	for batch := 0; batch < maxOnChainBatches && s.nextBlock <= head; batch++ {
+		to := s.nextBlock + onChainBatchBlocks - 1
+		if to > head {
+			to = head
+		}
+
+		events, err := account.FilterStrategyCreated(&bind.FilterOpts{Start: s.nextBlock, End: &to, Context: ctx}, nil)
+		if err != nil {
+			return discovered, fmt.Errorf("failed to filter StrategyCreated logs: %v", err)
+		}
+		for events.Next() {
+			id := events.Event.StrategyId.Uint64()
+			if s.known[id] {
+				continue
+			}
+			strategy, err := s.build(ctx, events.Event)
+			if err != nil {
+				events.Close()
+				return discovered, err
+			}
+			s.known[id] = true
+			if strategy == nil {
+				continue
+			}
+			log.Printf("⛓️  Discovered on-chain %s Strategy #%d on chain %d", strategy.GetType(), id, s.chainID)
+			discovered = append(discovered, strategy)
+		}
+		err = events.Error()
+		events.Close()
+		if err != nil {
+			return discovered, fmt.Errorf("failed to read StrategyCreated logs: %v", err)
+		}
+
+		s.nextBlock = to + 1
+	}
+	return discovered, nil
+}
+
+// build creates the engine strategy for a StrategyCreated event, or nil for an unknown type
+func (s *StrategySync) build(ctx context.Context, event *contracts.SmartAccountV2StrategyCreated) (TradingStrategy, error) {
+	id := event.StrategyId.Uint64()
+
+	switch event.StrategyType {
+	case "DCA":
+		state, err := s.account.DCAStrategy(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read DCA strategy #%d: %v", id, err)
+		}
+		strategy := NewDCAStrategy(id, state.TokenIn, state.TokenOut, state.AmountPerExecution,
+			state.Interval.Uint64(), state.MaxExecutions.Uint64(), s.client, s.account.Address, s.auth)
+		strategy.OnChain = s.account
+		strategy.ChainID = s.chainID
+		strategy.GasPolicy = s.GasPolicy
+		strategy.SlippageBps = s.SlippageBps
+		strategy.Swapper = s.Swapper
+		strategy.Signer = s.Signer
+		strategy.Approver = s.Approver
+		strategy.Notifier = s.Notifier
+		return strategy, nil
+
+	case "Grid":
+		state, err := s.account.GridStrategy(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read grid strategy #%d: %v", id, err)
+		}
+		strategy := NewGridStrategy(id, state.TokenA, state.TokenB, state.GridSize.Uint64(),
+			state.PriceStep, state.BasePrice, s.client, s.account.Address, s.auth)
+		strategy.OnChain = s.account
+		strategy.ChainID = s.chainID
+		strategy.SlippageBps = s.SlippageBps
+		strategy.Swapper = s.Swapper
+		strategy.Signer = s.Signer
+		strategy.Approver = s.Approver
+		strategy.Notifier = s.Notifier
+		if !validPriceStep(state.PriceStep) {
+			log.Printf("❌ Grid strategy #%d on chain %d has price step %v, deactivating it", id, s.chainID, state.PriceStep)
+			strategy.Active = false
+		}
+		return strategy, nil
+
+	case "Rebalance":
+		// Rebalancing makes no trades yet, so recording executions on the contract would claim rebalances that never happened
+		log.Printf("⚠️  Skipping on-chain rebalance strategy #%d: rebalancing trades are not implemented", id)
+		return nil, nil
+
+	default:
+		log.Printf("⚠️  Skipping strategy #%d of unknown type %q", id, event.StrategyType)
+		return nil, nil
+	}
+}
@@ -0,0 +1,153 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"agent/contracts"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var testAccount = common.HexToAddress("0xAcc0000000000000000000000000000000000001")
+
+// gridAccount is a SmartAccountV2 backend serving one stored grid strategy
+type gridAccount struct {
+	bind.ContractBackend
+	abi  *abi.ABI
+	grid contracts.GridStrategyState
+}
+
+func newGridAccount(t *testing.T, grid contracts.GridStrategyState) *contracts.SmartAccountClient {
+	parsed, err := contracts.SmartAccountV2MetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &gridAccount{abi: parsed, grid: grid}
+	account, err := contracts.NewSmartAccountClient(context.Background(), testAccount, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return account
+}
+
+func (g *gridAccount) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (g *gridAccount) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, err := g.abi.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "nextStrategyId":
+		return method.Outputs.Pack(big.NewInt(1))
+	case "gridStrategies":
+		return method.Outputs.Pack(g.grid.Active, g.grid.TokenA, g.grid.TokenB, g.grid.GridSize, g.grid.PriceStep, g.grid.BasePrice)
+	}
+	return nil, fmt.Errorf("unexpected call to %s", method.Name)
+}
+
+func gridState(active bool, priceStep int64) contracts.GridStrategyState {
+	return contracts.GridStrategyState{
+		Active:    active,
+		TokenA:    common.HexToAddress("0x70c0000000000000000000000000000000000002"),
+		TokenB:    common.HexToAddress("0x70c0000000000000000000000000000000000003"),
+		GridSize:  big.NewInt(10),
+		PriceStep: big.NewInt(priceStep),
+		BasePrice: big.NewInt(2000e8),
+	}
+}
+
+func TestGridSyncOnChain(t *testing.T) {
+	cases := []struct {
+		name   string
+		before bool // local state before the sync
+		grid   contracts.GridStrategyState
+		active bool
+	}{
+		{name: "active grid", before: true, grid: gridState(true, 10e8), active: true},
+		{name: "paused on the contract", before: true, grid: gridState(false, 10e8), active: false},
+		{name: "resumed on the contract", before: false, grid: gridState(true, 10e8), active: true},
+		{name: "zero price step", before: true, grid: gridState(true, 0), active: false},
+		{name: "zero price step stays inactive", before: false, grid: gridState(true, 0), active: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			account := newGridAccount(t, c.grid)
+			strategy := NewGridStrategy(1, common.Address{}, common.Address{}, 5, big.NewInt(1), big.NewInt(1), nil, testAccount, nil)
+			strategy.OnChain = account
+			strategy.Active = c.before
+
+			if err := strategy.syncOnChain(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if strategy.Active != c.active {
+				t.Errorf("active %v, expected %v", strategy.Active, c.active)
+			}
+			if strategy.PriceStep.Cmp(c.grid.PriceStep) != 0 || strategy.GridSize != c.grid.GridSize.Uint64() {
+				t.Errorf("parameters not taken from the contract: step %s, size %d", strategy.PriceStep, strategy.GridSize)
+			}
+
+			// An inactive grid is never checked against a price, so a zero step cannot divide
+			if !strategy.Active {
+				if due, err := strategy.ShouldExecute(context.Background()); err != nil || due {
+					t.Errorf("inactive grid due %v, error %v", due, err)
+				}
+			}
+		})
+	}
+}
+
+func TestStrategySyncBuild(t *testing.T) {
+	cases := []struct {
+		name         string
+		strategyType string
+		grid         contracts.GridStrategyState
+		built        bool
+		active       bool
+	}{
+		{name: "grid", strategyType: "Grid", grid: gridState(true, 10e8), built: true, active: true},
+		{name: "grid without a price step", strategyType: "Grid", grid: gridState(true, 0), built: true, active: false},
+		{name: "rebalance is skipped", strategyType: "Rebalance"},
+		{name: "unknown type is skipped", strategyType: "Lending"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			account := newGridAccount(t, c.grid)
+			strategySync, err := NewStrategySync(10, account, nil, nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			strategy, err := strategySync.build(context.Background(), &contracts.SmartAccountV2StrategyCreated{
+				StrategyId:   big.NewInt(1),
+				StrategyType: c.strategyType,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (strategy != nil) != c.built {
+				t.Fatalf("built %v, expected %v", strategy != nil, c.built)
+			}
+			if !c.built {
+				return
+			}
+			grid := strategy.(*GridStrategy)
+			if grid.Active != c.active {
+				t.Errorf("active %v, expected %v", grid.Active, c.active)
+			}
+			if grid.ChainID != 10 || grid.OnChain != account {
+				t.Errorf("grid bound to chain %d and account %p, expected chain 10 and %p", grid.ChainID, grid.OnChain, account)
+			}
+		})
+	}
+}
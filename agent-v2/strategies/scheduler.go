@@ -0,0 +1,216 @@
+package strategies
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Scheduled is implemented by strategies that know when they are next due.
+// Strategies that do not implement it are checked every PollInterval.
+type Scheduled interface {
+	NextRun() time.Time
+}
+
+// Scheduler runs every strategy in its own goroutine, waking it when it is
+// due. A strategy never overlaps with itself because its goroutine waits for
+// each run to finish, and at most a fixed number of strategies run at once.
+// Runs on the same chain are serialized so their executions do not interleave.
+// Nonces are not the scheduler's concern: bridge.KeyedSender locks them per
+// chain and address, which also covers strategies that send on several chains
+// and sends made outside the scheduler.
+type Scheduler struct {
+	PollInterval time.Duration // wake interval of strategies that do not declare one, and of deferred ones
+	Timeout      time.Duration // per run, including the wait for its chain and a worker
+	gate         *GasGate      // optional
+	workers      chan struct{}
+	entries      map[strategyKey]*scheduledStrategy
+	chains       map[uint64]chan struct{} // chainID -> semaphore of one
+	ctx          context.Context          // set once Run starts
+	execCtx      context.Context          // outlives ctx so runs in progress can finish on shutdown
+	abort        context.CancelFunc
+	wg           sync.WaitGroup
+	mu           sync.Mutex
+}
+
+type scheduledStrategy struct {
+	strategy TradingStrategy
+	chainID  uint64
+}
+
+func NewScheduler(workers int, gate *GasGate) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Scheduler{
+		PollInterval: 30 * time.Second,
+		Timeout:      2 * time.Minute,
+		gate:         gate,
+		workers:      make(chan struct{}, workers),
+		entries:      make(map[strategyKey]*scheduledStrategy),
+		chains:       make(map[uint64]chan struct{}),
+	}
+}
+
+// Add schedules a strategy; it is checked as soon as the scheduler runs
+func (s *Scheduler) Add(strategy TradingStrategy) {
+	var chainID uint64
+	if aware, ok := strategy.(ChainAware); ok {
+		chainID = aware.GetChainID()
+	}
+	key := strategyKey{chainID: chainID, id: strategy.GetID()}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.entries[key]; exists {
+		log.Printf("⚠️  %s strategy #%d on chain %d is already scheduled, ignoring the duplicate",
+			strategy.GetType(), strategy.GetID(), chainID)
+		return
+	}
+	entry := &scheduledStrategy{strategy: strategy, chainID: chainID}
+	s.entries[key] = entry
+	if s.chains[chainID] == nil {
+		s.chains[chainID] = make(chan struct{}, 1)
+	}
+	if s.ctx != nil && s.ctx.Err() == nil {
+		s.start(s.ctx, entry)
+	}
+}
+
+// Run starts every scheduled strategy and blocks until ctx is cancelled.
+// Cancelling ctx stops new runs; runs in progress keep going until Drain.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.execCtx, s.abort = context.WithCancel(context.WithoutCancel(ctx))
+	for _, entry := range s.entries {
+		s.start(ctx, entry)
+	}
+	s.mu.Unlock()
+
+	<-ctx.Done()
+}
+
+// Drain waits up to timeout for the runs in progress after Run's context was
+// cancelled. It aborts the remaining runs and returns false if they do not
+// finish in time.
+func (s *Scheduler) Drain(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+	}
+
+	s.mu.Lock()
+	if s.abort != nil {
+		s.abort()
+	}
+	s.mu.Unlock()
+	return false
+}
+
+// start must be called with s.mu held
+func (s *Scheduler) start(ctx context.Context, entry *scheduledStrategy) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.loop(ctx, entry)
+	}()
+}
+
+func (s *Scheduler) loop(ctx context.Context, entry *scheduledStrategy) {
+	next := time.Now()
+	for {
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+
+		s.run(ctx, entry)
+		next = s.nextRun(entry.strategy)
+	}
+}
+
+// nextRun is when a strategy should next be checked
+func (s *Scheduler) nextRun(strategy TradingStrategy) time.Time {
+	now := time.Now()
+	if scheduled, ok := strategy.(Scheduled); ok {
+		// A due time already past means the last check declined or failed, so poll
+		if next := scheduled.NextRun(); next.After(now) {
+			return next
+		}
+	}
+	return now.Add(s.PollInterval)
+}
+
+// run checks and, if due, executes a strategy. The chain is taken before a
+// worker, so runs queued behind their chain do not hold workers other chains
+// could use. Both waits count against the timeout and end when stop is
+// cancelled; a run that has started keeps the scheduler's execution context.
+func (s *Scheduler) run(stop context.Context, entry *scheduledStrategy) {
+	strategy := entry.strategy
+	ctx, cancel := context.WithTimeout(s.execCtx, s.Timeout)
+	defer cancel()
+
+	s.mu.Lock()
+	chain := s.chains[entry.chainID]
+	s.mu.Unlock()
+	if !acquire(ctx, stop, chain) {
+		if stop.Err() == nil {
+			log.Printf("⚠️  %s strategy #%d timed out waiting for chain %d", strategy.GetType(), strategy.GetID(), entry.chainID)
+		}
+		return
+	}
+	defer func() { <-chain }()
+	if !acquire(ctx, stop, s.workers) {
+		if stop.Err() == nil {
+			log.Printf("⚠️  %s strategy #%d timed out waiting for a worker", strategy.GetType(), strategy.GetID())
+		}
+		return
+	}
+	defer func() { <-s.workers }()
+
+	shouldExecute, err := strategy.ShouldExecute(ctx)
+	if err != nil {
+		log.Printf("⚠️  Error checking strategy %d: %v", strategy.GetID(), err)
+		return
+	}
+	if !shouldExecute {
+		return
+	}
+	if s.gate != nil && !s.gate.Allow(ctx, strategy, entry.chainID) {
+		return
+	}
+
+	log.Printf("🎯 Executing %s strategy #%d", strategy.GetType(), strategy.GetID())
+	started := time.Now()
+	if err := strategy.Execute(ctx); err != nil {
+		log.Printf("❌ Strategy execution failed: %v", err)
+		return
+	}
+	log.Printf("✅ Strategy #%d executed successfully in %s", strategy.GetID(), time.Since(started).Round(time.Millisecond))
+}
+
+// acquire takes a slot of sem unless ctx expires or stop is cancelled first
+func acquire(ctx, stop context.Context, sem chan struct{}) bool {
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-stop.Done():
+		return false
+	}
+}
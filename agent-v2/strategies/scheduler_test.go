@@ -0,0 +1,153 @@
+package strategies
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStrategy is always due and takes duration to execute
+type fakeStrategy struct {
+	id       uint64
+	chainID  uint64
+	duration time.Duration
+	onRun    func(chainID uint64, start bool)
+	block    bool // run until the execution context is cancelled
+	aborted  chan struct{}
+}
+
+func (f *fakeStrategy) ShouldExecute(ctx context.Context) (bool, error) { return true, nil }
+func (f *fakeStrategy) GetType() string                                 { return "Fake" }
+func (f *fakeStrategy) GetID() uint64                                   { return f.id }
+func (f *fakeStrategy) GetChainID() uint64                              { return f.chainID }
+
+func (f *fakeStrategy) Execute(ctx context.Context) error {
+	if f.onRun != nil {
+		f.onRun(f.chainID, true)
+		defer f.onRun(f.chainID, false)
+	}
+	if f.block {
+		<-ctx.Done()
+		close(f.aborted)
+		return ctx.Err()
+	}
+	time.Sleep(f.duration)
+	return nil
+}
+
+// concurrency records the most runs in progress at once, per chain and overall
+type concurrency struct {
+	running    map[uint64]int
+	maxByChain map[uint64]int
+	total      int
+	maxTotal   int
+	mu         sync.Mutex
+}
+
+func (c *concurrency) onRun(chainID uint64, start bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !start {
+		c.running[chainID]--
+		c.total--
+		return
+	}
+	c.running[chainID]++
+	c.total++
+	c.maxByChain[chainID] = max(c.maxByChain[chainID], c.running[chainID])
+	c.maxTotal = max(c.maxTotal, c.total)
+}
+
+func TestSchedulerConcurrency(t *testing.T) {
+	cases := []struct {
+		name     string
+		workers  int
+		chains   []uint64 // one strategy per entry
+		maxTotal int      // most runs expected at once across chains
+	}{
+		{name: "one chain is serialized", workers: 4, chains: []uint64{1, 1, 1}, maxTotal: 1},
+		{name: "chains run in parallel", workers: 4, chains: []uint64{1, 10, 137}, maxTotal: 3},
+		{name: "workers bound parallel chains", workers: 2, chains: []uint64{1, 10, 137}, maxTotal: 2},
+		{name: "mixed", workers: 4, chains: []uint64{1, 1, 10, 10}, maxTotal: 2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			counts := &concurrency{running: make(map[uint64]int), maxByChain: make(map[uint64]int)}
+			scheduler := NewScheduler(c.workers, nil)
+			scheduler.PollInterval = time.Millisecond
+			for i, chainID := range c.chains {
+				scheduler.Add(&fakeStrategy{id: uint64(i), chainID: chainID, duration: 20 * time.Millisecond, onRun: counts.onRun})
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+			defer cancel()
+			scheduler.Run(ctx)
+			if !scheduler.Drain(time.Second) {
+				t.Fatal("runs did not finish after cancellation")
+			}
+
+			counts.mu.Lock()
+			defer counts.mu.Unlock()
+			for chainID, runs := range counts.maxByChain {
+				if runs != 1 {
+					t.Errorf("%d runs at once on chain %d", runs, chainID)
+				}
+			}
+			if counts.maxTotal != c.maxTotal {
+				t.Errorf("at most %d runs at once, expected %d", counts.maxTotal, c.maxTotal)
+			}
+		})
+	}
+}
+
+func TestSchedulerDrain(t *testing.T) {
+	cases := []struct {
+		name     string
+		block    bool
+		drained  bool
+		duration time.Duration
+	}{
+		{name: "runs in progress finish", duration: 50 * time.Millisecond, drained: true},
+		{name: "runs past the timeout are aborted", block: true, drained: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			started := make(chan struct{})
+			var once sync.Once
+			strategy := &fakeStrategy{
+				id:       1,
+				duration: c.duration,
+				block:    c.block,
+				aborted:  make(chan struct{}),
+				onRun: func(chainID uint64, start bool) {
+					if start {
+						once.Do(func() { close(started) })
+					}
+				},
+			}
+			scheduler := NewScheduler(1, nil)
+			scheduler.Add(strategy)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				<-started
+				cancel()
+			}()
+			scheduler.Run(ctx)
+
+			if drained := scheduler.Drain(100 * time.Millisecond); drained != c.drained {
+				t.Fatalf("drained %v, expected %v", drained, c.drained)
+			}
+			if c.block {
+				select {
+				case <-strategy.aborted:
+				case <-time.After(time.Second):
+					t.Fatal("the run in progress was not aborted")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package strategies
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SwapResult describes a swap executed through the Smart Account, with
+// AmountOut as measured from its receipt
+type SwapResult struct {
+	ChainID   uint64
+	TxHash    common.Hash
+	TokenIn   common.Address
+	TokenOut  common.Address
+	AmountIn  *big.Int
+	AmountOut *big.Int
+}
+
+// Trade is an executed swap attributed to a strategy
+type Trade struct {
+	StrategyID   uint64         `json:"strategyId"`
+	StrategyType string         `json:"strategyType"`
+	ChainID      uint64         `json:"chainId"`
+	TxHash       common.Hash    `json:"txHash"`
+	TokenIn      common.Address `json:"tokenIn"`
+	TokenOut     common.Address `json:"tokenOut"`
+	AmountIn     *big.Int       `json:"amountIn"`
+	AmountOut    *big.Int       `json:"amountOut"`
+	Timestamp    time.Time      `json:"timestamp"`
+}
+
+// TradeRecorder receives every trade executed by a strategy, e.g. for cost basis accounting
+type TradeRecorder interface {
+	RecordTrade(ctx context.Context, trade *Trade) error
+}
+
+// TradeReporter is implemented by strategies that report their trades
+type TradeReporter interface {
+	SetTradeRecorder(recorder TradeRecorder)
+}
+
+// tradeReporting is embedded by strategies to forward executed swaps to a TradeRecorder
+type tradeReporting struct {
+	recorder TradeRecorder
+}
+
+func (t *tradeReporting) SetTradeRecorder(recorder TradeRecorder) {
+	t.recorder = recorder
+}
+
+// reportTrade records a swap that was mined; results without a transaction
+// hash or a measured output are not trades and are dropped
+func (t *tradeReporting) reportTrade(ctx context.Context, strategyID uint64, strategyType string, result *SwapResult) {
+	if t.recorder == nil || result == nil || result.AmountIn == nil || result.AmountOut == nil {
+		return
+	}
+	if result.TxHash == (common.Hash{}) {
+		log.Printf("⚠️  Not recording %s strategy #%d trade without a mined transaction", strategyType, strategyID)
+		return
+	}
+
+	trade := &Trade{
+		StrategyID:   strategyID,
+		StrategyType: strategyType,
+		ChainID:      result.ChainID,
+		TxHash:       result.TxHash,
+		TokenIn:      result.TokenIn,
+		TokenOut:     result.TokenOut,
+		AmountIn:     result.AmountIn,
+		AmountOut:    result.AmountOut,
+		Timestamp:    time.Now(),
+	}
+	if err := t.recorder.RecordTrade(ctx, trade); err != nil {
+		log.Printf("⚠️  Failed to record trade for %s strategy #%d: %v", strategyType, strategyID, err)
+	}
+}
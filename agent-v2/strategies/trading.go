@@ -7,8 +7,14 @@ import (
 	"math/big"
 	"time"
 
+	"agent/bridge"
+	"agent/contracts"
+	"agent/dex"
+	"agent/notify"
+
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
@@ -20,20 +26,34 @@ type TradingStrategy interface {
 	GetID() uint64
 }
 
+// ChainAware is implemented by strategies bound to the smart account on one chain
+type ChainAware interface {
+	GetChainID() uint64
+}
+
 // DCAStrategy implements Dollar Cost Averaging
 type DCAStrategy struct {
-	ID                  uint64
-	TokenIn             common.Address
-	TokenOut            common.Address
-	AmountPerExecution  *big.Int
-	IntervalSeconds     uint64
-	LastExecution       time.Time
-	TotalExecutions     uint64
-	MaxExecutions       uint64
-	Active              bool
-	client              *ethclient.Client
-	contractAddress     common.Address
-	auth                *bind.TransactOpts
+	ID                 uint64
+	TokenIn            common.Address
+	TokenOut           common.Address
+	AmountPerExecution *big.Int
+	IntervalSeconds    uint64
+	LastExecution      time.Time
+	TotalExecutions    uint64
+	MaxExecutions      uint64
+	Active             bool
+	ChainID            uint64 // chain of the smart account the strategy executes through
+	client             *ethclient.Client
+	contractAddress    common.Address
+	auth               *bind.TransactOpts
+	GasPolicy          *GasPolicy                    // optional; DCA is not latency-sensitive
+	SlippageBps        uint32                        // tolerance on each swap; zero uses dex.DefaultSlippageBps
+	Swapper            Swapper                       // builds the swaps; required to execute
+	Signer             bridge.Sender                 // signs the smart account's calls; required to execute
+	Approver           Approver                      // adds approvals of the quoted spender to the swap batch
+	Notifier           notify.Notifier               // optional; receives slippage alerts
+	OnChain            *contracts.SmartAccountClient // optional; SmartAccountV2 holding the strategy, the source of truth for scheduling
+	tradeReporting
 }
 
 func NewDCAStrategy(
@@ -62,6 +82,12 @@ func NewDCAStrategy(
 }
 
 func (d *DCAStrategy) ShouldExecute(ctx context.Context) (bool, error) {
+	if d.OnChain != nil {
+		if err := d.syncOnChain(ctx); err != nil {
+			return false, err
+		}
+	}
+
 	if !d.Active {
 		return false, nil
 	}
@@ -76,24 +102,32 @@ func (d *DCAStrategy) ShouldExecute(ctx context.Context) (bool, error) {
 }
 
 func (d *DCAStrategy) Execute(ctx context.Context) error {
-	log.Printf("🔄 Executing DCA Strategy #%d: %s -> %s", 
+	log.Printf("🔄 Executing DCA Strategy #%d: %s -> %s",
 		d.ID, d.TokenIn.Hex()[:8], d.TokenOut.Hex()[:8])
 
-	// Get swap quote
-	quote, err := GetSwapQuote(
-		d.TokenIn.Hex(),
-		d.TokenOut.Hex(),
-		d.AmountPerExecution.String(),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to get swap quote: %v", err)
+	// The contract enforces the interval and execution cap, so record the
+	// execution in the swap's batch: neither lands without the other
+	var record []contracts.SmartAccountV2Call
+	if d.OnChain != nil {
+		data, err := contracts.PackExecuteDCA(d.ID)
+		if err != nil {
+			return fmt.Errorf("failed to pack executeDCA: %v", err)
+		}
+		record = append(record, contracts.SmartAccountV2Call{Target: d.contractAddress, Value: new(big.Int), Data: data})
 	}
 
 	// Execute the swap through Smart Account
-	err = ExecuteSwapThroughSmartAccount(ctx, d.client, d.contractAddress, d.auth, quote)
+	result, err := ExecuteSwapThroughSmartAccount(ctx, d.client, d.Swapper, d.Approver, d.Signer, d.Notifier, d.contractAddress, &dex.QuoteRequest{
+		ChainID:     d.ChainID,
+		TokenIn:     d.TokenIn,
+		TokenOut:    d.TokenOut,
+		AmountIn:    d.AmountPerExecution,
+		SlippageBps: d.SlippageBps,
+	}, record...)
 	if err != nil {
 		return fmt.Errorf("failed to execute swap: %v", err)
 	}
+	d.reportTrade(ctx, d.ID, d.GetType(), result)
 
 	// Update strategy state
 	d.LastExecution = time.Now()
@@ -107,6 +141,23 @@ func (d *DCAStrategy) Execute(ctx context.Context) error {
 	return nil
 }
 
+// syncOnChain replaces the local schedule with the strategy stored in the contract
+func (d *DCAStrategy) syncOnChain(ctx context.Context) error {
+	state, err := d.OnChain.DCAStrategy(ctx, d.ID)
+	if err != nil {
+		return fmt.Errorf("failed to read DCA strategy #%d: %v", d.ID, err)
+	}
+	d.Active = state.Active
+	d.TokenIn = state.TokenIn
+	d.TokenOut = state.TokenOut
+	d.AmountPerExecution = state.AmountPerExecution
+	d.IntervalSeconds = state.Interval.Uint64()
+	d.LastExecution = time.Unix(state.LastExecution.Int64(), 0)
+	d.TotalExecutions = state.TotalExecutions.Uint64()
+	d.MaxExecutions = state.MaxExecutions.Uint64()
+	return nil
+}
+
 func (d *DCAStrategy) GetType() string {
 	return "DCA"
 }
@@ -115,6 +166,22 @@ func (d *DCAStrategy) GetID() uint64 {
 	return d.ID
 }
 
+func (d *DCAStrategy) GetChainID() uint64 {
+	return d.ChainID
+}
+
+// NextRun is when the next purchase is due
+func (d *DCAStrategy) NextRun() time.Time {
+	if !d.Active {
+		return time.Time{}
+	}
+	return d.LastExecution.Add(time.Duration(d.IntervalSeconds) * time.Second)
+}
+
+func (d *DCAStrategy) GetGasPolicy() *GasPolicy {
+	return d.GasPolicy
+}
+
 // GridStrategy implements Grid Trading
 type GridStrategy struct {
 	ID              uint64
@@ -125,9 +192,17 @@ type GridStrategy struct {
 	BasePrice       *big.Int
 	GridLevels      map[uint64]bool
 	Active          bool
+	ChainID         uint64 // chain of the smart account the strategy executes through
 	client          *ethclient.Client
 	contractAddress common.Address
 	auth            *bind.TransactOpts
+	SlippageBps     uint32                        // tolerance on each swap; zero uses dex.DefaultSlippageBps
+	Swapper         Swapper                       // builds the swaps; required to execute
+	Signer          bridge.Sender                 // signs the smart account's calls; required to execute
+	Approver        Approver                      // adds approvals of the quoted spender to the swap batch
+	Notifier        notify.Notifier               // optional; receives slippage alerts
+	OnChain         *contracts.SmartAccountClient // optional; SmartAccountV2 holding the grid parameters
+	tradeReporting
 }
 
 func NewGridStrategy(
@@ -155,6 +230,12 @@ func NewGridStrategy(
 }
 
 func (g *GridStrategy) ShouldExecute(ctx context.Context) (bool, error) {
+	if g.OnChain != nil {
+		if err := g.syncOnChain(ctx); err != nil {
+			return false, err
+		}
+	}
+
 	if !g.Active {
 		return false, nil
 	}
@@ -200,12 +281,47 @@ func (g *GridStrategy) Execute(ctx context.Context) error {
 	// Calculate trade amount (simplified - could be more sophisticated)
 	tradeAmount := new(big.Int).SetUint64(1000000000000000000) // 1 token
 
-	quote, err := GetSwapQuote(tokenIn.Hex(), tokenOut.Hex(), tradeAmount.String())
+	result, err := ExecuteSwapThroughSmartAccount(ctx, g.client, g.Swapper, g.Approver, g.Signer, g.Notifier, g.contractAddress, &dex.QuoteRequest{
+		ChainID:     g.ChainID,
+		TokenIn:     tokenIn,
+		TokenOut:    tokenOut,
+		AmountIn:    tradeAmount,
+		SlippageBps: g.SlippageBps,
+	})
 	if err != nil {
 		return err
 	}
+	g.reportTrade(ctx, g.ID, g.GetType(), result)
+	return nil
+}
 
-	return ExecuteSwapThroughSmartAccount(ctx, g.client, g.contractAddress, g.auth, quote)
+// syncOnChain picks up a pause and the grid parameters stored in the contract.
+// The contract has no grid execute function, so fills are only tracked locally.
+func (g *GridStrategy) syncOnChain(ctx context.Context) error {
+	state, err := g.OnChain.GridStrategy(ctx, g.ID)
+	if err != nil {
+		return fmt.Errorf("failed to read grid strategy #%d: %v", g.ID, err)
+	}
+	wasActive := g.Active
+	g.Active = state.Active
+	g.TokenA = state.TokenA
+	g.TokenB = state.TokenB
+	g.GridSize = state.GridSize.Uint64()
+	g.PriceStep = state.PriceStep
+	g.BasePrice = state.BasePrice
+	if g.Active && !validPriceStep(g.PriceStep) {
+		g.Active = false
+		if wasActive {
+			log.Printf("❌ Grid strategy #%d on chain %d has price step %v, deactivating it", g.ID, g.ChainID, g.PriceStep)
+		}
+	}
+	return nil
+}
+
+// validPriceStep reports whether a grid's price step can divide price
+// differences; a grid created directly on the contract may have none
+func validPriceStep(step *big.Int) bool {
+	return step != nil && step.Sign() > 0
 }
 
 func (g *GridStrategy) GetType() string {
@@ -216,18 +332,24 @@ func (g *GridStrategy) GetID() uint64 {
 	return g.ID
 }
 
+func (g *GridStrategy) GetChainID() uint64 {
+	return g.ChainID
+}
+
 // RebalanceStrategy implements Portfolio Rebalancing
 type RebalanceStrategy struct {
-	ID                   uint64
-	Tokens               []common.Address
-	TargetPercentages    []uint64 // basis points
-	RebalanceThreshold   uint64   // percentage deviation to trigger
-	MinInterval          time.Duration
-	LastRebalance        time.Time
-	Active               bool
-	client               *ethclient.Client
-	contractAddress      common.Address
-	auth                 *bind.TransactOpts
+	ID                 uint64
+	Tokens             []common.Address
+	TargetPercentages  []uint64 // basis points
+	RebalanceThreshold uint64   // percentage deviation to trigger
+	MinInterval        time.Duration
+	LastRebalance      time.Time
+	Active             bool
+	ChainID            uint64 // chain of the smart account the strategy executes through
+	client             *ethclient.Client
+	contractAddress    common.Address
+	auth               *bind.TransactOpts
+	tradeReporting
 }
 
 func NewRebalanceStrategy(
@@ -336,6 +458,18 @@ func (r *RebalanceStrategy) GetID() uint64 {
 	return r.ID
 }
 
+// NextRun is the earliest the portfolio may be rebalanced again; after that its drift is polled
+func (r *RebalanceStrategy) NextRun() time.Time {
+	if !r.Active {
+		return time.Time{}
+	}
+	return r.LastRebalance.Add(r.MinInterval)
+}
+
+func (r *RebalanceStrategy) GetChainID() uint64 {
+	return r.ChainID
+}
+
 // Helper functions (these would be implemented based on your DEX integration)
 
 func GetCurrentPrice(tokenA, tokenB common.Address) (*big.Int, error) {
@@ -353,24 +487,103 @@ func GetPortfolioBalances(tokens []common.Address, account common.Address) ([]*b
 	return balances, nil
 }
 
-func ExecuteSwapThroughSmartAccount(ctx context.Context, client *ethclient.Client, contractAddress common.Address, auth *bind.TransactOpts, quote *SwapQuote) error {
-	// This would execute the swap through the Smart Account
-	log.Printf("💱 Executing swap through Smart Account: %s", contractAddress.Hex())
-	return nil
+// Approver grants a swap's spender an allowance of the smart account's tokens,
+// e.g. a *multichain.AllowanceManager. AddApprovals appends the approvals to
+// the swap's batch and returns a func that records them once it is mined.
+type Approver interface {
+	AddApprovals(ctx context.Context, batch *contracts.Batch, chainID uint64, token, spender common.Address, amount *big.Int) (func(), error)
 }
 
-// SwapQuote represents a swap quote from DEX
-type SwapQuote struct {
-	Price string `json:"price"`
-	To    string `json:"to"`
-	Data  string `json:"data"`
+// Swapper builds the calldata of a swap for an exact input amount, e.g. a *dex.Router
+type Swapper interface {
+	Swap(ctx context.Context, req *dex.QuoteRequest) (*dex.Quote, error)
 }
 
-func GetSwapQuote(tokenIn, tokenOut, amount string) (*SwapQuote, error) {
-	// This would integrate with your existing OKX DEX API
-	return &SwapQuote{
-		Price: "1.0",
-		To:    "0x1234567890abcdef1234567890abcdef12345678",
-		Data:  "0x",
+// ExecuteSwapThroughSmartAccount swaps from the smart account with calldata
+// built by swapper. The swap is sent through executeCalls, which forwards the
+// value of native-input swaps, so the account must be a SmartAccountV2. When
+// the quote's spender may not spend an ERC-20 input yet, the approval runs
+// ahead of the swap in the same batch. Calls in after run in the batch too, so
+// they revert with the swap. The amount out
+// is what the receipt shows the account received; shortfalls beyond the
+// slippage tolerance are sent to notifier.
+func ExecuteSwapThroughSmartAccount(ctx context.Context, client *ethclient.Client, swapper Swapper, approver Approver, signer bridge.Sender, notifier notify.Notifier, account common.Address, req *dex.QuoteRequest, after ...contracts.SmartAccountV2Call) (*SwapResult, error) {
+	if swapper == nil || signer == nil {
+		return nil, fmt.Errorf("no DEX router or signer configured")
+	}
+	log.Printf("💱 Executing swap through Smart Account: %s", account.Hex())
+
+	req.From = account
+	quote, err := swapper.Swap(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get swap: %v", err)
+	}
+	if quote.Tx == nil {
+		return nil, fmt.Errorf("%s returned no swap transaction", quote.Venue)
+	}
+	if err := dex.CheckMinReceive(quote, req.Slippage()); err != nil {
+		return nil, err
+	}
+
+	batch := contracts.NewBatch()
+	recordApprovals := func() {}
+	if req.TokenIn != dex.NativeToken && quote.Spender != (common.Address{}) {
+		if approver == nil {
+			return nil, fmt.Errorf("swapping %s needs an approval of %s but no approver is configured", req.TokenIn.Hex(), quote.Spender.Hex())
+		}
+		recordApprovals, err = approver.AddApprovals(ctx, batch, req.ChainID, req.TokenIn, quote.Spender, req.AmountIn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to approve swap: %v", err)
+		}
+	}
+
+	batch.Add(quote.Tx.To, quote.Tx.Value, quote.Tx.Data)
+	batch.Calls = append(batch.Calls, after...)
+	data, err := batch.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack swap: %v", err)
+	}
+	receipt, err := signer.Send(ctx, req.ChainID, account, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s swap failed: %v", quote.Venue, err)
+	}
+	recordApprovals()
+
+	received, err := amountReceived(ctx, client, receipt, req.TokenOut, account)
+	if err != nil {
+		return nil, fmt.Errorf("swap %s mined but its output could not be measured: %v", receipt.TxHash.Hex(), err)
+	}
+	if received.Cmp(quote.MinAmountOut) < 0 {
+		notify.Alertf(ctx, notifier, "%s swap %s on chain %d received %s, below its minimum of %s",
+			quote.Venue, receipt.TxHash.Hex(), req.ChainID, received, quote.MinAmountOut)
+	} else if realized := dex.RealizedSlippageBps(quote.AmountOut, received); realized > int64(req.Slippage()) {
+		notify.Alertf(ctx, notifier, "Realized slippage of %d bps on %s swap %s exceeds the %d bps limit: expected %s, received %s",
+			realized, quote.Venue, receipt.TxHash.Hex(), req.Slippage(), quote.AmountOut, received)
+	}
+
+	return &SwapResult{
+		ChainID:   req.ChainID,
+		TxHash:    receipt.TxHash,
+		TokenIn:   req.TokenIn,
+		TokenOut:  req.TokenOut,
+		AmountIn:  req.AmountIn,
+		AmountOut: received,
 	}, nil
 }
+
+// amountReceived measures what account received in a swap from the receipt's
+// Transfer logs, or from its balance change across the block for native output
+func amountReceived(ctx context.Context, client *ethclient.Client, receipt *types.Receipt, token, account common.Address) (*big.Int, error) {
+	if token != dex.NativeToken {
+		return dex.AmountReceived(receipt, token, account), nil
+	}
+	after, err := client.BalanceAt(ctx, account, receipt.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	before, err := client.BalanceAt(ctx, account, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Sub(after, before), nil
+}
@@ -0,0 +1,95 @@
+package userop
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Bundler is a client of the ERC-4337 bundler JSON-RPC API for one chain
+type Bundler struct {
+	EntryPoint common.Address
+	rpc        *rpc.Client
+}
+
+func DialBundler(ctx context.Context, url string) (*Bundler, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to bundler %s: %v", url, err)
+	}
+	return &Bundler{EntryPoint: EntryPointV07, rpc: client}, nil
+}
+
+// GasEstimate is the result of eth_estimateUserOperationGas
+type GasEstimate struct {
+	PreVerificationGas            *hexutil.Big `json:"preVerificationGas"`
+	VerificationGasLimit          *hexutil.Big `json:"verificationGasLimit"`
+	CallGasLimit                  *hexutil.Big `json:"callGasLimit"`
+	PaymasterVerificationGasLimit *hexutil.Big `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       *hexutil.Big `json:"paymasterPostOpGasLimit,omitempty"`
+}
+
+// Apply copies the estimated limits into op
+func (e *GasEstimate) Apply(op *UserOperation) {
+	op.PreVerificationGas = (*big.Int)(e.PreVerificationGas)
+	op.VerificationGasLimit = (*big.Int)(e.VerificationGasLimit)
+	op.CallGasLimit = (*big.Int)(e.CallGasLimit)
+	if op.Paymaster != nil {
+		op.PaymasterVerificationGasLimit = (*big.Int)(e.PaymasterVerificationGasLimit)
+		op.PaymasterPostOpGasLimit = (*big.Int)(e.PaymasterPostOpGasLimit)
+	}
+}
+
+// OperationReceipt is the part of eth_getUserOperationReceipt the agent uses
+type OperationReceipt struct {
+	UserOpHash    common.Hash  `json:"userOpHash"`
+	Success       bool         `json:"success"`
+	Reason        string       `json:"reason"`
+	ActualGasCost *hexutil.Big `json:"actualGasCost"`
+	Receipt       struct {
+		TransactionHash common.Hash `json:"transactionHash"`
+	} `json:"receipt"`
+}
+
+// SupportedEntryPoints lists the EntryPoints the bundler accepts operations for
+func (b *Bundler) SupportedEntryPoints(ctx context.Context) ([]common.Address, error) {
+	var entryPoints []common.Address
+	if err := b.rpc.CallContext(ctx, &entryPoints, "eth_supportedEntryPoints"); err != nil {
+		return nil, fmt.Errorf("eth_supportedEntryPoints failed: %v", err)
+	}
+	return entryPoints, nil
+}
+
+// EstimateGas estimates the gas limits of op; its signature only has to be well-formed
+func (b *Bundler) EstimateGas(ctx context.Context, op *UserOperation) (*GasEstimate, error) {
+	var estimate GasEstimate
+	if err := b.rpc.CallContext(ctx, &estimate, "eth_estimateUserOperationGas", op, b.EntryPoint); err != nil {
+		return nil, fmt.Errorf("eth_estimateUserOperationGas failed: %v", err)
+	}
+	if estimate.PreVerificationGas == nil || estimate.VerificationGasLimit == nil || estimate.CallGasLimit == nil {
+		return nil, fmt.Errorf("bundler returned an incomplete gas estimate")
+	}
+	return &estimate, nil
+}
+
+// Send submits a signed operation and returns the userOpHash the bundler computed
+func (b *Bundler) Send(ctx context.Context, op *UserOperation) (common.Hash, error) {
+	var hash common.Hash
+	if err := b.rpc.CallContext(ctx, &hash, "eth_sendUserOperation", op, b.EntryPoint); err != nil {
+		return common.Hash{}, fmt.Errorf("eth_sendUserOperation failed: %v", err)
+	}
+	return hash, nil
+}
+
+// Receipt returns the receipt of an included operation, or nil while it is pending
+func (b *Bundler) Receipt(ctx context.Context, hash common.Hash) (*OperationReceipt, error) {
+	var receipt *OperationReceipt
+	if err := b.rpc.CallContext(ctx, &receipt, "eth_getUserOperationReceipt", hash); err != nil {
+		return nil, fmt.Errorf("eth_getUserOperationReceipt failed: %v", err)
+	}
+	return receipt, nil
+}
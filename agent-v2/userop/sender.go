@@ -0,0 +1,197 @@
+package userop
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"agent/bridge"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// dummySignature is a well-formed signature bundlers can simulate validation with before the real one exists
+var dummySignature = hexutil.MustDecode("0xfffffffffffffffffffffffffffffff0000000000000000000000000000000007aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1c")
+
+// Signer signs userOpHashes as EIP-191 personal messages, which is what
+// SmartAccountV2.validateUserOp recovers. session.Manager implements it with
+// the current session key.
+type Signer interface {
+	Address() common.Address
+	SignHash(hash common.Hash) ([]byte, error)
+}
+
+// KeySigner signs with a fixed private key, e.g. the owner's
+type KeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func NewKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{key: key}
+}
+
+func (s *KeySigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+func (s *KeySigner) SignHash(hash common.Hash) ([]byte, error) {
+	return SignHash(s.key, hash)
+}
+
+// SignHash produces the EIP-191 personal signature of hash with a 27/28 recovery byte
+func SignHash(key *ecdsa.PrivateKey, hash common.Hash) ([]byte, error) {
+	signature, err := crypto.Sign(accounts.TextHash(hash.Bytes()), key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+// Sender submits the calls it is given as UserOperations of the smart account
+// they are addressed to. It replaces the EOA signer of a bridge.SmartAccountSender:
+// the execute calldata becomes the operation's callData, and the account pays
+// for gas from its EntryPoint deposit or balance unless a paymaster sponsors it.
+type Sender struct {
+	Paymaster     *common.Address // optional sponsor of every operation
+	PaymasterData []byte
+	PollInterval  time.Duration
+	signer        Signer
+	clients       bridge.ClientSource
+	bundlers      map[uint64]*Bundler
+}
+
+func NewSender(signer Signer, clients bridge.ClientSource, bundlers map[uint64]*Bundler) *Sender {
+	return &Sender{
+		PollInterval: 2 * time.Second,
+		signer:       signer,
+		clients:      clients,
+		bundlers:     bundlers,
+	}
+}
+
+// Address is the key signing the operations
+func (s *Sender) Address() common.Address {
+	return s.signer.Address()
+}
+
+// Send wraps data into a UserOperation of the account at to, submits it and
+// waits for the bundler to include it. The returned receipt is that of the
+// bundle transaction, which also carries the operation's logs.
+func (s *Sender) Send(ctx context.Context, chainID uint64, to common.Address, data []byte, value *big.Int) (*types.Receipt, error) {
+	if value != nil && value.Sign() != 0 {
+		return nil, fmt.Errorf("a UserOperation cannot carry value")
+	}
+	bundler, exists := s.bundlers[chainID]
+	if !exists {
+		return nil, fmt.Errorf("no bundler configured for chain %d", chainID)
+	}
+	client, err := s.clients(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.build(ctx, client, bundler, chainID, to, data)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := op.Hash(bundler.EntryPoint, chainID)
+	op.Signature, err = s.signer.SignHash(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign UserOperation: %v", err)
+	}
+
+	submitted, err := bundler.Send(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+	if submitted != hash {
+		return nil, fmt.Errorf("bundler returned userOpHash %s, expected %s", submitted.Hex(), hash.Hex())
+	}
+	log.Printf("📨 UserOperation %s submitted for %s on chain %d", hash.Hex(), to.Hex(), chainID)
+
+	opReceipt, err := s.wait(ctx, bundler, hash)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := client.TransactionReceipt(ctx, opReceipt.Receipt.TransactionHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt of %s: %v", opReceipt.Receipt.TransactionHash.Hex(), err)
+	}
+	if !opReceipt.Success {
+		return receipt, fmt.Errorf("UserOperation %s reverted: %s", hash.Hex(), opReceipt.Reason)
+	}
+	return receipt, nil
+}
+
+// build fills in the nonce, fees, paymaster and the bundler's gas estimate
+func (s *Sender) build(ctx context.Context, client *ethclient.Client, bundler *Bundler, chainID uint64, account common.Address, data []byte) (*UserOperation, error) {
+	nonceData, _ := EntryPointABI.Pack("getNonce", account, big.NewInt(0))
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &bundler.EntryPoint, Data: nonceData}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get UserOperation nonce: %v", err)
+	}
+	if len(output) != 32 {
+		return nil, fmt.Errorf("no EntryPoint at %s on chain %d", bundler.EntryPoint.Hex(), chainID)
+	}
+
+	tip, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest gas tip: %v", err)
+	}
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %v", err)
+	}
+	maxFee := new(big.Int).Set(tip)
+	if head.BaseFee != nil {
+		maxFee.Add(maxFee, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+	}
+
+	op := &UserOperation{
+		Sender:               account,
+		Nonce:                new(big.Int).SetBytes(output),
+		CallData:             data,
+		MaxFeePerGas:         maxFee,
+		MaxPriorityFeePerGas: tip,
+		Paymaster:            s.Paymaster,
+		PaymasterData:        s.PaymasterData,
+		Signature:            dummySignature,
+	}
+	estimate, err := bundler.EstimateGas(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+	estimate.Apply(op)
+	return op, nil
+}
+
+func (s *Sender) wait(ctx context.Context, bundler *Bundler, hash common.Hash) (*OperationReceipt, error) {
+	ticker := time.NewTicker(s.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := bundler.Receipt(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			return receipt, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("UserOperation %s not included: %v", hash.Hex(), ctx.Err())
+		}
+	}
+}
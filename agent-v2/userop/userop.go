@@ -0,0 +1,234 @@
+// Package userop submits smart account calls as ERC-4337 UserOperations
+// through a bundler, as an alternative to sending them from an EOA.
+package userop
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EntryPointV07 is the canonical ERC-4337 v0.7 EntryPoint, deployed at the same address on every chain
+var EntryPointV07 = common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+
+const entryPointABI = `[
+	{"inputs":[{"internalType":"address","name":"sender","type":"address"},{"internalType":"uint192","name":"key","type":"uint192"}],"name":"getNonce","outputs":[{"internalType":"uint256","name":"nonce","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"components":[{"internalType":"address","name":"sender","type":"address"},{"internalType":"uint256","name":"nonce","type":"uint256"},{"internalType":"bytes","name":"initCode","type":"bytes"},{"internalType":"bytes","name":"callData","type":"bytes"},{"internalType":"bytes32","name":"accountGasLimits","type":"bytes32"},{"internalType":"uint256","name":"preVerificationGas","type":"uint256"},{"internalType":"bytes32","name":"gasFees","type":"bytes32"},{"internalType":"bytes","name":"paymasterAndData","type":"bytes"},{"internalType":"bytes","name":"signature","type":"bytes"}],"internalType":"struct PackedUserOperation[]","name":"ops","type":"tuple[]"},{"internalType":"address payable","name":"beneficiary","type":"address"}],"name":"handleOps","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"userOpHash","type":"bytes32"},{"indexed":true,"internalType":"address","name":"sender","type":"address"},{"indexed":true,"internalType":"address","name":"paymaster","type":"address"},{"indexed":false,"internalType":"uint256","name":"nonce","type":"uint256"},{"indexed":false,"internalType":"bool","name":"success","type":"bool"},{"indexed":false,"internalType":"uint256","name":"actualGasCost","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"actualGasUsed","type":"uint256"}],"name":"UserOperationEvent","type":"event"}
+]`
+
+var (
+	// EntryPointABI covers the EntryPoint methods and events the agent and the local bundler use
+	EntryPointABI = mustParseABI(entryPointABI)
+
+	// UserOperationEventTopic is the topic0 of the EntryPoint's UserOperationEvent
+	UserOperationEventTopic = EntryPointABI.Events["UserOperationEvent"].ID
+
+	// packArgs is the field layout hashed into a userOpHash
+	packArgs = mustArguments("address", "uint256", "bytes32", "bytes32", "bytes32", "uint256", "bytes32", "bytes32")
+	hashArgs = mustArguments("bytes32", "address", "uint256")
+)
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("invalid ABI: %v", err))
+	}
+	return parsed
+}
+
+func mustArguments(types ...string) abi.Arguments {
+	arguments := make(abi.Arguments, 0, len(types))
+	for _, name := range types {
+		typ, err := abi.NewType(name, "", nil)
+		if err != nil {
+			panic(fmt.Sprintf("invalid ABI type %s: %v", name, err))
+		}
+		arguments = append(arguments, abi.Argument{Type: typ})
+	}
+	return arguments
+}
+
+// UserOperation is an EntryPoint v0.7 UserOperation in the unpacked form bundlers accept over RPC
+type UserOperation struct {
+	Sender                        common.Address
+	Nonce                         *big.Int
+	Factory                       *common.Address // nil once the account is deployed
+	FactoryData                   []byte
+	CallData                      []byte
+	CallGasLimit                  *big.Int
+	VerificationGasLimit          *big.Int
+	PreVerificationGas            *big.Int
+	MaxFeePerGas                  *big.Int
+	MaxPriorityFeePerGas          *big.Int
+	Paymaster                     *common.Address // nil when the account pays its own gas
+	PaymasterVerificationGasLimit *big.Int
+	PaymasterPostOpGasLimit       *big.Int
+	PaymasterData                 []byte
+	Signature                     []byte
+}
+
+// PackedUserOperation is the on-chain form EntryPoint.handleOps takes
+type PackedUserOperation struct {
+	Sender             common.Address
+	Nonce              *big.Int
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   [32]byte
+	PreVerificationGas *big.Int
+	GasFees            [32]byte
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+// Pack converts the operation to its on-chain form
+func (op *UserOperation) Pack() PackedUserOperation {
+	var initCode []byte
+	if op.Factory != nil {
+		initCode = append(op.Factory.Bytes(), op.FactoryData...)
+	}
+	var paymasterAndData []byte
+	if op.Paymaster != nil {
+		paymasterAndData = append(op.Paymaster.Bytes(), packUint128s(op.PaymasterVerificationGasLimit, op.PaymasterPostOpGasLimit)...)
+		paymasterAndData = append(paymasterAndData, op.PaymasterData...)
+	}
+
+	var accountGasLimits, gasFees [32]byte
+	copy(accountGasLimits[:], packUint128s(op.VerificationGasLimit, op.CallGasLimit))
+	copy(gasFees[:], packUint128s(op.MaxPriorityFeePerGas, op.MaxFeePerGas))
+
+	return PackedUserOperation{
+		Sender:             op.Sender,
+		Nonce:              orZero(op.Nonce),
+		InitCode:           initCode,
+		CallData:           op.CallData,
+		AccountGasLimits:   accountGasLimits,
+		PreVerificationGas: orZero(op.PreVerificationGas),
+		GasFees:            gasFees,
+		PaymasterAndData:   paymasterAndData,
+		Signature:          op.Signature,
+	}
+}
+
+// Hash is the userOpHash the account's signer signs, bound to an EntryPoint and chain
+func (op *UserOperation) Hash(entryPoint common.Address, chainID uint64) common.Hash {
+	packed := op.Pack()
+	encoded, err := packArgs.Pack(
+		packed.Sender,
+		packed.Nonce,
+		crypto.Keccak256Hash(packed.InitCode),
+		crypto.Keccak256Hash(packed.CallData),
+		packed.AccountGasLimits,
+		packed.PreVerificationGas,
+		packed.GasFees,
+		crypto.Keccak256Hash(packed.PaymasterAndData),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("failed to pack UserOperation: %v", err))
+	}
+	encoded, err = hashArgs.Pack(crypto.Keccak256Hash(encoded), entryPoint, new(big.Int).SetUint64(chainID))
+	if err != nil {
+		panic(fmt.Sprintf("failed to pack userOpHash: %v", err))
+	}
+	return crypto.Keccak256Hash(encoded)
+}
+
+// packUint128s concatenates two values as big-endian uint128s
+func packUint128s(high, low *big.Int) []byte {
+	packed := make([]byte, 32)
+	orZero(high).FillBytes(packed[:16])
+	orZero(low).FillBytes(packed[16:])
+	return packed
+}
+
+func orZero(value *big.Int) *big.Int {
+	if value == nil {
+		return new(big.Int)
+	}
+	return value
+}
+
+// rpcUserOperation is the JSON encoding of a UserOperation in the bundler RPC API
+type rpcUserOperation struct {
+	Sender                        common.Address  `json:"sender"`
+	Nonce                         *hexutil.Big    `json:"nonce"`
+	Factory                       *common.Address `json:"factory,omitempty"`
+	FactoryData                   *hexutil.Bytes  `json:"factoryData,omitempty"`
+	CallData                      hexutil.Bytes   `json:"callData"`
+	CallGasLimit                  *hexutil.Big    `json:"callGasLimit"`
+	VerificationGasLimit          *hexutil.Big    `json:"verificationGasLimit"`
+	PreVerificationGas            *hexutil.Big    `json:"preVerificationGas"`
+	MaxFeePerGas                  *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas          *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Paymaster                     *common.Address `json:"paymaster,omitempty"`
+	PaymasterVerificationGasLimit *hexutil.Big    `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       *hexutil.Big    `json:"paymasterPostOpGasLimit,omitempty"`
+	PaymasterData                 *hexutil.Bytes  `json:"paymasterData,omitempty"`
+	Signature                     hexutil.Bytes   `json:"signature"`
+}
+
+func (op *UserOperation) MarshalJSON() ([]byte, error) {
+	encoded := rpcUserOperation{
+		Sender:               op.Sender,
+		Nonce:                (*hexutil.Big)(orZero(op.Nonce)),
+		Factory:              op.Factory,
+		CallData:             op.CallData,
+		CallGasLimit:         (*hexutil.Big)(orZero(op.CallGasLimit)),
+		VerificationGasLimit: (*hexutil.Big)(orZero(op.VerificationGasLimit)),
+		PreVerificationGas:   (*hexutil.Big)(orZero(op.PreVerificationGas)),
+		MaxFeePerGas:         (*hexutil.Big)(orZero(op.MaxFeePerGas)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(orZero(op.MaxPriorityFeePerGas)),
+		Signature:            op.Signature,
+	}
+	// Bundlers expect the data fields, possibly "0x", whenever the address is set
+	if op.Factory != nil {
+		factoryData := hexutil.Bytes(op.FactoryData)
+		encoded.FactoryData = &factoryData
+	}
+	if op.Paymaster != nil {
+		encoded.Paymaster = op.Paymaster
+		encoded.PaymasterVerificationGasLimit = (*hexutil.Big)(orZero(op.PaymasterVerificationGasLimit))
+		encoded.PaymasterPostOpGasLimit = (*hexutil.Big)(orZero(op.PaymasterPostOpGasLimit))
+		paymasterData := hexutil.Bytes(op.PaymasterData)
+		encoded.PaymasterData = &paymasterData
+	}
+	return json.Marshal(&encoded)
+}
+
+func (op *UserOperation) UnmarshalJSON(data []byte) error {
+	var decoded rpcUserOperation
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	var factoryData, paymasterData []byte
+	if decoded.FactoryData != nil {
+		factoryData = *decoded.FactoryData
+	}
+	if decoded.PaymasterData != nil {
+		paymasterData = *decoded.PaymasterData
+	}
+	*op = UserOperation{
+		Sender:                        decoded.Sender,
+		Nonce:                         (*big.Int)(decoded.Nonce),
+		Factory:                       decoded.Factory,
+		FactoryData:                   factoryData,
+		CallData:                      decoded.CallData,
+		CallGasLimit:                  (*big.Int)(decoded.CallGasLimit),
+		VerificationGasLimit:          (*big.Int)(decoded.VerificationGasLimit),
+		PreVerificationGas:            (*big.Int)(decoded.PreVerificationGas),
+		MaxFeePerGas:                  (*big.Int)(decoded.MaxFeePerGas),
+		MaxPriorityFeePerGas:          (*big.Int)(decoded.MaxPriorityFeePerGas),
+		Paymaster:                     decoded.Paymaster,
+		PaymasterVerificationGasLimit: (*big.Int)(decoded.PaymasterVerificationGasLimit),
+		PaymasterPostOpGasLimit:       (*big.Int)(decoded.PaymasterPostOpGasLimit),
+		PaymasterData:                 paymasterData,
+		Signature:                     decoded.Signature,
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package userop
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// recordedOp is the operation of go-ethereum's erc7562Tracer.test_simple trace,
+// sent to the v0.7 EntryPoint on chain 1337. The EntryPoint passed it to the
+// account's validateUserOp with recordedHash.
+func recordedOp() *UserOperation {
+	return &UserOperation{
+		Sender:               common.HexToAddress("0x8C9d927336adc963536122F8E0D269319e79ed7A"),
+		Nonce:                big.NewInt(0),
+		CallData:             hexutil.MustDecode("0xa9e966b7000000000000000000000000000000000000000000000000000000000010f447"),
+		CallGasLimit:         big.NewInt(300000),
+		VerificationGasLimit: big.NewInt(1000000),
+		PreVerificationGas:   big.NewInt(300000),
+		MaxFeePerGas:         big.NewInt(4000000000),
+		MaxPriorityFeePerGas: big.NewInt(3000000000),
+		Signature:            hexutil.MustDecode("0xface"),
+	}
+}
+
+var recordedHash = common.HexToHash("0x88a9b2626e43da02f978ae6cc89feffb68afcd5860cb9239337352db4b694fe1")
+
+func TestHash(t *testing.T) {
+	cases := []struct {
+		name       string
+		modify     func(op *UserOperation)
+		entryPoint common.Address
+		chainID    uint64
+		matches    bool
+	}{
+		{name: "recorded vector", entryPoint: EntryPointV07, chainID: 1337, matches: true},
+		{name: "signature is not hashed", modify: func(op *UserOperation) { op.Signature = nil }, entryPoint: EntryPointV07, chainID: 1337, matches: true},
+		{name: "nil nonce is zero", modify: func(op *UserOperation) { op.Nonce = nil }, entryPoint: EntryPointV07, chainID: 1337, matches: true},
+		{name: "other chain", entryPoint: EntryPointV07, chainID: 1, matches: false},
+		{name: "other EntryPoint", entryPoint: common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"), chainID: 1337, matches: false},
+		{name: "gas limits are packed in order", modify: func(op *UserOperation) {
+			op.CallGasLimit, op.VerificationGasLimit = op.VerificationGasLimit, op.CallGasLimit
+		}, entryPoint: EntryPointV07, chainID: 1337, matches: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			op := recordedOp()
+			if c.modify != nil {
+				c.modify(op)
+			}
+			hash := op.Hash(c.entryPoint, c.chainID)
+			if (hash == recordedHash) != c.matches {
+				t.Errorf("hash %s, recorded %s, expected match %v", hash.Hex(), recordedHash.Hex(), c.matches)
+			}
+		})
+	}
+}
+
+func TestPack(t *testing.T) {
+	factory := common.HexToAddress("0xFAc7000000000000000000000000000000000001")
+	paymaster := common.HexToAddress("0x9a70000000000000000000000000000000000002")
+	cases := []struct {
+		name             string
+		modify           func(op *UserOperation)
+		initCode         string
+		paymasterAndData string
+	}{
+		{name: "deployed account paying its own gas", initCode: "0x", paymasterAndData: "0x"},
+		{
+			name: "undeployed account",
+			modify: func(op *UserOperation) {
+				op.Factory = &factory
+				op.FactoryData = hexutil.MustDecode("0x1234")
+			},
+			initCode:         "0xfac70000000000000000000000000000000000011234",
+			paymasterAndData: "0x",
+		},
+		{
+			name: "paymaster",
+			modify: func(op *UserOperation) {
+				op.Paymaster = &paymaster
+				op.PaymasterVerificationGasLimit = big.NewInt(0x10000)
+				op.PaymasterPostOpGasLimit = big.NewInt(0x20)
+				op.PaymasterData = hexutil.MustDecode("0xbeef")
+			},
+			initCode: "0x",
+			paymasterAndData: "0x9a70000000000000000000000000000000000002" +
+				"00000000000000000000000000010000" + "00000000000000000000000000000020" + "beef",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			op := recordedOp()
+			if c.modify != nil {
+				c.modify(op)
+			}
+			packed := op.Pack()
+
+			if got := hexutil.Encode(packed.InitCode); got != c.initCode {
+				t.Errorf("initCode %s, expected %s", got, c.initCode)
+			}
+			if got := hexutil.Encode(packed.PaymasterAndData); got != c.paymasterAndData {
+				t.Errorf("paymasterAndData %s, expected %s", got, c.paymasterAndData)
+			}
+
+			// verificationGasLimit and maxPriorityFeePerGas take the high 128 bits
+			accountGasLimits := hexutil.MustDecode("0x000000000000000000000000000f4240000000000000000000000000000493e0")
+			if !bytes.Equal(packed.AccountGasLimits[:], accountGasLimits) {
+				t.Errorf("accountGasLimits %x, expected %x", packed.AccountGasLimits, accountGasLimits)
+			}
+			gasFees := hexutil.MustDecode("0x000000000000000000000000b2d05e00000000000000000000000000ee6b2800")
+			if !bytes.Equal(packed.GasFees[:], gasFees) {
+				t.Errorf("gasFees %x, expected %x", packed.GasFees, gasFees)
+			}
+		})
+	}
+}
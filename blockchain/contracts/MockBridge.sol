@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.20;
+
+import "@openzeppelin/contracts/token/ERC20/IERC20.sol";
+import "@openzeppelin/contracts/token/ERC20/utils/SafeERC20.sol";
+
+/// @notice Lock-and-release bridge for local testing. Deploy one instance per
+/// chain; a trusted relayer releases on the destination what was deposited on
+/// the source. Never use it with real funds.
+contract MockBridge {
+    using SafeERC20 for IERC20;
+
+    address public constant NATIVE = 0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE;
+
+    address public relayer;
+    uint256 public nextDepositId;
+    mapping(bytes32 => bool) public released;
+
+    event Deposited(
+        uint256 indexed depositId,
+        address indexed token,
+        address indexed recipient,
+        uint256 amount,
+        uint256 destinationChainId,
+        address sender
+    );
+    event Released(bytes32 indexed transferId, address indexed token, address indexed recipient, uint256 amount);
+
+    constructor(address _relayer) {
+        relayer = _relayer;
+    }
+
+    function deposit(address token, uint256 amount, uint256 destinationChainId, address recipient)
+        external
+        payable
+        returns (uint256 depositId)
+    {
+        if (token == NATIVE) {
+            require(msg.value == amount, "Wrong value");
+        } else {
+            require(msg.value == 0, "Unexpected value");
+            IERC20(token).safeTransferFrom(msg.sender, address(this), amount);
+        }
+
+        depositId = nextDepositId++;
+        emit Deposited(depositId, token, recipient, amount, destinationChainId, msg.sender);
+    }
+
+    function transferId(uint256 sourceChainId, uint256 depositId) public pure returns (bytes32) {
+        return keccak256(abi.encode(sourceChainId, depositId));
+    }
+
+    function release(uint256 sourceChainId, uint256 depositId, address token, address recipient, uint256 amount)
+        external
+    {
+        require(msg.sender == relayer, "Not relayer");
+        bytes32 id = transferId(sourceChainId, depositId);
+        require(!released[id], "Already released");
+        released[id] = true;
+
+        if (token == NATIVE) {
+            (bool success,) = recipient.call{ value: amount }("");
+            require(success, "Transfer failed");
+        } else {
+            IERC20(token).safeTransfer(recipient, amount);
+        }
+        emit Released(id, token, recipient, amount);
+    }
+
+    receive() external payable { }
+}
@@ -2,6 +2,7 @@
 pragma solidity ^0.8.20;
 
 import "@openzeppelin/contracts/utils/cryptography/ECDSA.sol";
+import "@openzeppelin/contracts/utils/cryptography/MessageHashUtils.sol";
 import "@openzeppelin/contracts/utils/ReentrancyGuard.sol";
 
 contract SmartAccountV2 is ReentrancyGuard {
@@ -9,6 +10,28 @@ contract SmartAccountV2 is ReentrancyGuard {
 
     address public owner;
     mapping(address => uint256) public sessionKeys;
+
+    // ERC-4337 EntryPoint v0.7, deployed at the same address on every chain
+    address public constant ENTRY_POINT = 0x0000000071727De22E5E9d8BAf0edAc6f37da032;
+    uint256 internal constant SIG_VALIDATION_FAILED = 1;
+
+    struct PackedUserOperation {
+        address sender;
+        uint256 nonce;
+        bytes initCode;
+        bytes callData;
+        bytes32 accountGasLimits;
+        uint256 preVerificationGas;
+        bytes32 gasFees;
+        bytes paymasterAndData;
+        bytes signature;
+    }
+
+    struct Call {
+        address target;
+        uint256 value;
+        bytes data;
+    }
     
     // Advanced Trading Strategies Storage
     struct DCAStrategy {
@@ -56,6 +79,9 @@ contract SmartAccountV2 is ReentrancyGuard {
     event StrategyExecuted(uint256 indexed strategyId, address indexed executor);
     event StrategyPaused(uint256 indexed strategyId);
     event ChainAdded(uint256 indexed chainId, address contractAddress);
+    event CallExecuted(uint256 indexed index, address indexed target, bool success, bytes returnData);
+
+    error CallFailed(uint256 index, bytes returnData);
 
     constructor(address _owner) {
         owner = _owner;
@@ -75,7 +101,18 @@ contract SmartAccountV2 is ReentrancyGuard {
     
     modifier onlyAuthorized() {
         require(
-            msg.sender == owner || sessionKeys[msg.sender] > block.timestamp,
+            msg.sender == owner || msg.sender == ENTRY_POINT || sessionKeys[msg.sender] > block.timestamp,
+            "Unauthorized"
+        );
+        _;
+    }
+
+    // Also admits the account itself, so a strategy execution can be recorded
+    // in the same executeCalls batch as the swap it pays for
+    modifier onlyAuthorizedOrSelf() {
+        require(
+            msg.sender == address(this) || msg.sender == owner || msg.sender == ENTRY_POINT ||
+                sessionKeys[msg.sender] > block.timestamp,
             "Unauthorized"
         );
         _;
@@ -85,11 +122,67 @@ contract SmartAccountV2 is ReentrancyGuard {
         sessionKeys[key] = expiresAt;
     }
 
+    // === ERC-4337 ===
+
+    // Accepts UserOperations signed by the owner or a session key. Validation may
+    // not read the block timestamp, so a session key's expiry is returned as
+    // validUntil for the EntryPoint to enforce.
+    function validateUserOp(
+        PackedUserOperation calldata userOp,
+        bytes32 userOpHash,
+        uint256 missingAccountFunds
+    ) external returns (uint256 validationData) {
+        require(msg.sender == ENTRY_POINT, "Not EntryPoint");
+
+        (address signer, ECDSA.RecoverError error, ) = ECDSA.tryRecover(
+            MessageHashUtils.toEthSignedMessageHash(userOpHash),
+            userOp.signature
+        );
+        if (error != ECDSA.RecoverError.NoError) {
+            validationData = SIG_VALIDATION_FAILED;
+        } else if (signer == owner) {
+            validationData = 0;
+        } else if (sessionKeys[signer] != 0) {
+            validationData = uint256(uint48(sessionKeys[signer])) << 160;
+        } else {
+            validationData = SIG_VALIDATION_FAILED;
+        }
+
+        if (missingAccountFunds > 0) {
+            // The EntryPoint checks the deposit itself, so a failed transfer needs no handling here
+            (bool success, ) = payable(msg.sender).call{value: missingAccountFunds}("");
+            (success);
+        }
+    }
+
+    // Holds native currency to prefund UserOperations
+    receive() external payable {}
+
     function execute(address target, bytes calldata data) external onlyAuthorized nonReentrant {
         (bool success, ) = target.call(data);
         require(success, "Call failed");
     }
-    
+
+    // Executes calls in order with value from the account's balance. Unless
+    // allowFailure is set, the first failing call reverts the whole batch with
+    // its index and revert data.
+    function executeCalls(Call[] calldata calls, bool allowFailure)
+        external
+        onlyAuthorized
+        nonReentrant
+        returns (bool[] memory successes, bytes[] memory results)
+    {
+        successes = new bool[](calls.length);
+        results = new bytes[](calls.length);
+        for (uint256 i = 0; i < calls.length; i++) {
+            (successes[i], results[i]) = calls[i].target.call{value: calls[i].value}(calls[i].data);
+            if (!successes[i] && !allowFailure) {
+                revert CallFailed(i, results[i]);
+            }
+            emit CallExecuted(i, calls[i].target, successes[i], results[i]);
+        }
+    }
+
     // === DCA Strategy Management ===
     
     function createDCAStrategy(
@@ -113,7 +206,7 @@ contract SmartAccountV2 is ReentrancyGuard {
         emit StrategyCreated(strategyId, "DCA");
     }
     
-    function executeDCA(uint256 strategyId) external onlyAuthorized {
+    function executeDCA(uint256 strategyId) external onlyAuthorizedOrSelf {
         DCAStrategy storage strategy = dcaStrategies[strategyId];
         require(strategy.active, "Strategy not active");
         require(block.timestamp >= strategy.lastExecution + strategy.interval, "Too early");
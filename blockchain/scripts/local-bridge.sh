@@ -0,0 +1,60 @@
+#!/bin/bash
+
+# Local Bridge Stand-in
+# =====================
+# Starts two anvil chains and deploys MockBridge to both so cross-chain flows
+# can be exercised without real bridges. The agent relays deposits itself when
+# LOCAL_BRIDGE_ADDRESS and LOCAL_BRIDGE_RPCS are set.
+
+set -e
+
+cd "$(dirname "$0")/.."
+
+CHAIN_A_ID=${CHAIN_A_ID:-31337}
+CHAIN_B_ID=${CHAIN_B_ID:-31338}
+CHAIN_A_RPC="http://127.0.0.1:8545"
+CHAIN_B_RPC="http://127.0.0.1:8546"
+
+# First default anvil account; it deploys the bridges and acts as relayer
+DEPLOYER_KEY=${DEPLOYER_KEY:-0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80}
+RELAYER=$(cast wallet address --private-key "$DEPLOYER_KEY")
+
+echo "🚀 Starting local chains..."
+anvil --port 8545 --chain-id "$CHAIN_A_ID" --silent &
+ANVIL_A=$!
+anvil --port 8546 --chain-id "$CHAIN_B_ID" --silent &
+ANVIL_B=$!
+trap 'kill $ANVIL_A $ANVIL_B 2>/dev/null' EXIT
+sleep 2
+
+deploy() {
+    forge create \
+        --rpc-url "$1" \
+        --private-key "$DEPLOYER_KEY" \
+        --broadcast \
+        contracts/MockBridge.sol:MockBridge \
+        --constructor-args "$RELAYER" | grep "Deployed to:" | awk '{print $3}'
+}
+
+echo "🔨 Deploying MockBridge..."
+BRIDGE_A=$(deploy "$CHAIN_A_RPC")
+BRIDGE_B=$(deploy "$CHAIN_B_RPC")
+
+# Both chains start from the same deployer nonce, so the addresses match
+if [ "$BRIDGE_A" != "$BRIDGE_B" ]; then
+    echo "❌ Bridge addresses differ: $BRIDGE_A vs $BRIDGE_B"
+    exit 1
+fi
+
+echo "💰 Funding bridge liquidity..."
+cast send --rpc-url "$CHAIN_A_RPC" --private-key "$DEPLOYER_KEY" "$BRIDGE_A" --value 100ether > /dev/null
+cast send --rpc-url "$CHAIN_B_RPC" --private-key "$DEPLOYER_KEY" "$BRIDGE_B" --value 100ether > /dev/null
+
+echo ""
+echo "✅ Local bridge ready. Add to your .env:"
+echo "   LOCAL_BRIDGE_ADDRESS=$BRIDGE_A"
+echo "   LOCAL_BRIDGE_RPCS=$CHAIN_A_ID:$CHAIN_A_RPC,$CHAIN_B_ID:$CHAIN_B_RPC"
+echo "   LOCAL_BRIDGE_RELAYER_KEY=$DEPLOYER_KEY"
+echo ""
+echo "Press Ctrl+C to stop the chains."
+wait
@@ -0,0 +1,54 @@
+#!/bin/bash
+
+# Local Bundler Stand-in
+# ======================
+# Forks a chain that has the ERC-4337 v0.7 EntryPoint into anvil, deploys a
+# SmartAccountV2 owned by the first anvil account, funds it for gas and runs
+# the agent's minimal bundler against the fork, so EXECUTION_BACKEND=userop
+# can be exercised without a real bundler.
+
+set -e
+
+cd "$(dirname "$0")/.."
+
+if [ -z "$FORK_RPC" ]; then
+    echo "❌ FORK_RPC is required: an RPC of a chain with the EntryPoint at 0x0000000071727De22E5E9d8BAf0edAc6f37da032"
+    exit 1
+fi
+
+RPC="http://127.0.0.1:8545"
+BUNDLER_PORT=${BUNDLER_PORT:-4337}
+
+# First default anvil account owns the account; the second submits bundles
+OWNER_KEY=${OWNER_KEY:-0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80}
+BUNDLER_KEY=${BUNDLER_KEY:-0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d}
+OWNER=$(cast wallet address --private-key "$OWNER_KEY")
+
+echo "🚀 Forking $FORK_RPC..."
+anvil --port 8545 --fork-url "$FORK_RPC" --silent &
+ANVIL=$!
+trap 'kill $ANVIL 2>/dev/null' EXIT
+sleep 3
+
+CHAIN_ID=$(cast chain-id --rpc-url "$RPC")
+
+echo "🔨 Deploying SmartAccountV2..."
+ACCOUNT=$(forge create \
+    --rpc-url "$RPC" \
+    --private-key "$OWNER_KEY" \
+    --broadcast \
+    contracts/SmartAccountV2.sol:SmartAccountV2 \
+    --constructor-args "$OWNER" | grep "Deployed to:" | awk '{print $3}')
+
+echo "💰 Funding the account for UserOperation gas..."
+cast send --rpc-url "$RPC" --private-key "$OWNER_KEY" "$ACCOUNT" --value 10ether > /dev/null
+
+echo ""
+echo "✅ Local bundler ready on chain $CHAIN_ID. Point the chain's RPC variable at $RPC and add to your .env:"
+echo "   SMART_ACCOUNT=$ACCOUNT"
+echo "   PRIVATE_KEY=$OWNER_KEY"
+echo "   EXECUTION_BACKEND=userop"
+echo "   BUNDLER_RPCS=$CHAIN_ID:http://127.0.0.1:$BUNDLER_PORT"
+echo ""
+echo "Press Ctrl+C to stop."
+(cd ../agent-v2 && go run ./cmd/localbundler -rpc "$RPC" -key "$BUNDLER_KEY" -listen "127.0.0.1:$BUNDLER_PORT")
@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.20;
+
+import "forge-std/Test.sol";
+import "@openzeppelin/contracts/utils/cryptography/MessageHashUtils.sol";
+import "../contracts/SmartAccountV2.sol";
+
+contract Target {
+    uint256 public value;
+    uint256 public received;
+
+    function store(uint256 newValue) external payable returns (uint256) {
+        value = newValue;
+        received += msg.value;
+        return newValue * 2;
+    }
+
+    function fail() external pure {
+        revert("Target failed");
+    }
+}
+
+contract SmartAccountV2Test is Test {
+    SmartAccountV2 account;
+    Target target;
+
+    uint256 ownerKey = 0xA11CE;
+    uint256 sessionKey = 0xB0B;
+    uint256 strangerKey = 0xE5E;
+    address owner;
+    address session;
+    address stranger;
+    address entryPoint;
+
+    event CallExecuted(uint256 indexed index, address indexed target, bool success, bytes returnData);
+
+    function setUp() public {
+        owner = vm.addr(ownerKey);
+        session = vm.addr(sessionKey);
+        stranger = vm.addr(strangerKey);
+
+        account = new SmartAccountV2(owner);
+        target = new Target();
+        entryPoint = account.ENTRY_POINT();
+    }
+
+    // === executeCalls ===
+
+    function testExecuteCallsRunsCallsInOrder() public {
+        vm.deal(address(account), 1 ether);
+
+        SmartAccountV2.Call[] memory calls = new SmartAccountV2.Call[](2);
+        calls[0] = SmartAccountV2.Call(address(target), 0, abi.encodeCall(Target.store, (1)));
+        calls[1] = SmartAccountV2.Call(address(target), 0.5 ether, abi.encodeCall(Target.store, (2)));
+
+        vm.expectEmit(true, true, false, true, address(account));
+        emit CallExecuted(0, address(target), true, abi.encode(uint256(2)));
+        vm.expectEmit(true, true, false, true, address(account));
+        emit CallExecuted(1, address(target), true, abi.encode(uint256(4)));
+
+        vm.prank(owner);
+        (bool[] memory successes, bytes[] memory results) = account.executeCalls(calls, false);
+
+        assertTrue(successes[0] && successes[1]);
+        assertEq(abi.decode(results[1], (uint256)), 4);
+        assertEq(target.value(), 2);
+        assertEq(target.received(), 0.5 ether);
+        assertEq(address(account).balance, 0.5 ether);
+    }
+
+    function testExecuteCallsRevertsWithFailedIndex() public {
+        SmartAccountV2.Call[] memory calls = new SmartAccountV2.Call[](3);
+        calls[0] = SmartAccountV2.Call(address(target), 0, abi.encodeCall(Target.store, (1)));
+        calls[1] = SmartAccountV2.Call(address(target), 0, abi.encodeCall(Target.fail, ()));
+        calls[2] = SmartAccountV2.Call(address(target), 0, abi.encodeCall(Target.store, (3)));
+
+        vm.expectRevert(
+            abi.encodeWithSelector(
+                SmartAccountV2.CallFailed.selector,
+                1,
+                abi.encodeWithSignature("Error(string)", "Target failed")
+            )
+        );
+        vm.prank(owner);
+        account.executeCalls(calls, false);
+
+        // The whole batch reverted, including the call before the failure
+        assertEq(target.value(), 0);
+    }
+
+    function testExecuteCallsAllowFailureContinues() public {
+        SmartAccountV2.Call[] memory calls = new SmartAccountV2.Call[](2);
+        calls[0] = SmartAccountV2.Call(address(target), 0, abi.encodeCall(Target.fail, ()));
+        calls[1] = SmartAccountV2.Call(address(target), 0, abi.encodeCall(Target.store, (7)));
+
+        vm.expectEmit(true, true, false, true, address(account));
+        emit CallExecuted(
+            0, address(target), false, abi.encodeWithSignature("Error(string)", "Target failed")
+        );
+
+        vm.prank(owner);
+        (bool[] memory successes, ) = account.executeCalls(calls, true);
+
+        assertFalse(successes[0]);
+        assertTrue(successes[1]);
+        assertEq(target.value(), 7);
+    }
+
+    function testExecuteCallsAuthorization() public {
+        SmartAccountV2.Call[] memory calls = new SmartAccountV2.Call[](1);
+        calls[0] = SmartAccountV2.Call(address(target), 0, abi.encodeCall(Target.store, (1)));
+
+        vm.prank(stranger);
+        vm.expectRevert("Unauthorized");
+        account.executeCalls(calls, false);
+
+        vm.prank(owner);
+        account.setSessionKey(session, block.timestamp + 1 hours);
+        vm.prank(session);
+        account.executeCalls(calls, false);
+        assertEq(target.value(), 1);
+
+        vm.warp(block.timestamp + 2 hours);
+        vm.prank(session);
+        vm.expectRevert("Unauthorized");
+        account.executeCalls(calls, false);
+
+        vm.prank(entryPoint);
+        account.executeCalls(calls, false);
+    }
+
+    function testExecuteDCAInBatch() public {
+        vm.prank(owner);
+        uint256 strategyId = account.createDCAStrategy(address(1), address(2), 1e6, 0, 2);
+
+        SmartAccountV2.Call[] memory calls = new SmartAccountV2.Call[](2);
+        calls[0] = SmartAccountV2.Call(address(target), 0, abi.encodeCall(Target.store, (1)));
+        calls[1] = SmartAccountV2.Call(address(account), 0, abi.encodeCall(SmartAccountV2.executeDCA, (strategyId)));
+
+        vm.prank(owner);
+        account.executeCalls(calls, false);
+        assertEq(account.getDCAStrategy(strategyId).totalExecutions, 1);
+
+        // A failed swap leaves the execution unrecorded
+        calls[0] = SmartAccountV2.Call(address(target), 0, abi.encodeCall(Target.fail, ()));
+        vm.prank(owner);
+        vm.expectRevert();
+        account.executeCalls(calls, false);
+        assertEq(account.getDCAStrategy(strategyId).totalExecutions, 1);
+    }
+
+    // === validateUserOp ===
+
+    function testValidateUserOpOnlyEntryPoint() public {
+        bytes32 userOpHash = keccak256("op");
+        SmartAccountV2.PackedUserOperation memory op = userOp(sign(ownerKey, userOpHash));
+
+        vm.prank(owner);
+        vm.expectRevert("Not EntryPoint");
+        account.validateUserOp(op, userOpHash, 0);
+    }
+
+    function testValidateUserOpSigners() public {
+        bytes32 userOpHash = keccak256("op");
+        uint256 expiresAt = block.timestamp + 1 days;
+        vm.prank(owner);
+        account.setSessionKey(session, expiresAt);
+
+        vm.startPrank(entryPoint);
+        assertEq(account.validateUserOp(userOp(sign(ownerKey, userOpHash)), userOpHash, 0), 0);
+        assertEq(
+            account.validateUserOp(userOp(sign(sessionKey, userOpHash)), userOpHash, 0),
+            uint256(uint48(expiresAt)) << 160
+        );
+        assertEq(account.validateUserOp(userOp(sign(strangerKey, userOpHash)), userOpHash, 0), 1);
+        assertEq(account.validateUserOp(userOp(hex"1234"), userOpHash, 0), 1);
+
+        // A signature over a different hash recovers some other address
+        assertEq(account.validateUserOp(userOp(sign(ownerKey, keccak256("other"))), userOpHash, 0), 1);
+        vm.stopPrank();
+    }
+
+    function testValidateUserOpPaysPrefund() public {
+        bytes32 userOpHash = keccak256("op");
+        vm.deal(address(account), 1 ether);
+        uint256 entryPointBalance = entryPoint.balance;
+
+        vm.prank(entryPoint);
+        account.validateUserOp(userOp(sign(ownerKey, userOpHash)), userOpHash, 0.1 ether);
+
+        assertEq(entryPoint.balance, entryPointBalance + 0.1 ether);
+        assertEq(address(account).balance, 0.9 ether);
+    }
+
+    function userOp(bytes memory signature) internal view returns (SmartAccountV2.PackedUserOperation memory op) {
+        op.sender = address(account);
+        op.signature = signature;
+    }
+
+    function sign(uint256 key, bytes32 userOpHash) internal pure returns (bytes memory) {
+        (uint8 v, bytes32 r, bytes32 s) = vm.sign(key, MessageHashUtils.toEthSignedMessageHash(userOpHash));
+        return abi.encodePacked(r, s, v);
+    }
+}
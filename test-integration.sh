@@ -126,6 +126,19 @@ fi
 
 cd ..
 
+# Test DEX aggregator adapters against recorded responses
+echo -e "\n${BLUE}💱 Testing DEX Aggregator Adapters...${NC}"
+
+cd agent-v2
+
+if go test ./dex/ > /dev/null 2>&1; then
+    test_passed "Aggregator adapters match recorded fixtures"
+else
+    test_failed "Aggregator fixture tests failed (run: cd agent-v2 && go test ./dex/)"
+fi
+
+cd ..
+
 # Test environment configuration
 echo -e "\n${BLUE}⚙️  Testing Environment Configuration...${NC}"
 
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -111,6 +112,8 @@ type DashboardServer struct {
 	upgrader   websocket.Upgrader
 	data       *DashboardData
 	ethClients map[uint64]*ethclient.Client
+	agentAPI   string // base URL of the agent's history API; empty keeps the mock portfolio change
+	httpClient *http.Client
 }
 
 func NewDashboardServer() *DashboardServer {
@@ -125,6 +128,8 @@ func NewDashboardServer() *DashboardServer {
 		upgrader:   upgrader,
 		data:       initializeMockData(),
 		ethClients: make(map[uint64]*ethclient.Client),
+		agentAPI:   strings.TrimSuffix(os.Getenv("AGENT_API_URL"), "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
 	}
 }
 
@@ -246,6 +251,33 @@ func (s *DashboardServer) updateChainData() {
 	}
 }
 
+// updatePerformance takes the portfolio change from the agent's time-weighted return
+func (s *DashboardServer) updatePerformance() {
+	if s.agentAPI == "" {
+		return
+	}
+
+	resp, err := s.httpClient.Get(s.agentAPI + "/api/performance")
+	if err != nil {
+		log.Printf("Failed to get performance from agent: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("Agent performance API returned HTTP %d", resp.StatusCode)
+		return
+	}
+
+	var report struct {
+		TotalChange string `json:"totalChange"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		log.Printf("Failed to decode agent performance: %v", err)
+		return
+	}
+	s.data.Portfolio.TotalChange = report.TotalChange
+}
+
 func (s *DashboardServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	conn, err := s.upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -427,6 +459,7 @@ func (s *DashboardServer) startPeriodicUpdates() {
 	go func() {
 		for range ticker.C {
 			s.updateChainData()
+			s.updatePerformance()
 			s.data.LastUpdated = time.Now()
 
 			// Simulate some data changes
@@ -470,6 +503,7 @@ func main() {
 
 	server := NewDashboardServer()
 	server.initializeEthClients()
+	server.updatePerformance()
 	server.startPeriodicUpdates()
 
 	r := mux.NewRouter()